@@ -0,0 +1,353 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	//BalanceFetcher fetch balances snapshot, which is implemented by rest clients
+	BalanceFetcher interface {
+		FetchBalance(ctx context.Context, currencies ...string) (*Balances, error)
+	}
+
+	//PositionFetcher fetch positions snapshot, all positions are returned if no symbol specified
+	PositionFetcher interface {
+		FetchPosition(ctx context.Context, syms ...Symbol) ([]*Position, error)
+	}
+
+	//OpenOrderFetcher fetch open orders snapshot of the symbol
+	OpenOrderFetcher interface {
+		FetchOpenOrders(ctx context.Context, sym Symbol) ([]*Order, error)
+	}
+
+	//OrderUpdater notify which carry order updates along with other changes
+	OrderUpdater interface {
+		OrderUpdates() []*Order
+	}
+
+	//PositionUpdater notify which carry position updates along with other changes
+	PositionUpdater interface {
+		PositionUpdates() []*Position
+	}
+
+	//AccountState consistent view of balances, positions and open orders of an account.
+	//private stream should be subscribed and routed to Handle before Bootstrap, events
+	//are buffered until the snapshots taken and replayed if received after the snapshot.
+	//call Reset and Bootstrap again after the private stream reconnected
+	AccountState struct {
+		mu       sync.Mutex
+		hedge    bool
+		ready    bool
+		overflow bool
+
+		balanceFetcher  BalanceFetcher
+		positionFetcher PositionFetcher
+		orderFetcher    OpenOrderFetcher
+		orderSymbols    []Symbol
+
+		balances  *LiveBalances
+		positions map[string]*Position
+		orders    map[string]*Order
+		finished  map[string]time.Time //tombstones of finished orders
+
+		balanceTS  time.Time
+		positionTS time.Time
+		orderTS    time.Time
+		buffer     []accountEvent
+	}
+
+	accountEvent struct {
+		data interface{}
+		recv time.Time
+	}
+)
+
+const (
+	//MaxAccountEvents max number of events buffered before bootstrapped
+	MaxAccountEvents = 10000
+
+	//finishedOrderTTL how long finished order id is kept to drop late updates
+	finishedOrderTTL = 10 * time.Minute
+)
+
+var (
+	//ErrAccountEventsOverflow too many events received before bootstrapped
+	ErrAccountEventsOverflow = errors.New("account events buffer overflow")
+)
+
+//NewAccountState create account state, in hedge mode long and short position of a
+//symbol are kept separately, otherwise the latest position of the symbol is kept
+func NewAccountState(hedge bool) *AccountState {
+	return &AccountState{
+		hedge:     hedge,
+		balances:  NewLiveBalances(nil),
+		positions: make(map[string]*Position),
+		orders:    make(map[string]*Order),
+		finished:  make(map[string]time.Time),
+	}
+}
+
+//WithBalance take balances snapshot with the fetcher
+func (as *AccountState) WithBalance(fetcher BalanceFetcher) *AccountState {
+	as.balanceFetcher = fetcher
+	return as
+}
+
+//WithPosition take positions snapshot with the fetcher
+func (as *AccountState) WithPosition(fetcher PositionFetcher) *AccountState {
+	as.positionFetcher = fetcher
+	return as
+}
+
+//WithOpenOrders take open orders snapshot of the symbols with the fetcher
+func (as *AccountState) WithOpenOrders(fetcher OpenOrderFetcher, syms ...Symbol) *AccountState {
+	as.orderFetcher = fetcher
+	as.orderSymbols = syms
+	return as
+}
+
+//Bootstrap take snapshots and replay buffered events received after each snapshot
+//started. events are applied directly once bootstrapped. the buffer is cleared if
+//fail, since the events are older than the snapshots of the next try
+func (as *AccountState) Bootstrap(ctx context.Context) error {
+	if err := as.bootstrap(ctx); err != nil {
+		as.mu.Lock()
+		as.buffer = nil
+		as.overflow = false
+		as.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (as *AccountState) bootstrap(ctx context.Context) error {
+	if as.balanceFetcher != nil {
+		ts := time.Now()
+		balances, err := as.balanceFetcher.FetchBalance(ctx)
+		if err != nil {
+			return errors.WithMessage(err, "fetch balance fail")
+		}
+
+		as.mu.Lock()
+		as.balances.Reset(balances)
+		as.balanceTS = ts
+		as.mu.Unlock()
+	}
+
+	if as.positionFetcher != nil {
+		ts := time.Now()
+		positions, err := as.positionFetcher.FetchPosition(ctx)
+		if err != nil {
+			return errors.WithMessage(err, "fetch position fail")
+		}
+
+		as.mu.Lock()
+		as.positions = make(map[string]*Position)
+		for _, p := range positions {
+			as.updatePosition(p)
+		}
+		as.positionTS = ts
+		as.mu.Unlock()
+	}
+
+	if as.orderFetcher != nil {
+		ts := time.Now()
+		var orders []*Order
+		for _, sym := range as.orderSymbols {
+			o, err := as.orderFetcher.FetchOpenOrders(ctx, sym)
+			if err != nil {
+				return errors.WithMessagef(err, "fetch open orders of %s fail", sym.String())
+			}
+			orders = append(orders, o...)
+		}
+
+		as.mu.Lock()
+		as.orders = make(map[string]*Order)
+		for _, o := range orders {
+			as.updateOrder(o)
+		}
+		as.orderTS = ts
+		as.mu.Unlock()
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	if as.overflow {
+		return errors.WithMessagef(ErrAccountEventsOverflow, "more than %d events", MaxAccountEvents)
+	}
+	for _, ev := range as.buffer {
+		as.apply(ev.data, ev.recv)
+	}
+	as.buffer = nil
+	as.ready = true
+	return nil
+}
+
+//Handle apply notify of private stream, which is buffered before bootstrapped
+func (as *AccountState) Handle(data interface{}) {
+	if notify, ok := data.(*WSNotify); ok {
+		data = notify.Data
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	if !as.ready {
+		if len(as.buffer) >= MaxAccountEvents {
+			as.overflow = true
+			return
+		}
+		as.buffer = append(as.buffer, accountEvent{data: data, recv: time.Now()})
+		return
+	}
+	as.apply(data, time.Now())
+}
+
+//Reset mark the state stale, events are buffered until Bootstrap again. it should
+//be called once the private stream reconnected since events may be missing
+func (as *AccountState) Reset() {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	as.ready = false
+	as.overflow = false
+	as.buffer = nil
+}
+
+//Ready whether the snapshots are taken
+func (as *AccountState) Ready() bool {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+	return as.ready
+}
+
+func (as *AccountState) Balances() *Balances {
+	return as.balances.Snapshot()
+}
+
+func (as *AccountState) Balance(currency string) (*Balance, error) {
+	return as.balances.Get(currency)
+}
+
+//Positions return copy of positions, closed positions are removed
+func (as *AccountState) Positions() []*Position {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	ret := make([]*Position, 0, len(as.positions))
+	for _, p := range as.positions {
+		c := *p
+		ret = append(ret, &c)
+	}
+	return ret
+}
+
+//OpenOrders return copy of open orders
+func (as *AccountState) OpenOrders() []*Order {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	ret := make([]*Order, 0, len(as.orders))
+	for _, o := range as.orders {
+		c := *o
+		ret = append(ret, &c)
+	}
+	return ret
+}
+
+//apply changes carried by data which received at recv, the part older than its
+//snapshot is skipped
+func (as *AccountState) apply(data interface{}, recv time.Time) {
+	if !recv.Before(as.balanceTS) {
+		as.balances.Handle(data)
+	}
+
+	if !recv.Before(as.positionTS) {
+		for _, p := range positionUpdates(data) {
+			as.updatePosition(p)
+		}
+	}
+
+	if !recv.Before(as.orderTS) {
+		for _, o := range orderUpdates(data) {
+			as.updateOrder(o)
+		}
+	}
+}
+
+func (as *AccountState) updatePosition(p *Position) {
+	if p.Symbol == nil {
+		return
+	}
+
+	key := p.Symbol.String()
+	if as.hedge {
+		key += "/" + p.Side.String()
+	}
+
+	if p.Position.IsZero() {
+		delete(as.positions, key)
+		return
+	}
+	as.positions[key] = p
+}
+
+func (as *AccountState) updateOrder(o *Order) {
+	if o.ID == nil {
+		return
+	}
+
+	key := o.ID.String()
+	if _, ok := as.finished[key]; ok {
+		//late update of finished order
+		return
+	}
+	if old, ok := as.orders[key]; ok && o.Updated.Before(old.Updated) {
+		return
+	}
+
+	switch o.Status {
+	case OrderStatusDone, OrderStatusCancel, OrderStatusFailed:
+		delete(as.orders, key)
+		as.finishOrder(key)
+		return
+	}
+	as.orders[key] = o
+}
+
+//finishOrder keep tombstone of the order and drop the expired ones
+func (as *AccountState) finishOrder(key string) {
+	now := time.Now()
+	for k, ts := range as.finished {
+		if now.Sub(ts) > finishedOrderTTL {
+			delete(as.finished, k)
+		}
+	}
+	as.finished[key] = now
+}
+
+func positionUpdates(data interface{}) []*Position {
+	switch t := data.(type) {
+	case *Position:
+		return []*Position{t}
+	case []*Position:
+		return t
+	case PositionUpdater:
+		return t.PositionUpdates()
+	}
+	return nil
+}
+
+func orderUpdates(data interface{}) []*Order {
+	switch t := data.(type) {
+	case *Order:
+		return []*Order{t}
+	case []*Order:
+		return t
+	case OrderUpdater:
+		return t.OrderUpdates()
+	}
+	return nil
+}
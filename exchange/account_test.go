@@ -0,0 +1,124 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	testSymbol struct {
+		*BaseSwapSymbol
+	}
+
+	testFetcher struct {
+		state *AccountState
+		sym   Symbol
+	}
+)
+
+func (ts *testSymbol) String() string {
+	return "BTC-SWAP"
+}
+
+func (tf *testFetcher) FetchBalance(ctx context.Context, currencies ...string) (*Balances, error) {
+	ret := NewBalances()
+	ret.Add(&Balance{Currency: "BTC", Total: decimal.NewFromInt(1), Free: decimal.NewFromInt(1)})
+	return ret, nil
+}
+
+func (tf *testFetcher) FetchPosition(ctx context.Context, syms ...Symbol) ([]*Position, error) {
+	return []*Position{{Symbol: tf.sym, Side: PositionSideLong, Position: decimal.NewFromInt(1)}}, nil
+}
+
+func (tf *testFetcher) FetchOpenOrders(ctx context.Context, sym Symbol) ([]*Order, error) {
+	orders := []*Order{{ID: NewStrID("1"), Symbol: sym, Status: OrderStatusOpen}}
+	//order filled after the snapshot started
+	tf.state.Handle(&Order{ID: NewStrID("1"), Symbol: sym, Status: OrderStatusDone, Updated: time.Now()})
+	return orders, nil
+}
+
+func TestAccountState(t *testing.T) {
+	sym := &testSymbol{NewBaseSwapSymbol("BTC")}
+	state := NewAccountState(false)
+	fetcher := &testFetcher{state: state, sym: sym}
+	state.WithBalance(fetcher).WithPosition(fetcher).WithOpenOrders(fetcher, sym)
+
+	//received before snapshots, superseded by them
+	state.Handle(&WSNotify{Data: []*Position{{Symbol: sym, Side: PositionSideShort, Position: decimal.NewFromInt(3)}}})
+	state.Handle(&Order{ID: NewStrID("2"), Symbol: sym, Status: OrderStatusOpen})
+	if len(state.OpenOrders()) != 0 {
+		t.Errorf("events should be buffered before bootstrap")
+	}
+
+	if err := state.Bootstrap(context.Background()); err != nil {
+		t.Fatalf("bootstrap fail %s", err.Error())
+	}
+
+	if orders := state.OpenOrders(); len(orders) != 0 {
+		t.Errorf("bad open orders %+v", orders)
+	}
+
+	positions := state.Positions()
+	if len(positions) != 1 || positions[0].Side != PositionSideLong {
+		t.Errorf("bad positions %+v", positions)
+	}
+
+	state.Handle(NewBalanceUpdate(&Balance{Currency: "BTC", Total: decimal.NewFromInt(2)}, BalanceFieldTotal, BalanceReasonTrade, time.Now(), nil))
+	state.Handle([]*Position{{Symbol: sym, Side: PositionSideShort, Position: decimal.Zero}})
+	if b, err := state.Balance("BTC"); err != nil || !b.Total.Equal(decimal.NewFromInt(2)) || !b.Free.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("bad balance %+v", b)
+	}
+	if positions := state.Positions(); len(positions) != 0 {
+		t.Errorf("position should be closed %+v", positions)
+	}
+}
+
+type failFetcher struct {
+	fail bool
+}
+
+func (ff *failFetcher) FetchBalance(ctx context.Context, currencies ...string) (*Balances, error) {
+	if ff.fail {
+		return nil, errors.New("fetch fail")
+	}
+	return NewBalances(), nil
+}
+
+func TestAccountStateReset(t *testing.T) {
+	sym := &testSymbol{NewBaseSwapSymbol("BTC")}
+	fetcher := &failFetcher{fail: true}
+	state := NewAccountState(false).WithBalance(fetcher)
+
+	state.Handle(&Order{ID: NewStrID("1"), Symbol: sym, Status: OrderStatusOpen})
+	if err := state.Bootstrap(context.Background()); err == nil || len(state.buffer) != 0 {
+		t.Errorf("buffer should be cleared when bootstrap fail %v", err)
+	}
+
+	for i := 0; i <= MaxAccountEvents; i++ {
+		state.Handle(&Order{ID: NewStrID("1"), Symbol: sym, Status: OrderStatusOpen})
+	}
+	fetcher.fail = false
+	if err := state.Bootstrap(context.Background()); !errors.Is(err, ErrAccountEventsOverflow) || state.Ready() {
+		t.Errorf("bootstrap should fail when buffer overflow %v", err)
+	}
+	if err := state.Bootstrap(context.Background()); err != nil || !state.Ready() {
+		t.Fatalf("bootstrap fail %v", err)
+	}
+
+	state.Handle(&Order{ID: NewStrID("2"), Symbol: sym, Status: OrderStatusOpen, Updated: time.Now()})
+	state.Handle(&Order{ID: NewStrID("2"), Symbol: sym, Status: OrderStatusDone, Updated: time.Now()})
+	state.Handle(&Order{ID: NewStrID("2"), Symbol: sym, Status: OrderStatusOpen, Updated: time.Now()})
+	if orders := state.OpenOrders(); len(orders) != 0 {
+		t.Errorf("late update of finished order should be dropped %+v", orders)
+	}
+
+	state.Reset()
+	state.Handle(&Order{ID: NewStrID("3"), Symbol: sym, Status: OrderStatusOpen})
+	if state.Ready() || len(state.OpenOrders()) != 0 {
+		t.Errorf("events should be buffered after reset")
+	}
+}
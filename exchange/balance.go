@@ -2,6 +2,8 @@ package exchange
 
 import (
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
@@ -20,6 +22,50 @@ type (
 		Balances map[string]*Balance
 		Raw      interface{}
 	}
+
+	//BalanceReason why the balance changed
+	BalanceReason int
+
+	//BalanceField fields of Balance carried by BalanceUpdate
+	BalanceField int
+
+	//BalanceUpdate latest balance of a currency pushed by private stream. Fields is the
+	//fields pushed by the exchange, others should be kept. zero Fields means all fields
+	BalanceUpdate struct {
+		Balance
+		Fields BalanceField
+		Reason BalanceReason
+		Time   time.Time
+		Raw    interface{}
+	}
+
+	//BalanceUpdater notify which carry balance updates along with other changes
+	BalanceUpdater interface {
+		BalanceUpdates() []*BalanceUpdate
+	}
+
+	//LiveBalances Balances maintained by BalanceUpdate, it's safe for concurrent use
+	LiveBalances struct {
+		mu       sync.RWMutex
+		balances *Balances
+		updated  map[string]time.Time
+	}
+)
+
+const (
+	BalanceReasonOther BalanceReason = iota
+	BalanceReasonTrade
+	BalanceReasonFunding
+	BalanceReasonTransfer
+)
+
+const (
+	BalanceFieldTotal BalanceField = 1 << iota
+	BalanceFieldFree
+	BalanceFieldFrozen
+	BalanceFieldEquity
+
+	BalanceFieldAll = BalanceFieldTotal | BalanceFieldFree | BalanceFieldFrozen | BalanceFieldEquity
 )
 
 //CurrencyFormat common method which used to transfer currency to uniq format
@@ -37,7 +83,7 @@ func NewBalances() *Balances {
 func (b *Balances) Add(balance *Balance) {
 	new := *balance
 	new.Currency = CurrencyFormat(balance.Currency)
-	b.Balances[balance.Currency] = &new
+	b.Balances[new.Currency] = &new
 }
 
 //Get return balance for specific token(upper case)
@@ -49,3 +95,145 @@ func (b *Balances) Get(currency string) (*Balance, error) {
 
 	return r, nil
 }
+
+func (br BalanceReason) String() string {
+	switch br {
+	case BalanceReasonTrade:
+		return "trade"
+	case BalanceReasonFunding:
+		return "funding"
+	case BalanceReasonTransfer:
+		return "transfer"
+	default:
+		return "other"
+	}
+}
+
+//NewBalanceUpdate build update from balance, the currency is formatted
+func NewBalanceUpdate(balance *Balance, fields BalanceField, reason BalanceReason, ts time.Time, raw interface{}) *BalanceUpdate {
+	ret := &BalanceUpdate{
+		Balance: *balance,
+		Fields:  fields,
+		Reason:  reason,
+		Time:    ts,
+		Raw:     raw,
+	}
+	ret.Currency = CurrencyFormat(balance.Currency)
+	return ret
+}
+
+//Has whether the field is pushed
+func (bu *BalanceUpdate) Has(field BalanceField) bool {
+	return bu.Fields == 0 || bu.Fields&field != 0
+}
+
+//NewLiveBalances create LiveBalances from snapshot, which can be nil
+func NewLiveBalances(snapshot *Balances) *LiveBalances {
+	ret := &LiveBalances{}
+	ret.Reset(snapshot)
+	return ret
+}
+
+//Reset replace balances with the snapshot
+func (lb *LiveBalances) Reset(snapshot *Balances) {
+	balances := NewBalances()
+	if snapshot != nil {
+		for _, b := range snapshot.Balances {
+			balances.Add(b)
+		}
+	}
+
+	lb.mu.Lock()
+	lb.balances = balances
+	lb.updated = make(map[string]time.Time)
+	lb.mu.Unlock()
+}
+
+//Apply merge updates into balances, update older than the last applied one of the
+//currency is skipped, update without time is always applied. return number of updates applied
+func (lb *LiveBalances) Apply(updates ...*BalanceUpdate) int {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	var ret int
+	for _, u := range updates {
+		currency := CurrencyFormat(u.Currency)
+		if last, ok := lb.updated[currency]; ok && u.Time.Before(last) && !u.Time.IsZero() {
+			continue
+		}
+
+		b, ok := lb.balances.Balances[currency]
+		if !ok {
+			b = &Balance{Currency: currency}
+			lb.balances.Balances[currency] = b
+		}
+
+		if u.Has(BalanceFieldTotal) {
+			b.Total = u.Total
+		}
+		if u.Has(BalanceFieldFree) {
+			b.Free = u.Free
+		}
+		if u.Has(BalanceFieldFrozen) {
+			b.Frozen = u.Frozen
+		}
+		if u.Has(BalanceFieldEquity) {
+			b.Equitity = u.Equitity
+		}
+		if !u.Time.IsZero() {
+			lb.updated[currency] = u.Time
+		}
+		ret++
+	}
+	return ret
+}
+
+//Handle apply balance updates carried by notify data, which can be *WSNotify,
+//*BalanceUpdate, []*BalanceUpdate, BalanceUpdater or *Balances with all fields
+//pushed. others are ignored
+func (lb *LiveBalances) Handle(data interface{}) int {
+	if notify, ok := data.(*WSNotify); ok {
+		data = notify.Data
+	}
+
+	switch t := data.(type) {
+	case *BalanceUpdate:
+		return lb.Apply(t)
+	case []*BalanceUpdate:
+		return lb.Apply(t...)
+	case BalanceUpdater:
+		return lb.Apply(t.BalanceUpdates()...)
+	case *Balances:
+		var updates []*BalanceUpdate
+		for _, b := range t.Balances {
+			updates = append(updates, NewBalanceUpdate(b, BalanceFieldAll, BalanceReasonOther, time.Time{}, t.Raw))
+		}
+		return lb.Apply(updates...)
+	}
+	return 0
+}
+
+//Get return copy of balance for specific currency
+func (lb *LiveBalances) Get(currency string) (*Balance, error) {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	b, err := lb.balances.Get(currency)
+	if err != nil {
+		return nil, err
+	}
+	ret := *b
+	return &ret, nil
+}
+
+//Snapshot return copy of current balances
+func (lb *LiveBalances) Snapshot() *Balances {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	ret := NewBalances()
+	for _, b := range lb.balances.Balances {
+		ret.Add(b)
+	}
+	return ret
+}
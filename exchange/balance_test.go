@@ -0,0 +1,31 @@
+package exchange
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestLiveBalances(t *testing.T) {
+	snapshot := NewBalances()
+	snapshot.Add(&Balance{Currency: "btc", Total: decimal.NewFromInt(2), Free: decimal.NewFromInt(1), Frozen: decimal.NewFromInt(1)})
+	lb := NewLiveBalances(snapshot)
+
+	ts := time.Now()
+	partial := NewBalanceUpdate(&Balance{Currency: "BTC", Total: decimal.NewFromInt(3)}, BalanceFieldTotal, BalanceReasonTransfer, ts, nil)
+	stale := NewBalanceUpdate(&Balance{Currency: "BTC", Total: decimal.NewFromInt(5)}, BalanceFieldAll, BalanceReasonTrade, ts.Add(-time.Second), nil)
+	if n := lb.Handle(&WSNotify{Data: []*BalanceUpdate{partial, stale}}); n != 1 {
+		t.Errorf("bad applied %d", n)
+	}
+
+	b, err := lb.Get("btc")
+	if err != nil || !b.Total.Equal(decimal.NewFromInt(3)) || !b.Free.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("bad balance %+v", b)
+	}
+
+	lb.Apply(NewBalanceUpdate(&Balance{Currency: "eth", Free: decimal.NewFromInt(1)}, BalanceFieldAll, BalanceReasonOther, ts, nil))
+	if s := lb.Snapshot(); len(s.Balances) != 2 || s.Balances["ETH"] == nil {
+		t.Errorf("bad snapshot %+v", s.Balances)
+	}
+}
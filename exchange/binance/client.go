@@ -23,6 +23,7 @@ type (
 		key     string
 		secret  string
 		apiHost string
+		limiter *request.Limiter
 	}
 
 	//RestReq basic binance rest request instance add recvWindow param support
@@ -40,6 +41,7 @@ func NewRestClient(key, secret, host string) *RestClient {
 		key:     key,
 		secret:  secret,
 		apiHost: host,
+		limiter: SharedLimiter(host),
 	}
 	return ret
 }
@@ -83,7 +85,7 @@ func (rc *RestClient) request(ctx context.Context, method, endPoint string, para
 	if err != nil {
 		return err
 	}
-	rerr := request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
+	rerr := request.DoWithLimiter(rc.limiter, req, func(resp *http.Response, ierr error) error {
 		if ierr != nil {
 			return ierr
 		}
@@ -93,13 +95,25 @@ func (rc *RestClient) request(ctx context.Context, method, endPoint string, para
 		}
 		defer resp.Body.Close()
 
+		//error response carry code and msg with 4XX or 5XX status
+		if resp.StatusCode >= http.StatusBadRequest {
+			var ae APIError
+			if err := json.Unmarshal(content, &ae); err == nil && ae.Code != 0 {
+				return &ae
+			}
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot {
+				return errors.WithMessagef(exchange.ErrRateLimited, "http status code=%d", resp.StatusCode)
+			}
+			return errors.Errorf("bad http status code=%d body=%s", resp.StatusCode, string(content))
+		}
+
 		if err := json.Unmarshal(content, dst); err != nil {
 			return err
 		}
 
 		if api, ok := dst.(APIIF); ok {
 			if code := api.ECode(); code != 0 {
-				return errors.Errorf("api error code=%d message=%s", api.ECode(), api.EMessage())
+				return &APIError{Code: code, Message: api.EMessage()}
 			}
 		}
 
@@ -12,3 +12,17 @@ func TestHash(t *testing.T) {
 		t.Errorf("unequal signature %s", sig)
 	}
 }
+
+func TestSharedLimiter(t *testing.T) {
+	spot := NewRestClient("", "", "api.binance.com")
+	margin := NewRestClient("", "", "api.binance.com")
+	if spot.Limiter() == nil || spot.Limiter() != margin.Limiter() {
+		t.Errorf("spot and margin clients should share limiter")
+	}
+	if NewRestClient("", "", "fapi.binance.com").Limiter() == spot.Limiter() {
+		t.Errorf("swap client should not share spot limiter")
+	}
+	if NewLimiter("vapi.binance.com") == nil || NewLimiter("testnet.binancefuture.com") == nil {
+		t.Errorf("unknown host should fallback to spot limiter")
+	}
+}
@@ -0,0 +1,220 @@
+package delivery
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/exchange/binance/swap"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	AccountAsset struct {
+		Asset                  string          `json:"asset"`
+		WalletBalance          decimal.Decimal `json:"walletBalance"`
+		UnrealizedProfit       decimal.Decimal `json:"unrealizedProfit"`
+		MarginBalance          decimal.Decimal `json:"marginBalance"`
+		MaintMargin            decimal.Decimal `json:"maintMargin"`
+		InitialMargin          decimal.Decimal `json:"initialMargin"`
+		PositionInitialMargin  decimal.Decimal `json:"positionInitialMargin"`
+		OpenOrderInitialMargin decimal.Decimal `json:"openOrderInitialMargin"`
+		MaxWithdrawAmount      decimal.Decimal `json:"maxWithdrawAmount"`
+		CrossWalletBalance     decimal.Decimal `json:"crossWalletBalance"`
+		CrossUnPNL             decimal.Decimal `json:"crossUnPnl"`
+		AvailableBalance       decimal.Decimal `json:"availableBalance"`
+		UpdateTime             int64           `json:"updateTime"`
+	}
+
+	AccountResp struct {
+		binance.APIError                //in case of error
+		FeeTier          int            `json:"feeTier"`
+		CanTrade         bool           `json:"canTrade"`
+		CanDeposit       bool           `json:"canDeposit"`
+		CanWithdraw      bool           `json:"canWithdraw"`
+		UpdateTime       int64          `json:"updateTime"`
+		Assets           []AccountAsset `json:"assets"`
+	}
+
+	PositionRisk struct {
+		Symbol           string          `json:"symbol"`
+		PositionAmt      decimal.Decimal `json:"positionAmt"`
+		EntryPrice       decimal.Decimal `json:"entryPrice"`
+		MarkPrice        decimal.Decimal `json:"markPrice"`
+		UnRealizedProfit decimal.Decimal `json:"unRealizedProfit"`
+		LiquidationPrice decimal.Decimal `json:"liquidationPrice"`
+		Leverage         decimal.Decimal `json:"leverage"`
+		MaxQty           decimal.Decimal `json:"maxQty"`
+		MarginType       string          `json:"marginType"`
+		IsolatedMargin   decimal.Decimal `json:"isolatedMargin"`
+		IsAutoAddMargin  string          `json:"isAutoAddMargin"`
+		PositionSide     string          `json:"positionSide"`
+		NotionalValue    decimal.Decimal `json:"notionalValue"`
+		IsolatedWallet   decimal.Decimal `json:"isolatedWallet"`
+		UpdateTime       int64           `json:"updateTime"`
+	}
+
+	AccountReq struct {
+		*binance.RestReq
+	}
+
+	PositionRiskReq struct {
+		*binance.RestReq
+	}
+)
+
+const (
+	AccountEndPoint      = "/dapi/v1/account"
+	PositionRiskEndPoint = "/dapi/v1/positionRisk"
+
+	MarginTypeIsolated = "isolated"
+	MarginTypeCross    = "cross"
+)
+
+func NewAccountReq() *AccountReq {
+	return &AccountReq{
+		RestReq: binance.NewRestReq(),
+	}
+}
+
+// NewPositionRiskReq query position of the pair, eg BTCUSD. all positions are returned if pair is empty
+func NewPositionRiskReq(pair string) *PositionRiskReq {
+	req := binance.NewRestReq()
+	if pair != "" {
+		req.AddFields("pair", pair)
+	}
+	return &PositionRiskReq{
+		RestReq: req,
+	}
+}
+
+func (rc *RestClient) Account(ctx context.Context, req *AccountReq) (*AccountResp, error) {
+	var ret AccountResp
+	if err := rc.GetRequest(ctx, AccountEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get account fail")
+	}
+
+	return &ret, nil
+}
+
+func (rc *RestClient) PositionRisk(ctx context.Context, req *PositionRiskReq) ([]PositionRisk, error) {
+	var ret []PositionRisk
+	if err := rc.GetRequest(ctx, PositionRiskEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get position risk fail")
+	}
+
+	return ret, nil
+}
+
+// FetchBalance return balance of the margin assets, all assets are returned if currencies is empty
+func (rc *RestClient) FetchBalance(ctx context.Context, currencies ...string) (*exchange.Balances, error) {
+	resp, err := rc.Account(ctx, NewAccountReq())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := make(map[string]struct{}, len(currencies))
+	for _, c := range currencies {
+		filter[exchange.CurrencyFormat(c)] = struct{}{}
+	}
+
+	ret := exchange.NewBalances()
+	for i := range resp.Assets {
+		as := &resp.Assets[i]
+		if _, ok := filter[exchange.CurrencyFormat(as.Asset)]; len(filter) != 0 && !ok {
+			continue
+		}
+		ret.Add(as.Transform())
+	}
+	ret.Raw = resp
+	return ret, nil
+}
+
+// FetchPosition return non-empty positions of the symbols, all positions are returned if sym is empty
+func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol) ([]*exchange.Position, error) {
+	resp, err := rc.PositionRisk(ctx, NewPositionRiskReq(""))
+	if err != nil {
+		return nil, err
+	}
+
+	filter := make(map[string]struct{}, len(sym))
+	for _, s := range sym {
+		filter[s.String()] = struct{}{}
+	}
+
+	var ret []*exchange.Position
+	for i := range resp {
+		pr := &resp[i]
+		if pr.PositionAmt.IsZero() {
+			continue
+		}
+
+		if _, ok := filter[pr.Symbol]; len(filter) != 0 && !ok {
+			continue
+		}
+
+		pos, err := pr.Transform()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, pos)
+	}
+	return ret, nil
+}
+
+func (as *AccountAsset) Transform() *exchange.Balance {
+	return &exchange.Balance{
+		Currency: as.Asset,
+		Equitity: as.MarginBalance,
+		Total:    as.WalletBalance,
+		Free:     as.AvailableBalance,
+		Frozen:   as.InitialMargin,
+	}
+}
+
+// Transform position risk into exchange.Position, position is the number of contracts
+func (pr *PositionRisk) Transform() (*exchange.Position, error) {
+	symbol, err := ParseSymbol(pr.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &exchange.Position{
+		Symbol:           symbol,
+		Mode:             exchange.PositionModeCross,
+		LiquidationPrice: pr.LiquidationPrice,
+		AvgOpenPrice:     pr.EntryPrice,
+		CreateTime:       tconv.Milli2Time(pr.UpdateTime),
+		Position:         pr.PositionAmt.Abs(),
+		AvailPosition:    pr.PositionAmt.Abs(),
+		UNRealizedPNL:    pr.UnRealizedProfit,
+		Leverage:         pr.Leverage,
+		Raw:              pr,
+	}
+
+	if pr.MarginType == MarginTypeIsolated {
+		ret.Mode = exchange.PositionModeFixed
+		ret.Margin = pr.IsolatedMargin
+	}
+
+	switch pr.PositionSide {
+	case swap.PositionSideLong:
+		ret.Side = exchange.PositionSideLong
+
+	case swap.PositionSideShort:
+		ret.Side = exchange.PositionSideShort
+
+	case swap.PositionSideBoth:
+		if pr.PositionAmt.IsNegative() {
+			ret.Side = exchange.PositionSideShort
+		} else {
+			ret.Side = exchange.PositionSideLong
+		}
+
+	default:
+		return nil, errors.Errorf("unknown positionSide=%s", pr.PositionSide)
+	}
+	return ret, nil
+}
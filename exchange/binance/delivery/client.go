@@ -0,0 +1,36 @@
+package delivery
+
+import (
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/exchange/binance/swap"
+)
+
+type (
+	//RestClient binance coin-m futures rest client
+	RestClient struct {
+		*binance.RestClient
+		side   *swap.GetPositionSideResp
+		wsAddr string
+	}
+)
+
+const (
+	DeliveryAPIHost     string = "dapi.binance.com"
+	DeliveryTestAPIHost string = "testnet.binancefuture.com"
+	DeliveryWSHost      string = "dstream.binance.com"
+	DeliveryTestWSHost  string = "dstream.binancefuture.com"
+)
+
+func NewRestClient(key, secret string) *RestClient {
+	return &RestClient{
+		RestClient: binance.NewRestClient(key, secret, DeliveryAPIHost),
+		wsAddr:     DeliveryWSHost,
+	}
+}
+
+func NewTestRestClient(key, secret string) *RestClient {
+	return &RestClient{
+		RestClient: binance.NewRestClient(key, secret, DeliveryTestAPIHost),
+		wsAddr:     DeliveryTestWSHost,
+	}
+}
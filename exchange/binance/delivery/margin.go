@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance/swap"
+)
+
+const (
+	LeveragePath   = "/dapi/v1/leverage"
+	MarginTypePath = "/dapi/v1/marginType"
+)
+
+func (rc *RestClient) SetLeverageRaw(ctx context.Context, req *swap.SetLeverageReq) (*swap.SetLeverageResp, error) {
+	return swap.SetLeverage(ctx, rc.RestClient, LeveragePath, req)
+}
+
+func (rc *RestClient) SetMarginType(ctx context.Context, req *swap.SetMarginTypeReq) error {
+	return swap.SetMarginType(ctx, rc.RestClient, MarginTypePath, req)
+}
+
+// SetLeverage set leverage of the symbol, the leverage should be integer
+func (rc *RestClient) SetLeverage(ctx context.Context, sym exchange.Symbol, leverage decimal.Decimal) error {
+	if !leverage.Equal(leverage.Truncate(0)) {
+		return exchange.NewBadArg("leverage should be integer", leverage)
+	}
+	_, err := rc.SetLeverageRaw(ctx, swap.NewSetLeverageReq(sym.String(), int(leverage.IntPart())))
+	return err
+}
+
+func (rc *RestClient) GetLeverage(ctx context.Context, sym exchange.Symbol) (decimal.Decimal, error) {
+	pos, err := rc.positionRisk(ctx, sym)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return pos.Leverage, nil
+}
+
+func (rc *RestClient) SetMarginMode(ctx context.Context, sym exchange.Symbol, mode exchange.PositionMode) error {
+	req, err := swap.NewSetMarginTypeReq(sym.String(), mode)
+	if err != nil {
+		return err
+	}
+	return rc.SetMarginType(ctx, req)
+}
+
+func (rc *RestClient) GetMarginMode(ctx context.Context, sym exchange.Symbol) (exchange.PositionMode, error) {
+	pos, err := rc.positionRisk(ctx, sym)
+	if err != nil {
+		return exchange.PositionModeCross, err
+	}
+	if pos.MarginType == MarginTypeIsolated {
+		return exchange.PositionModeFixed, nil
+	}
+	return exchange.PositionModeCross, nil
+}
+
+func (rc *RestClient) SetPositionMode(ctx context.Context, hedge bool) error {
+	return rc.SetPositionSide(ctx, swap.NewSetPositionSideRequest(hedge))
+}
+
+func (rc *RestClient) GetPositionMode(ctx context.Context) (bool, error) {
+	side, err := rc.GetPositionSide(ctx, swap.NewGetPositionSideRequest())
+	if err != nil {
+		return false, err
+	}
+	return side.DualSidePosition, nil
+}
+
+func (rc *RestClient) positionRisk(ctx context.Context, sym exchange.Symbol) (*PositionRisk, error) {
+	pair := strings.SplitN(sym.String(), "_", 2)[0]
+	if raw, ok := sym.Raw().(*Symbol); ok {
+		pair = raw.Pair
+	}
+
+	risks, err := rc.PositionRisk(ctx, NewPositionRiskReq(pair))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range risks {
+		if risks[i].Symbol == sym.String() {
+			return &risks[i], nil
+		}
+	}
+	return nil, errors.Errorf("unknown position=%s", sym.String())
+}
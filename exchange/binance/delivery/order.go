@@ -0,0 +1,345 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/exchange/binance/swap"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	AddOrderReq struct {
+		*binance.RestReq
+	}
+
+	OrderResp struct {
+		binance.APIError                 //in case of error
+		ClientOrderID    string          `json:"clientOrderId"`
+		CumQty           decimal.Decimal `json:"cumQty"`
+		CumBase          decimal.Decimal `json:"cumBase"`
+		ExecutedQty      decimal.Decimal `json:"executedQty"`
+		OrderID          int64           `json:"orderId"`
+		AvgPrice         decimal.Decimal `json:"avgPrice"`
+		OrigQty          decimal.Decimal `json:"origQty"`
+		Price            decimal.Decimal `json:"price"`
+		ReduceOnly       bool            `json:"reduceOnly"`
+		Side             string          `json:"side"`
+		PositionSide     string          `json:"positionSide"`
+		Status           string          `json:"status"`
+		StopPrice        decimal.Decimal `json:"stopPrice"`
+		ClosePosition    bool            `json:"closePosition"`
+		Symbol           string          `json:"symbol"`
+		Pair             string          `json:"pair"`
+		TimeInForce      string          `json:"timeInForce"`
+		Type             string          `json:"type"`
+		OrigType         string          `json:"origType"`
+		Time             int64           `json:"time"`
+		UpdateTime       int64           `json:"updateTime"`
+		WorkingType      string          `json:"workingType"`
+		PriceProtect     bool            `json:"priceProtect"`
+	}
+
+	OrderReq struct {
+		*binance.RestReq
+	}
+)
+
+const (
+	OrderEndPoint      = "/dapi/v1/order"
+	OpenOrdersEndPoint = "/dapi/v1/openOrders"
+
+	TimeInForceGTC = "GTC"
+	TimeInForceIOC = "IOC"
+	TimeInForceFOK = "FOK"
+	TimeInForceGTX = "GTX"
+)
+
+// NewAddOrderReq according symbol, side, type
+func NewAddOrderReq(symbol string, side string, typ string) *AddOrderReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	req.AddFields("side", side)
+	req.AddFields("type", typ)
+
+	return &AddOrderReq{
+		RestReq: req,
+	}
+}
+
+func (req *AddOrderReq) TimeInForce(tif string) *AddOrderReq {
+	req.AddFields("timeInForce", tif)
+	return req
+}
+
+func (req *AddOrderReq) PositionSide(side string) *AddOrderReq {
+	req.AddFields("positionSide", side)
+	return req
+}
+
+func (req *AddOrderReq) Price(prc decimal.Decimal) *AddOrderReq {
+	req.AddFields("price", prc.String())
+	return req
+}
+
+// Quantity number of contracts
+func (req *AddOrderReq) Quantity(q decimal.Decimal) *AddOrderReq {
+	req.AddFields("quantity", q.String())
+	return req
+}
+
+// ReduceOnly can only be sent in one-way mode
+func (req *AddOrderReq) ReduceOnly(reduceOnly bool) *AddOrderReq {
+	req.AddFields("reduceOnly", reduceOnly)
+	return req
+}
+
+func (req *AddOrderReq) NewClientOrderID(id string) *AddOrderReq {
+	req.AddFields("newClientOrderId", id)
+	return req
+}
+
+func (rc *RestClient) AddOrder(ctx context.Context, req *AddOrderReq) (*OrderResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodPost, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "add order fail")
+	}
+
+	return &ret, nil
+}
+
+func NewOrderReq(symbol string) *OrderReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	return &OrderReq{
+		RestReq: req,
+	}
+}
+
+func (r *OrderReq) OrderID(id int64) *OrderReq {
+	r.AddFields("orderId", id)
+	return r
+}
+
+func (r *OrderReq) OrigClientOrderID(id string) *OrderReq {
+	r.AddFields("origClientOrderId", id)
+	return r
+}
+
+func (rc *RestClient) GetOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	var ret OrderResp
+	if err := rc.GetRequest(ctx, OrderEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get order req fail")
+	}
+
+	return &ret, nil
+}
+
+func (rc *RestClient) DeleteOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get req fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodDelete, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "cancel order fail")
+	}
+
+	return &ret, nil
+}
+
+// OpenOrders return open orders of the symbol, all symbols are queried if symbol is empty
+func (rc *RestClient) OpenOrders(ctx context.Context, symbol string) ([]OrderResp, error) {
+	req := binance.NewRestReq()
+	if symbol != "" {
+		req.AddFields("symbol", symbol)
+	}
+
+	var ret []OrderResp
+	if err := rc.GetRequest(ctx, OpenOrdersEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get open orders fail")
+	}
+	return ret, nil
+}
+
+func (resp *OrderResp) Transfer() (*exchange.Order, error) {
+	symbol, err := ParseSymbol(resp.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	typ, ok := swap.OrderType2ExType[resp.Type]
+	if !ok {
+		return nil, errors.Errorf("unknown resp type=%s", resp.Type)
+	}
+
+	status, ok := swap.Status2ExStatus[resp.Status]
+	if !ok {
+		return nil, errors.Errorf("unknown resp status=%s", resp.Status)
+	}
+
+	side, err := swap.ParseOrderSide(resp.Side, resp.PositionSide, resp.ReduceOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &exchange.Order{
+		ID:       exchange.NewIntID(resp.OrderID),
+		ClientID: exchange.NewStrID(resp.ClientOrderID),
+		Symbol:   symbol,
+		Amount:   resp.OrigQty,
+		Filled:   resp.ExecutedQty,
+		Price:    resp.Price,
+		AvgPrice: resp.AvgPrice,
+		Type:     typ,
+		Side:     side,
+		Status:   status,
+		Updated:  tconv.Milli2Time(resp.UpdateTime),
+		Raw:      resp,
+	}
+	if resp.Time != 0 {
+		ret.Created = tconv.Milli2Time(resp.Time)
+	}
+	return ret, nil
+}
+
+// CreateOrder create order in contracts, GetPositionSide should be called first
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	if rc.side == nil {
+		return nil, errors.Errorf("positionSide not init")
+	}
+
+	typ, ok := swap.ExType2OrderType[req.Type]
+	if !ok {
+		return nil, errors.Errorf("unknown type=%s", req.Type)
+	}
+
+	side, positionSide, reduceOnly, err := swap.OrderSideParam(req.Side, rc.side.DualSidePosition)
+	if err != nil {
+		return nil, err
+	}
+
+	tif := TimeInForceGTC
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				tif = TimeInForceGTX
+			}
+
+		case *exchange.TimeInForceOption:
+			switch t.Flag {
+			case exchange.TimeInForceGTC:
+				tif = TimeInForceGTC
+
+			case exchange.TimeInForceIOC:
+				tif = TimeInForceIOC
+
+			case exchange.TimeInForceFOK:
+				tif = TimeInForceFOK
+			}
+
+		default:
+			return nil, errors.Errorf("unsupport option %+v", opt)
+		}
+	}
+
+	or := NewAddOrderReq(req.Symbol.String(), side, typ)
+	if typ == swap.OrderTypeLimit {
+		or.Price(req.Price)
+		or.TimeInForce(tif)
+	}
+	or.Quantity(req.Amount)
+	or.PositionSide(positionSide)
+	if reduceOnly {
+		or.ReduceOnly(true)
+	}
+	if req.ClientID != nil {
+		or.NewClientOrderID(req.ClientID.String())
+	}
+
+	resp, err := rc.AddOrder(ctx, or)
+	if err != nil {
+		return nil, errors.WithMessage(err, "add order fail")
+	}
+
+	ret, err := resp.Transfer()
+	if err != nil {
+		return nil, errors.WithMessage(err, "transfer order fail")
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.GetOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transfer()
+}
+
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.DeleteOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transfer()
+}
+
+func (rc *RestClient) FetchOpenOrders(ctx context.Context, sym exchange.Symbol) ([]*exchange.Order, error) {
+	resp, err := rc.OpenOrders(ctx, sym.String())
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, 0, len(resp))
+	for i := range resp {
+		o, err := resp[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, o)
+	}
+	return ret, nil
+}
+
+// orderReq build OrderReq by order ID, ClientID is used if ID is not set
+func orderReq(order *exchange.Order) (*OrderReq, error) {
+	req := NewOrderReq(order.Symbol.String())
+	if order.ID != nil {
+		id, err := strconv.ParseInt(order.ID.String(), 10, 64)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad orderID=%s", order.ID.String())
+		}
+		return req.OrderID(id), nil
+	}
+
+	if order.ClientID != nil {
+		return req.OrigClientOrderID(order.ClientID.String()), nil
+	}
+
+	return nil, errors.Errorf("order id or client id is required")
+}
@@ -0,0 +1,51 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/exchange/binance/swap"
+)
+
+const (
+	PositionSidePath = "/dapi/v1/positionSide/dual"
+)
+
+func (rc *RestClient) GetPositionSide(ctx context.Context, req *swap.GetPositionSideRequest) (*swap.GetPositionSideResp, error) {
+	if rc.side != nil {
+		return rc.side, nil
+	}
+
+	var side swap.GetPositionSideResp
+	if err := rc.GetRequest(ctx, PositionSidePath, req, true, &side); err != nil {
+		return nil, errors.WithMessage(err, "get dual position side fail")
+	}
+
+	rc.side = &side
+	return &side, nil
+}
+
+func (rc *RestClient) SetPositionSide(ctx context.Context, req *swap.SetPositionSideRequest) error {
+	values, err := req.Values()
+	if err != nil {
+		return errors.WithMessage(err, "get request values fail")
+	}
+
+	var resp swap.SetPositionSideResp
+	if err := rc.Request(ctx, http.MethodPost, PositionSidePath, values, nil, true, &resp); err != nil {
+		if err = binance.CheckMarginError(err); err != nil {
+			return errors.WithMessage(err, "set position side fail")
+		}
+	}
+
+	if err := binance.CheckMarginCode(resp.Code, resp.Message); err != nil {
+		return errors.WithMessage(err, "set position side fail")
+	}
+
+	rc.side = &swap.GetPositionSideResp{
+		DualSidePosition: req.DualSide(),
+	}
+	return nil
+}
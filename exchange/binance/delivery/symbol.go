@@ -0,0 +1,224 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	// SwapSymbol coin-m perpetual symbol, amount is the number of contracts
+	// and ContractVal is the usd value of one contract
+	SwapSymbol struct {
+		*exchange.BaseSwapSymbol
+		Symbol string
+	}
+
+	// FuturesSymbol coin-m delivery symbol, amount is the number of contracts
+	// and ContractVal is the usd value of one contract
+	FuturesSymbol struct {
+		*exchange.BaseFutureSymbol
+		Symbol string
+	}
+
+	ExchangeInfo struct {
+		Timezone   string   `json:"timezone"`
+		ServerTime int64    `json:"serverTime"`
+		Symbols    []Symbol `json:"symbols"`
+	}
+
+	Symbol struct {
+		Symbol                string          `json:"symbol"`
+		Pair                  string          `json:"pair"`
+		ContractType          string          `json:"contractType"`
+		DeliveryDate          int64           `json:"deliveryDate"`
+		OnboardDate           int64           `json:"onboardDate"`
+		ContractStatus        string          `json:"contractStatus"`
+		ContractSize          decimal.Decimal `json:"contractSize"`
+		MarginAsset           string          `json:"marginAsset"`
+		MaintMarginPercent    decimal.Decimal `json:"maintMarginPercent"`
+		RequiredMarginPercent decimal.Decimal `json:"requiredMarginPercent"`
+		BaseAsset             string          `json:"baseAsset"`
+		QuoteAsset            string          `json:"quoteAsset"`
+		PricePrecision        int             `json:"pricePrecision"`
+		QuantityPrecision     int             `json:"quantityPrecision"`
+		BaseAssetPrecision    int             `json:"baseAssetPrecision"`
+		QuotePrecision        int             `json:"quotePrecision"`
+		UnderlyingType        string          `json:"underlyingType"`
+		Filters               []Filter        `json:"filters"`
+		OrderTypes            []string        `json:"orderTypes"`
+		TimeInForce           []string        `json:"timeInForce"`
+	}
+
+	Filter struct {
+		FilterType string          `json:"filterType"`
+		MinPrice   decimal.Decimal `json:"minPrice"`
+		MaxPrice   decimal.Decimal `json:"maxPrice"`
+		TickSize   decimal.Decimal `json:"tickSize"`
+		StepSize   decimal.Decimal `json:"stepSize"`
+		MaxQty     decimal.Decimal `json:"maxQty"`
+		MinQty     decimal.Decimal `json:"minQty"`
+	}
+)
+
+const (
+	ExchangeInfoEndPoint = "/dapi/v1/exchangeInfo"
+
+	ContractTypePerpetual      = "PERPETUAL"
+	ContractTypeCurrentQuarter = "CURRENT_QUARTER"
+	ContractTypeNextQuarter    = "NEXT_QUARTER"
+
+	priceFilter = "PRICE_FILTER"
+	lotSize     = "LOT_SIZE"
+)
+
+var (
+	symbolMap  = map[string]exchange.Symbol{}
+	restClient *RestClient
+
+	contractTypeMap = map[string]exchange.FutureType{
+		ContractTypeCurrentQuarter: exchange.FutureTypeCQ,
+		ContractTypeNextQuarter:    exchange.FutureTypeNQ,
+	}
+)
+
+func Init(ctx context.Context) error {
+	if restClient != nil {
+		return errors.Errorf("client alreaduy init")
+	}
+	restClient = NewRestClient("", "")
+	return UpdateSymbolMap(ctx)
+}
+
+func InitTest(ctx context.Context) error {
+	if restClient != nil {
+		return errors.Errorf("client alreaduy init")
+	}
+	restClient = NewTestRestClient("", "")
+	return UpdateSymbolMap(ctx)
+}
+
+func UpdateSymbolMap(ctx context.Context) error {
+	symbols, err := restClient.Symbols(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "fetch symbols fail")
+	}
+
+	for _, s := range symbols {
+		symbolMap[s.String()] = s
+	}
+	return nil
+}
+
+// ParseSymbol return SwapSymbol for perpetual and FuturesSymbol for quarter contracts
+func ParseSymbol(symbol string) (exchange.Symbol, error) {
+	sym, ok := symbolMap[symbol]
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol %s", symbol)
+	}
+	return sym, nil
+}
+
+func ParseSwapSymbol(symbol string) (exchange.SwapSymbol, error) {
+	sym, err := ParseSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	// FuturesSymbol also implements exchange.SwapSymbol
+	ret, ok := sym.(*SwapSymbol)
+	if !ok {
+		return nil, errors.Errorf("%s is not swap symbol", symbol)
+	}
+	return ret, nil
+}
+
+func ParseFuturesSymbol(symbol string) (exchange.FuturesSymbol, error) {
+	sym, err := ParseSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, ok := sym.(*FuturesSymbol)
+	if !ok {
+		return nil, errors.Errorf("%s is not futures symbol", symbol)
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) ExchangeInfo(ctx context.Context) (*ExchangeInfo, error) {
+	var info ExchangeInfo
+	if err := rc.Request(ctx, http.MethodGet, ExchangeInfoEndPoint, nil, nil, false, &info); err != nil {
+		return nil, errors.WithMessage(err, "fetch exchangeInfo fail")
+	}
+	return &info, nil
+}
+
+// Symbols return perpetual and quarter symbols, contracts in delivering are ignored
+func (rc *RestClient) Symbols(ctx context.Context) ([]exchange.Symbol, error) {
+	info, err := rc.ExchangeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []exchange.Symbol
+	for i := range info.Symbols {
+		sym := info.Symbols[i]
+		if _, ok := contractTypeMap[sym.ContractType]; !ok && sym.ContractType != ContractTypePerpetual {
+			continue
+		}
+
+		s, err := sym.Parse()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
+func (s *Symbol) Parse() (exchange.Symbol, error) {
+	ns := *s
+
+	cfg := exchange.SymbolConfig{}
+	for _, f := range s.Filters {
+		switch f.FilterType {
+		case priceFilter:
+			cfg.PricePrecision = f.TickSize
+
+		case lotSize:
+			cfg.AmountPrecision = f.StepSize
+			cfg.AmountMin = f.MinQty
+			cfg.AmountMax = f.MaxQty
+		}
+	}
+
+	if s.ContractType == ContractTypePerpetual {
+		return &SwapSymbol{
+			exchange.NewBaseSwapSymbolWithCfg(s.Pair, s.ContractSize, cfg, &ns),
+			s.Symbol,
+		}, nil
+	}
+
+	typ, ok := contractTypeMap[s.ContractType]
+	if !ok {
+		return nil, errors.Errorf("unknown contractType=%s", s.ContractType)
+	}
+
+	return &FuturesSymbol{
+		exchange.NewBaseFuturesSymbolWithCfgCV(s.Pair, tconv.Milli2Time(s.DeliveryDate), typ, cfg, s.ContractSize, &ns),
+		s.Symbol,
+	}, nil
+}
+
+func (s *SwapSymbol) String() string {
+	return s.Symbol
+}
+
+func (s *FuturesSymbol) String() string {
+	return s.Symbol
+}
@@ -0,0 +1,58 @@
+package delivery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func TestSymbolParse(t *testing.T) {
+	raw := `[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","contractType":"PERPETUAL","deliveryDate":4133404800000,
+	"contractStatus":"TRADING","contractSize":100,"marginAsset":"BTC","baseAsset":"BTC","quoteAsset":"USD",
+	"filters":[{"filterType":"PRICE_FILTER","minPrice":"0.1","maxPrice":"100000","tickSize":"0.1"},
+	{"filterType":"LOT_SIZE","stepSize":"1","maxQty":"1000000","minQty":"1"}]},
+	{"symbol":"BTCUSD_211231","pair":"BTCUSD","contractType":"CURRENT_QUARTER","deliveryDate":1640937600000,
+	"contractStatus":"TRADING","contractSize":100,"marginAsset":"BTC","baseAsset":"BTC","quoteAsset":"USD",
+	"filters":[{"filterType":"PRICE_FILTER","minPrice":"0.1","maxPrice":"100000","tickSize":"0.1"},
+	{"filterType":"LOT_SIZE","stepSize":"1","maxQty":"1000000","minQty":"1"}]}]`
+
+	var symbols []Symbol
+	if err := json.Unmarshal([]byte(raw), &symbols); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	perp, err := symbols[0].Parse()
+	if err != nil {
+		t.Fatalf("parse perpetual fail %s", err.Error())
+	}
+	swapSym, ok := perp.(exchange.SwapSymbol)
+	if !ok || swapSym.Index() != "BTCUSD" || !swapSym.ContractVal().Equal(decimal.NewFromInt(100)) ||
+		!swapSym.PricePrecision().Equal(decimal.RequireFromString("0.1")) {
+		t.Errorf("bad swap symbol %+v", perp)
+	}
+
+	quarter, err := symbols[1].Parse()
+	if err != nil {
+		t.Fatalf("parse quarter fail %s", err.Error())
+	}
+	futSym, ok := quarter.(exchange.FuturesSymbol)
+	if !ok || futSym.Type() != exchange.FutureTypeCQ || futSym.String() != "BTCUSD_211231" ||
+		futSym.SettleTime().Unix() != 1640937600 {
+		t.Errorf("bad futures symbol %+v", quarter)
+	}
+
+	prev := symbolMap
+	defer func() { symbolMap = prev }()
+	symbolMap = map[string]exchange.Symbol{perp.String(): perp, quarter.String(): quarter}
+	if _, err := ParseSwapSymbol("BTCUSD_211231"); err == nil {
+		t.Errorf("quarter should not be parsed as swap symbol")
+	}
+	if _, err := ParseFuturesSymbol("BTCUSD_PERP"); err == nil {
+		t.Errorf("perpetual should not be parsed as futures symbol")
+	}
+	if _, err := ParseSwapSymbol("BTCUSD_PERP"); err != nil {
+		t.Errorf("parse swap symbol fail %s", err.Error())
+	}
+}
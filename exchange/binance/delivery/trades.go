@@ -0,0 +1,104 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/exchange/binance/swap"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	Trade struct {
+		Symbol          string          `json:"symbol"`
+		ID              int64           `json:"id"`
+		OrderID         int64           `json:"orderId"`
+		Pair            string          `json:"pair"`
+		Side            string          `json:"side"`
+		Price           decimal.Decimal `json:"price"`
+		Qty             decimal.Decimal `json:"qty"`
+		RealizedPnl     decimal.Decimal `json:"realizedPnl"`
+		MarginAsset     string          `json:"marginAsset"`
+		BaseQty         decimal.Decimal `json:"baseQty"`
+		Commission      decimal.Decimal `json:"commission"`
+		CommissionAsset string          `json:"commissionAsset"`
+		Time            int64           `json:"time"`
+		PositionSide    string          `json:"positionSide"`
+		Buyer           bool            `json:"buyer"`
+		Maker           bool            `json:"maker"`
+	}
+)
+
+const (
+	UserTradesEndPoint = "/dapi/v1/userTrades"
+)
+
+func (rc *RestClient) UserTrades(ctx context.Context, symbol string, st int64, et int64, fid int64, limit int) ([]Trade, error) {
+	value := binance.TradeParam(symbol, st, et, fid, limit)
+
+	var ret []Trade
+	if err := rc.Request(ctx, http.MethodGet, UserTradesEndPoint, value, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "fetch userTrades fail")
+	}
+	return ret, nil
+}
+
+// Parse trade into exchange.Trade, amount is the number of contracts
+func (t *Trade) Parse() (*exchange.Trade, error) {
+	s, err := ParseSymbol(t.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := swap.ParseSide(t.Side, t.PositionSide)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exchange.Trade{
+		ID:          strconv.FormatInt(t.ID, 10),
+		OrderID:     strconv.FormatInt(t.OrderID, 10),
+		Symbol:      s,
+		Side:        side,
+		Amount:      t.Qty,
+		Price:       t.Price,
+		Fee:         t.Commission.Neg(),
+		FeeCurrency: t.CommissionAsset,
+		Time:        tconv.Milli2Time(t.Time),
+		IsMaker:     t.Maker,
+		Raw:         *t,
+	}, nil
+}
+
+func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) ([]exchange.Trade, error) {
+	var fid int64
+	if req.StartID != "" {
+		var err error
+		fid, err = strconv.ParseInt(req.StartID, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	trades, err := rc.UserTrades(ctx, req.Symbol.String(), tconv.Time2Milli(req.StartTime),
+		tconv.Time2Milli(req.EndTime), fid, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []exchange.Trade{}
+	for i := range trades {
+		trade := trades[i]
+		t, err := trade.Parse()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *t)
+	}
+	return ret, nil
+}
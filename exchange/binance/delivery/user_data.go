@@ -0,0 +1,19 @@
+package delivery
+
+import (
+	"github.com/szmcdull/ccexgo/exchange/binance/swap"
+)
+
+// NewUserDataCodeC coin-m user data stream share message format with usdⓈ-m,
+// amounts of orders and positions are the number of contracts
+func NewUserDataCodeC() *swap.UserDataCodeC {
+	return swap.NewUserDataCodeCWithParser(ParseSymbol)
+}
+
+func NewUserDataWSClient(key, secret string, data chan interface{}) *swap.UserDataWSClient {
+	return swap.NewUserDataWSClientWithCodeC(NewUserDataCodeC(), NewRestClient(key, secret), data)
+}
+
+func NewTestUserDataWSClient(key, secret string, data chan interface{}) *swap.UserDataWSClient {
+	return swap.NewUserDataWSClientWithCodeC(NewUserDataCodeC(), NewTestRestClient(key, secret), data)
+}
@@ -1,6 +1,35 @@
 package binance
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+const (
+	ErrCodeTooManyRequests          = -1003
+	ErrCodeTooManyOrders            = -1015
+	ErrCodeServiceShuttingDown      = -1016
+	ErrCodeInvalidTimestamp         = -1021
+	ErrCodeInvalidSignature         = -1022
+	ErrCodeBadSymbol                = -1121
+	ErrCodeNewOrderRejected         = -2010
+	ErrCodeCancelRejected           = -2011
+	ErrCodeNoSuchOrder              = -2013
+	ErrCodeBalanceNotSufficient     = -2018
+	ErrCodeMarginNotSufficient      = -2019
+	ErrCodeReduceOnlyReject         = -2022
+	ErrCodePostOnlyReject           = -5022
+	ErrCodeNoNeedChangeMarginType   = -4046
+	ErrCodeMarginTypeOpenOrders     = -4047
+	ErrCodeMarginTypePosition       = -4048
+	ErrCodeNoNeedChangePositionSide = -4059
+	ErrCodePositionSideOpenOrders   = -4067
+	ErrCodePositionSidePosition     = -4068
+)
 
 type (
 	APIIF interface {
@@ -15,6 +44,36 @@ type (
 	}
 )
 
+var (
+	codeErrors = map[int]error{
+		ErrCodeTooManyRequests:      exchange.ErrRateLimited,
+		ErrCodeTooManyOrders:        exchange.ErrRateLimited,
+		ErrCodeServiceShuttingDown:  exchange.ErrMaintenance,
+		ErrCodeInvalidTimestamp:     exchange.ErrTimestampOutOfWindow,
+		ErrCodeInvalidSignature:     exchange.ErrInvalidSignature,
+		ErrCodeBadSymbol:            exchange.ErrSymbolNotFound,
+		ErrCodeNoSuchOrder:          exchange.ErrOrderNotFound,
+		ErrCodeBalanceNotSufficient: exchange.ErrInsufficientBalance,
+		ErrCodeMarginNotSufficient:  exchange.ErrInsufficientBalance,
+		ErrCodeReduceOnlyReject:     exchange.ErrReduceOnlyRejected,
+		ErrCodePostOnlyReject:       exchange.ErrPostOnlyRejected,
+	}
+
+	// spot order rejections share codes and are distinguished by message
+	msgErrors = map[int][]struct {
+		msg string
+		err error
+	}{
+		ErrCodeNewOrderRejected: {
+			{"insufficient balance", exchange.ErrInsufficientBalance},
+			{"immediately match and take", exchange.ErrPostOnlyRejected},
+		},
+		ErrCodeCancelRejected: {
+			{"unknown order", exchange.ErrOrderNotFound},
+		},
+	}
+)
+
 func (ae *APIError) ECode() int {
 	return ae.Code
 }
@@ -27,7 +86,64 @@ func (ae *APIError) Error() string {
 	return fmt.Sprintf("api error code:%d message:'%s'", ae.Code, ae.Message)
 }
 
-func (ae *APIError) Is(target interface{}) bool {
-	_, ok := target.(*APIError)
-	return ok
+// Is match *APIError and the exchange error mapped from the code
+func (ae *APIError) Is(target error) bool {
+	if _, ok := target.(*APIError); ok {
+		return true
+	}
+	err := ae.exchangeError()
+	return err != nil && target == err
+}
+
+func (ae *APIError) exchangeError() error {
+	if err, ok := codeErrors[ae.Code]; ok {
+		return err
+	}
+
+	msg := strings.ToLower(ae.Message)
+	for _, me := range msgErrors[ae.Code] {
+		if strings.Contains(msg, me.msg) {
+			return me.err
+		}
+	}
+	return nil
+}
+
+// ClientIDMaxLen max length of newClientOrderId
+const ClientIDMaxLen = 36
+
+// NewClientIDGenerator generate newClientOrderId with the prefix
+func NewClientIDGenerator(prefix string) *exchange.ClientIDGenerator {
+	return exchange.NewClientIDGenerator(prefix, ClientIDMaxLen, false)
+}
+
+// IsOrderNotFound whether the error means the order does not exist
+func IsOrderNotFound(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.Code == ErrCodeNoSuchOrder
+}
+
+// CheckMarginCode check response code of leverage, margin type and position side
+// settings. the no need to change code is treated as success
+func CheckMarginCode(code int, msg string) error {
+	switch code {
+	case 0, 200, ErrCodeNoNeedChangeMarginType, ErrCodeNoNeedChangePositionSide:
+		return nil
+
+	case ErrCodeMarginTypeOpenOrders, ErrCodeMarginTypePosition,
+		ErrCodePositionSideOpenOrders, ErrCodePositionSidePosition:
+		return exchange.NewPositionExists(&APIError{Code: code, Message: msg})
+
+	default:
+		return &APIError{Code: code, Message: msg}
+	}
+}
+
+// CheckMarginError apply CheckMarginCode to APIError returned by request
+func CheckMarginError(err error) error {
+	var ae *APIError
+	if errors.As(err, &ae) {
+		return CheckMarginCode(ae.Code, ae.Message)
+	}
+	return err
 }
@@ -0,0 +1,51 @@
+package binance
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func TestCheckMarginCode(t *testing.T) {
+	for _, code := range []int{0, 200, ErrCodeNoNeedChangeMarginType, ErrCodeNoNeedChangePositionSide} {
+		if err := CheckMarginCode(code, ""); err != nil {
+			t.Errorf("code %d should be success %s", code, err.Error())
+		}
+	}
+
+	err := CheckMarginCode(ErrCodePositionSidePosition, "position side cannot be changed if there exists position")
+	var ae *APIError
+	if !errors.Is(err, &exchange.ErrPositionExists{}) || !errors.As(err, &ae) || ae.Code != ErrCodePositionSidePosition {
+		t.Errorf("bad position exists error %v", err)
+	}
+
+	if err := CheckMarginCode(-4028, "leverage is not valid"); err == nil || errors.Is(err, &exchange.ErrPositionExists{}) {
+		t.Errorf("bad api error %v", err)
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	data := []struct {
+		err    error
+		target error
+	}{
+		{&APIError{Code: ErrCodeNoSuchOrder, Message: "Order does not exist."}, exchange.ErrOrderNotFound},
+		{&APIError{Code: ErrCodeInvalidTimestamp}, exchange.ErrTimestampOutOfWindow},
+		{&APIError{Code: ErrCodeNewOrderRejected, Message: "Account has insufficient balance for requested action."}, exchange.ErrInsufficientBalance},
+		{&APIError{Code: ErrCodeNewOrderRejected, Message: "Order would immediately match and take."}, exchange.ErrPostOnlyRejected},
+	}
+
+	for _, d := range data {
+		var ae *APIError
+		err := fmt.Errorf("wrap %w", d.err)
+		if !errors.Is(err, d.target) || !errors.As(err, &ae) {
+			t.Errorf("error %v should be %v", d.err, d.target)
+		}
+	}
+
+	if errors.Is(&APIError{Code: ErrCodeNewOrderRejected, Message: "Filter failure: PRICE_FILTER"}, exchange.ErrInsufficientBalance) {
+		t.Errorf("filter failure should not be insufficient balance")
+	}
+}
@@ -0,0 +1,134 @@
+package binance
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/szmcdull/ccexgo/misc/request"
+)
+
+var (
+	limiterMu sync.Mutex
+	limiters  = map[string]*request.Limiter{}
+)
+
+const (
+	RuleWeight     = "weight"
+	RuleSAPIWeight = "sapiWeight"
+	RuleOrders10s  = "orders10s"
+	RuleOrders1m   = "orders1m"
+	RuleOrders1d   = "orders1d"
+)
+
+// NewSpotLimiter limiter of api.binance.com, /api/* endpoints consume request weight and
+// order placements also count orders. /sapi/* endpoints have separate weight limit
+func NewSpotLimiter() *request.Limiter {
+	return request.NewLimiter(
+		request.Rule{Name: RuleWeight, Limit: 6000, Interval: time.Minute},
+		request.Rule{Name: RuleSAPIWeight, Limit: 12000, Interval: time.Minute},
+		request.Rule{Name: RuleOrders10s, Limit: 100, Interval: 10 * time.Second},
+		request.Rule{Name: RuleOrders1d, Limit: 200000, Interval: 24 * time.Hour},
+	).Default(request.Cost{RuleWeight: 1}).
+		Header("X-MBX-USED-WEIGHT-1M", RuleWeight).
+		Header("X-SAPI-USED-IP-WEIGHT-1M", RuleSAPIWeight).
+		Header("X-MBX-ORDER-COUNT-10S", RuleOrders10s).
+		Header("X-MBX-ORDER-COUNT-1D", RuleOrders1d).
+		Endpoint("", "/api/v3/exchangeInfo", request.Cost{RuleWeight: 20}).
+		Endpoint("", "/api/v3/account", request.Cost{RuleWeight: 20}).
+		Endpoint("", "/api/v3/myTrades", request.Cost{RuleWeight: 20}).
+		Endpoint("", "/api/v3/openOrders", request.Cost{RuleWeight: 6}).
+		Endpoint(http.MethodGet, "/api/v3/order", request.Cost{RuleWeight: 4}).
+		Endpoint(http.MethodPost, "/api/v3/order", request.Cost{RuleWeight: 1, RuleOrders10s: 1, RuleOrders1d: 1}).
+		Endpoint(http.MethodPost, "/api/v3/order/oco", request.Cost{RuleWeight: 1, RuleOrders10s: 2, RuleOrders1d: 2}).
+		Endpoint("", "/sapi/v1/margin/account", request.Cost{RuleSAPIWeight: 10}).
+		Endpoint("", "/sapi/v1/margin/isolated/account", request.Cost{RuleSAPIWeight: 10}).
+		Endpoint(http.MethodPost, "/sapi/v1/margin/order", request.Cost{RuleSAPIWeight: 6, RuleOrders10s: 1, RuleOrders1d: 1}).
+		Endpoint(http.MethodGet, "/sapi/v1/margin/order", request.Cost{RuleSAPIWeight: 10}).
+		Endpoint(http.MethodDelete, "/sapi/v1/margin/order", request.Cost{RuleSAPIWeight: 10}).
+		Endpoint("", "/sapi/v1/margin/openOrders", request.Cost{RuleSAPIWeight: 10})
+}
+
+// NewSwapLimiter limiter of fapi.binance.com
+func NewSwapLimiter() *request.Limiter {
+	return request.NewLimiter(
+		request.Rule{Name: RuleWeight, Limit: 2400, Interval: time.Minute},
+		request.Rule{Name: RuleOrders10s, Limit: 300, Interval: 10 * time.Second},
+		request.Rule{Name: RuleOrders1m, Limit: 1200, Interval: time.Minute},
+	).Default(request.Cost{RuleWeight: 1}).
+		Header("X-MBX-USED-WEIGHT-1M", RuleWeight).
+		Header("X-MBX-ORDER-COUNT-10S", RuleOrders10s).
+		Header("X-MBX-ORDER-COUNT-1M", RuleOrders1m).
+		Endpoint("", "/fapi/v2/account", request.Cost{RuleWeight: 5}).
+		Endpoint("", "/fapi/v2/positionRisk", request.Cost{RuleWeight: 5}).
+		Endpoint("", "/fapi/v1/userTrades", request.Cost{RuleWeight: 5}).
+		Endpoint("", "/fapi/v1/income", request.Cost{RuleWeight: 30}).
+		Endpoint("", "/fapi/v1/commissionRate", request.Cost{RuleWeight: 20}).
+		Endpoint(http.MethodPost, "/fapi/v1/order", request.Cost{RuleOrders10s: 1, RuleOrders1m: 1}).
+		Endpoint(http.MethodPost, "/fapi/v1/batchOrders", request.Cost{RuleWeight: 5, RuleOrders10s: 5, RuleOrders1m: 1})
+}
+
+// NewDeliveryLimiter limiter of dapi.binance.com
+func NewDeliveryLimiter() *request.Limiter {
+	return request.NewLimiter(
+		request.Rule{Name: RuleWeight, Limit: 2400, Interval: time.Minute},
+		request.Rule{Name: RuleOrders1m, Limit: 1200, Interval: time.Minute},
+	).Default(request.Cost{RuleWeight: 1}).
+		Header("X-MBX-USED-WEIGHT-1M", RuleWeight).
+		Header("X-MBX-ORDER-COUNT-1M", RuleOrders1m).
+		Endpoint("", "/dapi/v1/account", request.Cost{RuleWeight: 5}).
+		Endpoint("", "/dapi/v1/userTrades", request.Cost{RuleWeight: 20}).
+		Endpoint("", "/dapi/v1/income", request.Cost{RuleWeight: 20}).
+		Endpoint("", "/dapi/v1/commissionRate", request.Cost{RuleWeight: 20}).
+		Endpoint(http.MethodPost, "/dapi/v1/order", request.Cost{RuleWeight: 1, RuleOrders1m: 1})
+}
+
+// NewLimiter limiter of the host, hosts other than fapi and dapi such as vapi, eapi
+// and testnet use the spot table
+func NewLimiter(host string) *request.Limiter {
+	switch limiterFamily(host) {
+	case "fapi":
+		return NewSwapLimiter()
+
+	case "dapi":
+		return NewDeliveryLimiter()
+	}
+	return NewSpotLimiter()
+}
+
+// SharedLimiter limiter shared by clients of the host family in the process, since
+// weight is limited by ip. spot and margin clients share the api. limiter
+func SharedLimiter(host string) *request.Limiter {
+	family := limiterFamily(host)
+
+	limiterMu.Lock()
+	defer limiterMu.Unlock()
+	l, ok := limiters[family]
+	if !ok {
+		l = NewLimiter(host)
+		limiters[family] = l
+	}
+	return l
+}
+
+// limiterFamily the hosts of the same family share ip weight, unknown host is a
+// family itself
+func limiterFamily(host string) string {
+	for _, prefix := range []string{"api", "fapi", "dapi"} {
+		if strings.HasPrefix(host, prefix+".") {
+			return prefix
+		}
+	}
+	return host
+}
+
+// SetLimiter replace the limiter, which can be shared with other clients of the same
+// ip or account. nil disables rate limiting
+func (rc *RestClient) SetLimiter(l *request.Limiter) {
+	rc.limiter = l
+}
+
+func (rc *RestClient) Limiter() *request.Limiter {
+	return rc.limiter
+}
@@ -0,0 +1,131 @@
+package margin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+)
+
+type (
+	UserAsset struct {
+		Asset    string          `json:"asset"`
+		Borrowed decimal.Decimal `json:"borrowed"`
+		Free     decimal.Decimal `json:"free"`
+		Interest decimal.Decimal `json:"interest"`
+		Locked   decimal.Decimal `json:"locked"`
+		NetAsset decimal.Decimal `json:"netAsset"`
+	}
+
+	CrossAccount struct {
+		binance.APIError                    //in case of error
+		BorrowEnabled       bool            `json:"borrowEnabled"`
+		MarginLevel         decimal.Decimal `json:"marginLevel"`
+		TotalAssetOfBtc     decimal.Decimal `json:"totalAssetOfBtc"`
+		TotalLiabilityOfBtc decimal.Decimal `json:"totalLiabilityOfBtc"`
+		TotalNetAssetOfBtc  decimal.Decimal `json:"totalNetAssetOfBtc"`
+		TradeEnabled        bool            `json:"tradeEnabled"`
+		TransferEnabled     bool            `json:"transferEnabled"`
+		UserAssets          []UserAsset     `json:"userAssets"`
+	}
+
+	IsolatedAsset struct {
+		UserAsset
+		BorrowEnabled bool            `json:"borrowEnabled"`
+		NetAssetOfBtc decimal.Decimal `json:"netAssetOfBtc"`
+		RepayEnabled  bool            `json:"repayEnabled"`
+		TotalAsset    decimal.Decimal `json:"totalAsset"`
+	}
+
+	IsolatedPair struct {
+		BaseAsset         IsolatedAsset   `json:"baseAsset"`
+		QuoteAsset        IsolatedAsset   `json:"quoteAsset"`
+		Symbol            string          `json:"symbol"`
+		IsolatedCreated   bool            `json:"isolatedCreated"`
+		Enabled           bool            `json:"enabled"`
+		MarginLevel       decimal.Decimal `json:"marginLevel"`
+		MarginLevelStatus string          `json:"marginLevelStatus"`
+		MarginRatio       decimal.Decimal `json:"marginRatio"`
+		IndexPrice        decimal.Decimal `json:"indexPrice"`
+		LiquidatePrice    decimal.Decimal `json:"liquidatePrice"`
+		LiquidateRate     decimal.Decimal `json:"liquidateRate"`
+		TradeEnabled      bool            `json:"tradeEnabled"`
+	}
+
+	IsolatedAccount struct {
+		binance.APIError                    //in case of error
+		Assets              []IsolatedPair  `json:"assets"`
+		TotalAssetOfBtc     decimal.Decimal `json:"totalAssetOfBtc"`
+		TotalLiabilityOfBtc decimal.Decimal `json:"totalLiabilityOfBtc"`
+		TotalNetAssetOfBtc  decimal.Decimal `json:"totalNetAssetOfBtc"`
+	}
+)
+
+const (
+	CrossAccountEndPoint    = "/sapi/v1/margin/account"
+	IsolatedAccountEndPoint = "/sapi/v1/margin/isolated/account"
+)
+
+func (rc *RestClient) CrossAccount(ctx context.Context) (*CrossAccount, error) {
+	var ret CrossAccount
+	if err := rc.GetRequest(ctx, CrossAccountEndPoint, binance.NewRestReq(), true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get cross account fail")
+	}
+	return &ret, nil
+}
+
+// IsolatedAccount return isolated margin account of the symbols, all pairs are returned if symbols is empty
+func (rc *RestClient) IsolatedAccount(ctx context.Context, symbols ...string) (*IsolatedAccount, error) {
+	req := binance.NewRestReq()
+	if len(symbols) != 0 {
+		req.AddFields("symbols", strings.Join(symbols, ","))
+	}
+
+	var ret IsolatedAccount
+	if err := rc.GetRequest(ctx, IsolatedAccountEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get isolated account fail")
+	}
+	return &ret, nil
+}
+
+// FetchBalance return cross margin balances. Equitity is net asset and the
+// borrowed amount and interest can be found in Raw
+func (rc *RestClient) FetchBalance(ctx context.Context, currencies ...string) (*exchange.Balances, error) {
+	if rc.isolated {
+		return nil, errors.Errorf("use IsolatedAccount for isolated margin balance")
+	}
+
+	acc, err := rc.CrossAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := make(map[string]struct{}, len(currencies))
+	for _, c := range currencies {
+		filter[exchange.CurrencyFormat(c)] = struct{}{}
+	}
+
+	ret := exchange.NewBalances()
+	for i := range acc.UserAssets {
+		ua := &acc.UserAssets[i]
+		if _, ok := filter[exchange.CurrencyFormat(ua.Asset)]; len(filter) != 0 && !ok {
+			continue
+		}
+		ret.Add(ua.Transform())
+	}
+	ret.Raw = acc
+	return ret, nil
+}
+
+func (ua *UserAsset) Transform() *exchange.Balance {
+	return &exchange.Balance{
+		Currency: ua.Asset,
+		Equitity: ua.NetAsset,
+		Total:    ua.Free.Add(ua.Locked),
+		Free:     ua.Free,
+		Frozen:   ua.Locked,
+	}
+}
@@ -0,0 +1,36 @@
+package margin
+
+import "github.com/szmcdull/ccexgo/exchange/binance"
+
+type (
+	// RestClient binance margin rest client, isolated client send isIsolated
+	// with every order, loan and account request
+	RestClient struct {
+		*binance.RestClient
+		isolated  bool
+		symbol    string //isolated symbol of user data stream
+		listenKey string
+	}
+)
+
+const (
+	MarginAPIHost = "api.binance.com"
+)
+
+func NewRestClient(key, secret string) *RestClient {
+	return &RestClient{
+		RestClient: binance.NewRestClient(key, secret, MarginAPIHost),
+	}
+}
+
+func NewIsolatedRestClient(key, secret string) *RestClient {
+	return &RestClient{
+		RestClient: binance.NewRestClient(key, secret, MarginAPIHost),
+		isolated:   true,
+	}
+}
+
+// Isolated return whether the client trade isolated margin
+func (rc *RestClient) Isolated() bool {
+	return rc.isolated
+}
@@ -0,0 +1,153 @@
+package margin
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	InterestHistoryReq struct {
+		*binance.RestReq
+	}
+
+	Interest struct {
+		TxID                int64           `json:"txId"`
+		InterestAccuredTime int64           `json:"interestAccuredTime"`
+		Asset               string          `json:"asset"`
+		RawAsset            string          `json:"rawAsset"`
+		Principal           decimal.Decimal `json:"principal"`
+		Interest            decimal.Decimal `json:"interest"`
+		InterestRate        decimal.Decimal `json:"interestRate"`
+		Type                string          `json:"type"`
+		IsolatedSymbol      string          `json:"isolatedSymbol"`
+	}
+
+	InterestHistoryResp struct {
+		binance.APIError            //in case of error
+		Rows             []Interest `json:"rows"`
+		Total            int        `json:"total"`
+	}
+)
+
+const (
+	InterestHistoryEndPoint = "/sapi/v1/margin/interestHistory"
+)
+
+func NewInterestHistoryReq() *InterestHistoryReq {
+	return &InterestHistoryReq{
+		RestReq: binance.NewRestReq(),
+	}
+}
+
+func (req *InterestHistoryReq) Asset(asset string) *InterestHistoryReq {
+	req.AddFields("asset", asset)
+	return req
+}
+
+func (req *InterestHistoryReq) IsolatedSymbol(symbol string) *InterestHistoryReq {
+	req.AddFields("isolatedSymbol", symbol)
+	return req
+}
+
+func (req *InterestHistoryReq) StartTime(ts int64) *InterestHistoryReq {
+	req.AddFields("startTime", ts)
+	return req
+}
+
+func (req *InterestHistoryReq) EndTime(ts int64) *InterestHistoryReq {
+	req.AddFields("endTime", ts)
+	return req
+}
+
+// Current page number start from 1
+func (req *InterestHistoryReq) Current(page int) *InterestHistoryReq {
+	req.AddFields("current", page)
+	return req
+}
+
+func (req *InterestHistoryReq) Size(size int) *InterestHistoryReq {
+	req.AddFields("size", size)
+	return req
+}
+
+func (rc *RestClient) InterestHistory(ctx context.Context, req *InterestHistoryReq) (*InterestHistoryResp, error) {
+	var ret InterestHistoryResp
+	if err := rc.GetRequest(ctx, InterestHistoryEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get interest history fail")
+	}
+	return &ret, nil
+}
+
+// Finance return interest history, only FinanceTypeInterest is supported.
+// StartID is used as page number if set
+func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam) ([]exchange.Finance, error) {
+	if req.Type != exchange.FinanceTypeInterest {
+		return nil, errors.Errorf("unsupport finance type %d", req.Type)
+	}
+
+	hr := NewInterestHistoryReq()
+	if req.Symbol != nil && rc.isolated {
+		hr.IsolatedSymbol(req.Symbol.String())
+	}
+	if !req.StartTime.IsZero() {
+		hr.StartTime(tconv.Time2Milli(req.StartTime))
+	}
+	if !req.EndTime.IsZero() {
+		hr.EndTime(tconv.Time2Milli(req.EndTime))
+	}
+	if req.StartID != "" {
+		page, err := strconv.Atoi(req.StartID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad page %s", req.StartID)
+		}
+		hr.Current(page)
+	}
+	if req.Limit != 0 {
+		hr.Size(req.Limit)
+	}
+
+	resp, err := rc.InterestHistory(ctx, hr)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]exchange.Finance, 0, len(resp.Rows))
+	for i := range resp.Rows {
+		f, err := resp.Rows[i].Parse()
+		if err != nil {
+			return nil, errors.WithMessage(err, "parse interest fail")
+		}
+		ret = append(ret, *f)
+	}
+	return ret, nil
+}
+
+// Parse interest into exchange.Finance, amount is negative as interest is paid
+func (i *Interest) Parse() (*exchange.Finance, error) {
+	var (
+		s   exchange.Symbol
+		err error
+	)
+	if i.IsolatedSymbol != "" {
+		s, err = ParseSymbol(i.IsolatedSymbol)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &exchange.Finance{
+		ID:       strconv.FormatInt(i.TxID, 10),
+		Time:     tconv.Milli2Time(i.InterestAccuredTime),
+		Amount:   i.Interest.Neg(),
+		Currency: i.Asset,
+		Type:     exchange.FinanceTypeInterest,
+		Symbol:   s,
+		Raw:      i,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package margin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange/binance/spot"
+)
+
+type (
+	ListenKeyResp struct {
+		ListenKey string `json:"listenKey"`
+	}
+)
+
+const (
+	CrossListenKeyEndPoint    = "/sapi/v1/userDataStream"
+	IsolatedListenKeyEndPoint = "/sapi/v1/userDataStream/isolated"
+)
+
+// GetListenKeyAddr create a listenKey and return the user data stream address
+func (rc *RestClient) GetListenKeyAddr(ctx context.Context) (string, error) {
+	var ret ListenKeyResp
+	if err := rc.Request(ctx, http.MethodPost, rc.listenKeyEndPoint(), rc.listenKeyValues(), nil, false, &ret); err != nil {
+		return "", errors.WithMessage(err, "request listenKey fail")
+	}
+
+	rc.listenKey = ret.ListenKey
+	return fmt.Sprintf("%s/%s", spot.WSClientEndPoint, ret.ListenKey), nil
+}
+
+func (rc *RestClient) PersistListenKey(ctx context.Context) error {
+	var ret map[string]interface{}
+
+	values := rc.listenKeyValues()
+	values.Add("listenKey", rc.listenKey)
+	if err := rc.Request(ctx, http.MethodPut, rc.listenKeyEndPoint(), values, nil, false, &ret); err != nil {
+		return errors.WithMessage(err, "persist listenKey fail")
+	}
+	return nil
+}
+
+func (rc *RestClient) DeleteListenKey(ctx context.Context) error {
+	var ret map[string]interface{}
+
+	values := rc.listenKeyValues()
+	values.Add("listenKey", rc.listenKey)
+	if err := rc.Request(ctx, http.MethodDelete, rc.listenKeyEndPoint(), values, nil, false, &ret); err != nil {
+		return errors.WithMessage(err, "delete listenKey fail")
+	}
+	return nil
+}
+
+func (rc *RestClient) listenKeyEndPoint() string {
+	if rc.isolated {
+		return IsolatedListenKeyEndPoint
+	}
+	return CrossListenKeyEndPoint
+}
+
+func (rc *RestClient) listenKeyValues() url.Values {
+	values := url.Values{}
+	if rc.isolated {
+		values.Add("symbol", rc.symbol)
+	}
+	return values
+}
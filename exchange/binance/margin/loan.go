@@ -0,0 +1,78 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+)
+
+type (
+	LoanReq struct {
+		*binance.RestReq
+		isolated bool
+		symbol   string
+	}
+
+	LoanResp struct {
+		binance.APIError       //in case of error
+		TranID           int64 `json:"tranId"`
+	}
+)
+
+const (
+	BorrowEndPoint = "/sapi/v1/margin/loan"
+	RepayEndPoint  = "/sapi/v1/margin/repay"
+)
+
+// NewLoanReq build borrow or repay request, symbol is required for isolated margin.
+// isolated client borrow or repay with the symbol of the user data stream if not set
+func NewLoanReq(asset string, amount decimal.Decimal) *LoanReq {
+	req := binance.NewRestReq()
+	req.AddFields("asset", asset)
+	req.AddFields("amount", amount.String())
+	return &LoanReq{
+		RestReq: req,
+	}
+}
+
+func (req *LoanReq) Isolated(symbol string) *LoanReq {
+	req.isolated = true
+	req.symbol = symbol
+	return req
+}
+
+func (rc *RestClient) Borrow(ctx context.Context, req *LoanReq) (*LoanResp, error) {
+	return rc.loan(ctx, BorrowEndPoint, req)
+}
+
+func (rc *RestClient) Repay(ctx context.Context, req *LoanReq) (*LoanResp, error) {
+	return rc.loan(ctx, RepayEndPoint, req)
+}
+
+func (rc *RestClient) loan(ctx context.Context, endPoint string, req *LoanReq) (*LoanResp, error) {
+	if req.isolated || rc.isolated {
+		symbol := req.symbol
+		if symbol == "" {
+			symbol = rc.symbol
+		}
+		if symbol == "" {
+			return nil, errors.Errorf("symbol is required for isolated margin loan")
+		}
+		req.AddFields("isIsolated", "TRUE")
+		req.AddFields("symbol", symbol)
+	}
+
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret LoanResp
+	if err := rc.Request(ctx, http.MethodPost, endPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessagef(err, "request %s fail", endPoint)
+	}
+	return &ret, nil
+}
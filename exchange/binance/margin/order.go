@@ -0,0 +1,191 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/exchange/binance/spot"
+)
+
+type (
+	// SideEffectOption specific whether the order borrow or repay automatically
+	SideEffectOption struct {
+		Type string
+	}
+
+	OrderResp struct {
+		spot.OrderResp
+		IsIsolated            bool            `json:"isIsolated"`
+		MarginBuyBorrowAmount decimal.Decimal `json:"marginBuyBorrowAmount"`
+		MarginBuyBorrowAsset  string          `json:"marginBuyBorrowAsset"`
+	}
+)
+
+const (
+	OrderEndPoint      = "/sapi/v1/margin/order"
+	OpenOrdersEndPoint = "/sapi/v1/margin/openOrders"
+
+	SideEffectNone      = "NO_SIDE_EFFECT"
+	SideEffectMarginBuy = "MARGIN_BUY"
+	SideEffectAutoRepay = "AUTO_REPAY"
+)
+
+func NewSideEffectOption(typ string) exchange.OrderReqOption {
+	return &SideEffectOption{
+		Type: typ,
+	}
+}
+
+func (rc *RestClient) AddOrder(ctx context.Context, req *spot.AddOrderReq) (*OrderResp, error) {
+	rc.addIsolated(req.RestReq)
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodPost, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "add margin order fail")
+	}
+	return &ret, nil
+}
+
+func (rc *RestClient) GetOrder(ctx context.Context, req *spot.OrderReq) (*OrderResp, error) {
+	rc.addIsolated(req.RestReq)
+
+	var ret OrderResp
+	if err := rc.GetRequest(ctx, OrderEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get margin order fail")
+	}
+	return &ret, nil
+}
+
+func (rc *RestClient) DeleteOrder(ctx context.Context, req *spot.OrderReq) (*OrderResp, error) {
+	rc.addIsolated(req.RestReq)
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodDelete, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "cancel margin order fail")
+	}
+	return &ret, nil
+}
+
+func (rc *RestClient) OpenOrders(ctx context.Context, req *spot.OpenOrdersReq) ([]OrderResp, error) {
+	rc.addIsolated(req.RestReq)
+
+	var ret []OrderResp
+	if err := rc.GetRequest(ctx, OpenOrdersEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get margin open orders fail")
+	}
+	return ret, nil
+}
+
+func (resp *OrderResp) Transfer() (*exchange.Order, error) {
+	return resp.TransferWith(parseSymbol)
+}
+
+// CreateOrder create margin order, SideEffectOption can be used to borrow
+// or repay automatically
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	var (
+		sideEffect string
+		opts       []exchange.OrderReqOption
+	)
+	for _, opt := range options {
+		if se, ok := opt.(*SideEffectOption); ok {
+			sideEffect = se.Type
+			continue
+		}
+		opts = append(opts, opt)
+	}
+
+	or, err := spot.NewCreateOrderReq(req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if sideEffect != "" {
+		or.AddFields("sideEffectType", sideEffect)
+	}
+
+	resp, err := rc.AddOrder(ctx, or)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Transfer()
+}
+
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.GetOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Transfer()
+}
+
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.DeleteOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Transfer()
+}
+
+func (rc *RestClient) FetchOpenOrders(ctx context.Context, sym exchange.Symbol) ([]*exchange.Order, error) {
+	resp, err := rc.OpenOrders(ctx, spot.NewOpenOrdersReq(sym.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, 0, len(resp))
+	for i := range resp {
+		o, err := resp[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, o)
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) addIsolated(req *binance.RestReq) {
+	if rc.isolated {
+		req.AddFields("isIsolated", "TRUE")
+	}
+}
+
+// orderReq build OrderReq by order ID, ClientID is used if ID is not set
+func orderReq(order *exchange.Order) (*spot.OrderReq, error) {
+	req := spot.NewOrderReq(order.Symbol.String())
+	if order.ID != nil {
+		id, err := strconv.ParseInt(order.ID.String(), 10, 64)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad orderID=%s", order.ID.String())
+		}
+		return req.OrderID(id), nil
+	}
+
+	if order.ClientID != nil {
+		return req.OrigClientOrderID(order.ClientID.String()), nil
+	}
+
+	return nil, errors.Errorf("order id or client id is required")
+}
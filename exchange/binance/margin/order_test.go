@@ -0,0 +1,41 @@
+package margin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance/spot"
+)
+
+func TestOrderTransfer(t *testing.T) {
+	sym := NewMarginSymbol(&spot.Symbol{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}, true, true)
+	symbolMap[sym.String()] = sym
+
+	raw := `{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"6gCrw2kRUAF9CvJDGP16IP",
+	"transactTime":1507725176595,"price":"1.00000000","origQty":"10.00000000","executedQty":"10.00000000",
+	"cummulativeQuoteQty":"10.00000000","status":"FILLED","timeInForce":"GTC","type":"MARKET","side":"SELL",
+	"marginBuyBorrowAmount":"5","marginBuyBorrowAsset":"BTC","isIsolated":true,
+	"fills":[{"price":"1","qty":"10","commission":"0.01","commissionAsset":"USDT"}]}`
+
+	var resp OrderResp
+	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	order, err := resp.Transfer()
+	if err != nil {
+		t.Fatalf("transfer fail %s", err.Error())
+	}
+
+	ms, ok := order.Symbol.(exchange.MarginSymbol)
+	if !ok || !ms.Lever().Equal(CrossMarginLever) {
+		t.Errorf("bad symbol %+v", order.Symbol)
+	}
+
+	if !resp.IsIsolated || !resp.MarginBuyBorrowAmount.Equal(decimal.NewFromInt(5)) ||
+		order.Status != exchange.OrderStatusDone || !order.AvgPrice.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("bad order %+v", *order)
+	}
+}
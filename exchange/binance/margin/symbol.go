@@ -0,0 +1,150 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance/spot"
+)
+
+type (
+	// MarginSymbol binance margin pair, precision is the same with spot symbol
+	MarginSymbol struct {
+		*exchange.BaseMarginSymbol
+		Symbol          string
+		CrossAllowed    bool
+		IsolatedAllowed bool
+	}
+
+	Pair struct {
+		ID            int64  `json:"id"`
+		Symbol        string `json:"symbol"`
+		Base          string `json:"base"`
+		Quote         string `json:"quote"`
+		IsMarginTrade bool   `json:"isMarginTrade"`
+		IsBuyAllowed  bool   `json:"isBuyAllowed"`
+		IsSellAllowed bool   `json:"isSellAllowed"`
+	}
+)
+
+const (
+	CrossPairsEndPoint    = "/sapi/v1/margin/allPairs"
+	IsolatedPairsEndPoint = "/sapi/v1/margin/isolated/allPairs"
+)
+
+var (
+	// CrossMarginLever max leverage of cross margin account
+	CrossMarginLever = decimal.NewFromInt(3)
+	// IsolatedMarginLever max leverage of isolated margin account in the first tier
+	IsolatedMarginLever = decimal.NewFromInt(10)
+
+	symbolMap = map[string]exchange.MarginSymbol{}
+)
+
+// Init load margin symbols, the pairs endpoint require api key
+func Init(ctx context.Context, rc *RestClient) error {
+	symbols, err := rc.Symbols(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "fetch symbols fail")
+	}
+
+	for _, s := range symbols {
+		symbolMap[s.String()] = s
+	}
+	return nil
+}
+
+func ParseSymbol(symbol string) (exchange.MarginSymbol, error) {
+	sym, ok := symbolMap[symbol]
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol %s", symbol)
+	}
+	return sym, nil
+}
+
+func (rc *RestClient) CrossPairs(ctx context.Context) ([]Pair, error) {
+	var ret []Pair
+	if err := rc.Request(ctx, http.MethodGet, CrossPairsEndPoint, nil, nil, false, &ret); err != nil {
+		return nil, errors.WithMessage(err, "fetch cross pairs fail")
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) IsolatedPairs(ctx context.Context) ([]Pair, error) {
+	var ret []Pair
+	if err := rc.Request(ctx, http.MethodGet, IsolatedPairsEndPoint, nil, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "fetch isolated pairs fail")
+	}
+	return ret, nil
+}
+
+// Symbols merge spot exchange info with cross and isolated margin pairs
+func (rc *RestClient) Symbols(ctx context.Context) ([]exchange.MarginSymbol, error) {
+	info, err := spot.NewRestClient("", "").ExchangeInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cross, err := rc.CrossPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	isolated, err := rc.IsolatedPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	crossMap := make(map[string]struct{}, len(cross))
+	for _, p := range cross {
+		if p.IsMarginTrade {
+			crossMap[p.Symbol] = struct{}{}
+		}
+	}
+
+	isolatedMap := make(map[string]struct{}, len(isolated))
+	for _, p := range isolated {
+		if p.IsMarginTrade {
+			isolatedMap[p.Symbol] = struct{}{}
+		}
+	}
+
+	var ret []exchange.MarginSymbol
+	for i := range info.Symbols {
+		sym := &info.Symbols[i]
+		_, c := crossMap[sym.Symbol]
+		_, iso := isolatedMap[sym.Symbol]
+		if !c && !iso {
+			continue
+		}
+		ret = append(ret, NewMarginSymbol(sym, c, iso))
+	}
+	return ret, nil
+}
+
+// NewMarginSymbol build MarginSymbol from spot symbol. Lever is cross
+// margin leverage unless the pair is isolated only
+func NewMarginSymbol(sym *spot.Symbol, cross, isolated bool) *MarginSymbol {
+	lever := CrossMarginLever
+	if !cross {
+		lever = IsolatedMarginLever
+	}
+
+	return &MarginSymbol{
+		BaseMarginSymbol: exchange.NewBaseMarginSymbol(sym.BaseAsset, sym.QuoteAsset, sym.Config(), lever, sym),
+		Symbol:           sym.Symbol,
+		CrossAllowed:     cross,
+		IsolatedAllowed:  isolated,
+	}
+}
+
+func (ms *MarginSymbol) String() string {
+	return ms.Symbol
+}
+
+func parseSymbol(symbol string) (exchange.Symbol, error) {
+	return ParseSymbol(symbol)
+}
@@ -0,0 +1,22 @@
+package margin
+
+import (
+	"github.com/szmcdull/ccexgo/exchange/binance/spot"
+)
+
+// NewUserDataCodeC margin user data stream share message format with spot
+func NewUserDataCodeC() *spot.UserDataCodeC {
+	return spot.NewUserDataCodeCWithParser(parseSymbol)
+}
+
+// NewUserDataWSClient return cross margin user data stream client
+func NewUserDataWSClient(key, secret string, data chan interface{}) *spot.UserDataWSClient {
+	return spot.NewUserDataWSClientWithCodeC(NewUserDataCodeC(), NewRestClient(key, secret), data)
+}
+
+// NewIsolatedUserDataWSClient return isolated margin user data stream client of the symbol
+func NewIsolatedUserDataWSClient(key, secret, symbol string, data chan interface{}) *spot.UserDataWSClient {
+	rc := NewIsolatedRestClient(key, secret)
+	rc.symbol = symbol
+	return spot.NewUserDataWSClientWithCodeC(NewUserDataCodeC(), rc, data)
+}
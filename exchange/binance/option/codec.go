@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"strconv"
 	"sync"
@@ -12,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
 	"github.com/szmcdull/ccexgo/internal/rpc"
+	"github.com/tidwall/gjson"
 )
 
 type (
@@ -23,6 +23,7 @@ type (
 	}
 
 	respAccount struct {
+		Asset            string          `json:"a"`
 		Total            decimal.Decimal `json:"b"`
 		PosValue         decimal.Decimal `json:"m"`
 		UnrealizedPNL    decimal.Decimal `json:"u"`
@@ -51,10 +52,13 @@ type (
 		Qty       decimal.Decimal `json:"q"`
 		TradeTime int64           `json:"T"`
 		Maker     int             `json:"m"`
+		Fee       decimal.Decimal `json:"f"`
 	}
 	respOrder struct {
 		Time     int64           `json:"T"`
 		OrderID  string          `json:"oid"`
+		ClientID string          `json:"c"`
+		Type     string          `json:"oty"`
 		Symbol   string          `json:"S"`
 		Price    decimal.Decimal `json:"p"`
 		Qty      decimal.Decimal `json:"q"`
@@ -78,13 +82,23 @@ type (
 		Desc     string         `json:"desc"`
 	}
 
+	// CodeC decode gzipped option websocket message, depth is merged into the order
+	// book of the symbol so the codec should not be shared by connections
 	CodeC struct {
+		mu    sync.Mutex
+		books map[string]*depthBook
 	}
 )
 
 const (
 	MethodSubscribe = "SUBSCRIBE"
 	MethodPing      = "PING"
+
+	EventDepth            = "depth"
+	EventTicker           = "ticker"
+	EventMarkPrice        = "markPrice"
+	EventAccountUpdate    = "ACCOUNT_UPDATE"
+	EventOrderTradeUpdate = "ORDER_TRADE_UPDATE"
 )
 
 var (
@@ -92,7 +106,9 @@ var (
 )
 
 func NewCodeC() *CodeC {
-	return &CodeC{}
+	return &CodeC{
+		books: map[string]*depthBook{},
+	}
 }
 
 func (cc *CodeC) Encode(rpc rpc.Request) ([]byte, error) {
@@ -142,14 +158,64 @@ func (cc *CodeC) Decode(raw []byte) (rpc.Response, error) {
 		return &rpc.Result{}, nil
 	}
 
-	var resp wsResp
-	if err := json.Unmarshal(all, &resp); err != nil {
-		fmt.Printf("%s %s\n", string(all), err.Error())
-		return nil, errors.WithMessage(err, "unmarshal json fail")
+	return cc.decodeMessage(all)
+}
+
+func (cc *CodeC) decodeMessage(msg []byte) (rpc.Response, error) {
+	g := gjson.ParseBytes(msg)
+	if g.IsArray() {
+		mp, err := ParseMarkPrice(msg)
+		if err != nil {
+			return nil, err
+		}
+		return &rpc.Notify{
+			Method: EventMarkPrice,
+			Params: mp,
+		}, nil
+	}
+
+	if g.Get("id").Exists() && !g.Get("e").Exists() {
+		var err error
+		if g.Get("code").Exists() {
+			err = errors.Errorf("error code: %d msg: %s", g.Get("code").Int(), g.Get("msg").String())
+		}
+		return &rpc.Result{
+			ID:    g.Get("id").String(),
+			Error: err,
+		}, nil
+	}
+
+	var (
+		event = g.Get("e").String()
+		param interface{}
+		err   error
+	)
+	switch event {
+	case EventDepth:
+		param, err = cc.parseDepth(msg)
+		if err == nil && param == nil {
+			// diff buffered or already merged
+			return nil, nil
+		}
+
+	case EventTicker:
+		param, err = ParseTicker(msg)
+
+	case EventAccountUpdate:
+		param, err = ParseAccountUpdate(msg)
+
+	case EventOrderTradeUpdate:
+		param, err = ParseOrderTradeUpdate(msg)
+
+	default:
+		return nil, errors.Errorf("unsupport event '%s' msg=%s", event, string(msg))
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return &rpc.Notify{
-		Method: "",
-		Params: &resp,
+		Method: event,
+		Params: param,
 	}, nil
 }
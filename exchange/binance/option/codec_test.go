@@ -0,0 +1,193 @@
+package option
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func initTestSymbol(t *testing.T) {
+	oi := &OptionInfo{
+		Underlying:    "BTCUSDT",
+		QuoteAsset:    "USDT",
+		Symbol:        "BTC-210430-60000-C",
+		Side:          SideCall,
+		StrikePrice:   decimal.NewFromInt(60000),
+		PriceScale:    2,
+		QuantityScale: 4,
+		ExpiryDate:    1619769600000,
+	}
+	sym, err := oi.Parse()
+	if err != nil {
+		t.Fatalf("parse symbol fail %s", err.Error())
+	}
+
+	mu.Lock()
+	symbolMap = map[string]*Symbol{oi.Symbol: sym}
+	mu.Unlock()
+}
+
+func decode(cc *CodeC, msg string) (rpc.Response, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(msg))
+	w.Close()
+
+	return cc.Decode(buf.Bytes())
+}
+
+func testDecode(t *testing.T, cc *CodeC, msg string) interface{} {
+	resp, err := decode(cc, msg)
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+	return resp.(*rpc.Notify).Params
+}
+
+func TestDecodeDepth(t *testing.T) {
+	initTestSymbol(t)
+	cc := NewCodeC()
+
+	book := testDecode(t, cc, `{"e":"depth","E":1619766000000,"s":"BTC-210430-60000-C","u":1,
+		"b":[["10.00","1.0"],["9.00","2.0"]],"a":[["11.00","1.5"],["12.00","3.0"]]}`).(*exchange.OrderBook)
+	if len(book.Bids) != 2 || book.Bids[0].Price != 10 || book.Asks[0].Price != 11 {
+		t.Errorf("bad snapshot %+v", *book)
+	}
+
+	book = testDecode(t, cc, `{"e":"depth","E":1619766000100,"s":"BTC-210430-60000-C","U":2,"u":3,"pu":1,
+		"b":[["10.00","0"],["9.50","1.0"]],"a":[["11.00","2.5"]]}`).(*exchange.OrderBook)
+	if len(book.Bids) != 2 || book.Bids[0].Price != 9.5 || book.Asks[0].Amount != 2.5 ||
+		book.Created.UnixNano()/1e6 != 1619766000100 {
+		t.Errorf("bad merged book %+v", *book)
+	}
+
+	gap := testDecode(t, cc, `{"e":"depth","E":1619766000200,"s":"BTC-210430-60000-C","U":5,"u":6,"pu":4,
+		"b":[],"a":[]}`).(*DepthGap)
+	if gap.Symbol != "BTC-210430-60000-C" || !errors.Is(gap.Err, ErrDepthGap) {
+		t.Errorf("gap should drop the book %+v", gap)
+	}
+
+	// diffs are buffered until the snapshot applied
+	for _, msg := range []string{
+		`{"e":"depth","E":1619766000300,"s":"BTC-210430-60000-C","U":7,"u":7,"pu":6,"b":[["7.00","1.0"]],"a":[]}`,
+		`{"e":"depth","E":1619766000400,"s":"BTC-210430-60000-C","U":8,"u":9,"pu":7,"b":[["8.50","1.0"]],"a":[]}`,
+	} {
+		if resp, err := decode(cc, msg); resp != nil || err != nil {
+			t.Errorf("diff should be buffered %+v %v", resp, err)
+		}
+	}
+
+	if _, err := cc.ApplyDepthSnapshot("BTC-210430-60000-C", &DepthSnapshot{UpdateID: 3}); !errors.Is(err, ErrDepthGap) {
+		t.Errorf("old snapshot should not be applied %v", err)
+	}
+	book, err := cc.ApplyDepthSnapshot("BTC-210430-60000-C", &DepthSnapshot{
+		UpdateID: 7,
+		Bids:     [][2]string{{"8.00", "1.0"}},
+		Asks:     [][2]string{{"13.00", "1.0"}},
+	})
+	if err != nil || len(book.Bids) != 2 || book.Bids[0].Price != 8.5 || book.Asks[0].Price != 13 {
+		t.Fatalf("bad resynced book %+v %v", book, err)
+	}
+
+	book = testDecode(t, cc, `{"e":"depth","E":1619766000500,"s":"BTC-210430-60000-C","U":10,"u":10,"pu":9,
+		"b":[["8.50","0"]],"a":[]}`).(*exchange.OrderBook)
+	if len(book.Bids) != 1 || book.Bids[0].Price != 8 {
+		t.Errorf("bad merged book after resync %+v", *book)
+	}
+}
+
+func TestMarketWSClientResync(t *testing.T) {
+	initTestSymbol(t)
+	data := make(chan interface{}, 4)
+	mc := newMarketWSClient(MarketWSAddr, data, func(ctx context.Context, symbol string) (*DepthSnapshot, error) {
+		return &DepthSnapshot{UpdateID: 1, Bids: [][2]string{{"10.00", "1.0"}}}, nil
+	})
+
+	resp, err := decode(mc.codec, `{"e":"depth","E":1619766000000,"s":"BTC-210430-60000-C","U":1,"u":2,"pu":0,
+		"b":[["9.00","1.0"]],"a":[]}`)
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	mc.Handle(ctx, resp.(*rpc.Notify))
+
+	if wn := (<-data).(*exchange.WSNotify); wn.Data.(*DepthGap) == nil {
+		t.Errorf("gap should be pushed %+v", wn)
+	}
+	select {
+	case v := <-data:
+		book := v.(*exchange.WSNotify).Data.(*exchange.OrderBook)
+		if len(book.Bids) != 2 {
+			t.Errorf("bad resynced book %+v", *book)
+		}
+	case <-ctx.Done():
+		t.Errorf("resynced book not pushed")
+	}
+}
+
+func TestDecodeMarket(t *testing.T) {
+	initTestSymbol(t)
+	cc := NewCodeC()
+
+	ticker := testDecode(t, cc, `{"e":"ticker","E":1619766000000,"T":1619765999000,"s":"BTC-210430-60000-C",
+		"c":"200","bo":"190","ao":"210","bq":"1","aq":"2","b":"0.8","a":"0.9","d":"0.3","t":"-50","g":"0.0001",
+		"v":"20","vo":"0.85","mp":"200"}`).(*exchange.Ticker)
+	tn := ticker.Raw.(*TickerNotify)
+	if !ticker.BestBid.Equal(decimal.NewFromInt(190)) || !ticker.MarkPrice.Equal(decimal.NewFromInt(200)) ||
+		!tn.MarkIV.Equal(decimal.RequireFromString("0.85")) || !tn.Delta.Equal(decimal.RequireFromString("0.3")) {
+		t.Errorf("bad ticker %+v", *ticker)
+	}
+
+	mp := testDecode(t, cc, `[{"e":"markPrice","E":1619766000000,"s":"BTC-210430-60000-C","mp":"200",
+		"vo":"0.85","d":"0.3"}]`).([]*MarkPrice)
+	if len(mp) != 1 || !mp[0].MarkPrice.Equal(decimal.NewFromInt(200)) || !mp[0].Delta.Equal(decimal.RequireFromString("0.3")) {
+		t.Errorf("bad mark price %+v", mp)
+	}
+
+	if ch := NewMarkPriceChannel("btcusdt").String(); ch != "BTCUSDT@markPrice" {
+		t.Errorf("bad channel %s", ch)
+	}
+}
+
+func TestDecodeUserData(t *testing.T) {
+	initTestSymbol(t)
+	cc := NewCodeC()
+
+	au := testDecode(t, cc, `{"e":"ACCOUNT_UPDATE","E":1619766000000,
+		"B":[{"a":"USDT","b":"1000","m":"100","u":"-20","o":"10","p":"40","r":"0","M":"5"}],
+		"P":[{"S":"BTC-210430-60000-C","c":"-2","r":"-1","p":"-400","a":"200"}]}`).(*AccountUpdate)
+	balance, err := au.Balances.Get("USDT")
+	if err != nil || !balance.Equitity.Equal(decimal.NewFromInt(980)) || !balance.Free.Equal(decimal.NewFromInt(930)) {
+		t.Errorf("bad balance %+v %v", balance, err)
+	}
+	if len(au.Positions) != 1 || au.Positions[0].Side != exchange.PositionSideShort ||
+		!au.Positions[0].Position.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("bad positions %+v", au.Positions)
+	}
+
+	ou := testDecode(t, cc, `{"e":"ORDER_TRADE_UPDATE","E":1619766000000,
+		"o":[{"T":1619765999000,"oid":"4611869636869226548","c":"cid","S":"BTC-210430-60000-C","p":"200",
+		"q":"-2","s":4,"e":"-1","ec":"-200","f":"0.2","oty":"LIMIT",
+		"fi":[{"t":"20","p":"200","q":"-1","T":1619765999500,"m":1,"f":"0.2"}]}]}`).(*OrderTradeUpdate)
+	if len(ou.Orders) != 1 || len(ou.Trades) != 1 {
+		t.Fatalf("bad order update %+v", *ou)
+	}
+	order := ou.Orders[0]
+	if order.Side != exchange.OrderSideSell || order.Status != exchange.OrderStatusOpen ||
+		!order.Filled.Equal(decimal.NewFromInt(1)) || !order.AvgPrice.Equal(decimal.NewFromInt(200)) ||
+		order.ClientID.String() != "cid" {
+		t.Errorf("bad order %+v", *order)
+	}
+	if trade := ou.Trades[0]; !trade.IsMaker || !trade.Fee.Equal(decimal.RequireFromString("-0.2")) ||
+		trade.OrderID != "4611869636869226548" {
+		t.Errorf("bad trade %+v", *trade)
+	}
+}
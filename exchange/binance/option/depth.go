@@ -1,9 +1,15 @@
 package option
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
 )
 
 type (
@@ -23,3 +29,292 @@ func NewDepthChannel(sym exchange.OptionSymbol, level int) *DepthChannel {
 func (dc *DepthChannel) String() string {
 	return fmt.Sprintf("%s@depth%d", dc.sym.String(), dc.level)
 }
+
+type (
+	// DepthSnapshot rest depth of the symbol, UpdateID is used to sync the diff depth stream
+	DepthSnapshot struct {
+		TransactTime int64       `json:"T"`
+		UpdateID     int64       `json:"u"`
+		Bids         [][2]string `json:"bids"`
+		Asks         [][2]string `json:"asks"`
+	}
+
+	// DepthSnapshotFunc fetch the depth snapshot of the symbol to resync the book
+	DepthSnapshotFunc func(ctx context.Context, symbol string) (*DepthSnapshot, error)
+
+	// DepthReq request of the rest depth
+	DepthReq struct {
+		*binance.RestReq
+	}
+
+	// DepthGap notify the diff depth of the symbol is missing, the book is dropped and
+	// diffs are buffered until a snapshot is applied by ApplyDepthSnapshot
+	DepthGap struct {
+		Symbol string
+		Err    error
+	}
+
+	depthBook struct {
+		book     *exchange.OrderBookDS
+		updateID int64
+		synced   bool           // whether diff after the snapshot is merged
+		syncing  bool           // waiting for the snapshot, book is nil
+		buffer   []*DepthNotify // diffs received while syncing
+	}
+
+	// DepthNotify depth message, U and pu is only set by diff depth stream
+	DepthNotify struct {
+		Event         string      `json:"e"`
+		EventTime     int64       `json:"E"`
+		TransactTime  int64       `json:"T"`
+		Symbol        string      `json:"s"`
+		UpdateID      int64       `json:"u"`
+		FirstUpdateID int64       `json:"U"`
+		PrevUpdateID  int64       `json:"pu"`
+		Bids          [][2]string `json:"b"`
+		Asks          [][2]string `json:"a"`
+	}
+)
+
+const (
+	DepthEndPoint = "/vapi/v1/depth"
+
+	// maxDepthBuffer max number of diffs buffered while syncing, the oldest is dropped
+	maxDepthBuffer = 1000
+)
+
+var (
+	// ErrDepthGap diff depth is missing and the book is dropped
+	ErrDepthGap = errors.New("depth update id gap")
+)
+
+func NewDepthReq(symbol string) *DepthReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	return &DepthReq{
+		RestReq: req,
+	}
+}
+
+// Limit level of the depth, valid limits are 10, 20, 50, 100, 500 and 1000
+func (dr *DepthReq) Limit(limit int) *DepthReq {
+	dr.AddFields("limit", limit)
+	return dr
+}
+
+func (rc *RestClient) Depth(ctx context.Context, req *DepthReq) (*DepthSnapshot, error) {
+	var ret DepthSnapshot
+	if err := rc.GetRequest(ctx, DepthEndPoint, req, false, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// DepthSnapshot fetch the 1000 level depth, which is used by CodeC to resync the book
+func (rc *RestClient) DepthSnapshot(ctx context.Context, symbol string) (*DepthSnapshot, error) {
+	return rc.Depth(ctx, NewDepthReq(symbol).Limit(1000))
+}
+
+func (ds *DepthSnapshot) Transform(symbol string) (*exchange.OrderBookNotify, error) {
+	sym, err := ParseSymbol(symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	bids, err := parseOrderElem(ds.Bids)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse bids fail")
+	}
+	asks, err := parseOrderElem(ds.Asks)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse asks fail")
+	}
+
+	return &exchange.OrderBookNotify{
+		Symbol: sym,
+		Bids:   bids,
+		Asks:   asks,
+		Raw:    ds,
+	}, nil
+}
+
+// IsDiff whether the message is an incremental update of the book
+func (dn *DepthNotify) IsDiff() bool {
+	return dn.PrevUpdateID != 0 || dn.FirstUpdateID != 0
+}
+
+func (dn *DepthNotify) Transform() (*exchange.OrderBookNotify, error) {
+	sym, err := ParseSymbol(dn.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	bids, err := parseOrderElem(dn.Bids)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse bids fail")
+	}
+	asks, err := parseOrderElem(dn.Asks)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse asks fail")
+	}
+
+	return &exchange.OrderBookNotify{
+		Symbol: sym,
+		Bids:   bids,
+		Asks:   asks,
+		Raw:    dn,
+	}, nil
+}
+
+// parseDepth merge depth message into the book of the symbol and return the
+// snapshot of the book. partial depth rebuild the book every time. diff depth must
+// follow the last update id (pu == last u), otherwise the book is dropped and
+// *DepthGap is returned, the diffs are buffered until ApplyDepthSnapshot. nil is
+// returned for diffs buffered or already merged
+func (cc *CodeC) parseDepth(msg []byte) (interface{}, error) {
+	var dn DepthNotify
+	if err := json.Unmarshal(msg, &dn); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal depth fail")
+	}
+
+	notify, err := dn.Transform()
+	if err != nil {
+		return nil, err
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	db, ok := cc.books[dn.Symbol]
+	if !dn.IsDiff() {
+		db = &depthBook{
+			book:     exchange.NewOrderBookDS(notify),
+			updateID: dn.UpdateID,
+			synced:   true,
+		}
+		cc.books[dn.Symbol] = db
+		return depthSnapshot(db, &dn), nil
+	}
+
+	if ok && db.syncing {
+		if len(db.buffer) == maxDepthBuffer {
+			db.buffer = db.buffer[1:]
+		}
+		db.buffer = append(db.buffer, &dn)
+		return nil, nil
+	}
+
+	if ok && dn.UpdateID <= db.updateID {
+		return nil, nil
+	}
+
+	var gap error
+	switch {
+	case !ok:
+		gap = errors.WithMessagef(ErrDepthGap, "no snapshot of %s", dn.Symbol)
+
+	case db.synced && dn.PrevUpdateID != db.updateID:
+		gap = errors.WithMessagef(ErrDepthGap, "diff %d follows %d of %s", dn.PrevUpdateID, db.updateID, dn.Symbol)
+
+	case !db.synced && dn.FirstUpdateID > db.updateID+1:
+		// the first diff after the snapshot should cover the update id of the snapshot
+		gap = errors.WithMessagef(ErrDepthGap, "snapshot %d is older than diff %d-%d of %s",
+			db.updateID, dn.FirstUpdateID, dn.UpdateID, dn.Symbol)
+	}
+	if gap != nil {
+		cc.books[dn.Symbol] = &depthBook{
+			syncing: true,
+			buffer:  []*DepthNotify{&dn},
+		}
+		return &DepthGap{Symbol: dn.Symbol, Err: gap}, nil
+	}
+
+	db.book.Update(notify)
+	db.updateID = dn.UpdateID
+	db.synced = true
+	return depthSnapshot(db, &dn), nil
+}
+
+// ApplyDepthSnapshot rebuild the book of the symbol after *DepthGap and merge the
+// buffered diffs. ErrDepthGap is returned if the snapshot can not be followed by the
+// buffered diffs, another snapshot should be applied later. nil book is returned if
+// the symbol is not waiting for snapshot
+func (cc *CodeC) ApplyDepthSnapshot(symbol string, ds *DepthSnapshot) (*exchange.OrderBook, error) {
+	notify, err := ds.Transform(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	db, ok := cc.books[symbol]
+	if !ok || !db.syncing {
+		return nil, nil
+	}
+
+	book := exchange.NewOrderBookDS(notify)
+	last := ds.UpdateID
+	var latest *DepthNotify
+	for _, dn := range db.buffer {
+		if dn.UpdateID <= last {
+			continue
+		}
+
+		if (latest == nil && dn.FirstUpdateID > last+1) || (latest != nil && dn.PrevUpdateID != last) {
+			return nil, errors.WithMessagef(ErrDepthGap, "snapshot %d of %s can not be followed by diff %d-%d",
+				ds.UpdateID, symbol, dn.FirstUpdateID, dn.UpdateID)
+		}
+
+		diff, err := dn.Transform()
+		if err != nil {
+			return nil, err
+		}
+		book.Update(diff)
+		last = dn.UpdateID
+		latest = dn
+	}
+
+	db = &depthBook{
+		book:     book,
+		updateID: last,
+		synced:   latest != nil,
+	}
+	cc.books[symbol] = db
+
+	ret := db.book.Snapshot()
+	if latest != nil {
+		ret.Created = time.Unix(0, latest.EventTime*int64(time.Millisecond))
+		ret.Raw = latest
+	} else {
+		ret.Created = time.Unix(0, ds.TransactTime*int64(time.Millisecond))
+		ret.Raw = ds
+	}
+	return ret, nil
+}
+
+func depthSnapshot(db *depthBook, dn *DepthNotify) *exchange.OrderBook {
+	ret := db.book.Snapshot()
+	ret.Created = time.Unix(0, dn.EventTime*int64(time.Millisecond))
+	ret.Raw = dn
+	return ret
+}
+
+func parseOrderElem(data [][2]string) ([]exchange.OrderElem, error) {
+	ret := make([]exchange.OrderElem, len(data))
+	for i, d := range data {
+		price, err := strconv.ParseFloat(d[0], 64)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parse price '%s' fail", d[0])
+		}
+		amount, err := strconv.ParseFloat(d[1], 64)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parse amount '%s' fail", d[1])
+		}
+		ret[i] = exchange.OrderElem{
+			Price:  price,
+			Amount: amount,
+		}
+	}
+	return ret, nil
+}
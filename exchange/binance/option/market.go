@@ -0,0 +1,127 @@
+package option
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	TickerChannel struct {
+		sym exchange.OptionSymbol
+	}
+
+	// MarkPriceChannel mark price of all the options of the underlying
+	MarkPriceChannel struct {
+		underlying string
+	}
+
+	Greeks struct {
+		Delta decimal.Decimal `json:"d"`
+		Theta decimal.Decimal `json:"t"`
+		Gamma decimal.Decimal `json:"g"`
+		Vega  decimal.Decimal `json:"v"`
+	}
+
+	TickerNotify struct {
+		Greeks
+		Event         string          `json:"e"`
+		EventTime     int64           `json:"E"`
+		TransactTime  int64           `json:"T"`
+		Symbol        string          `json:"s"`
+		Open          decimal.Decimal `json:"o"`
+		High          decimal.Decimal `json:"h"`
+		Low           decimal.Decimal `json:"l"`
+		Close         decimal.Decimal `json:"c"`
+		Volume        decimal.Decimal `json:"V"`
+		Amount        decimal.Decimal `json:"A"`
+		BestBid       decimal.Decimal `json:"bo"`
+		BestAsk       decimal.Decimal `json:"ao"`
+		BestBidSize   decimal.Decimal `json:"bq"`
+		BestAskSize   decimal.Decimal `json:"aq"`
+		BidIV         decimal.Decimal `json:"b"`
+		AskIV         decimal.Decimal `json:"a"`
+		MarkIV        decimal.Decimal `json:"vo"`
+		MarkPrice     decimal.Decimal `json:"mp"`
+		ExercisePrice decimal.Decimal `json:"eep"`
+	}
+
+	// MarkPrice mark price with iv and greeks, the iv and greeks fields is zero if
+	// not pushed
+	MarkPrice struct {
+		Greeks
+		Event     string          `json:"e"`
+		EventTime int64           `json:"E"`
+		Symbol    string          `json:"s"`
+		MarkPrice decimal.Decimal `json:"mp"`
+		BidIV     decimal.Decimal `json:"b"`
+		AskIV     decimal.Decimal `json:"a"`
+		MarkIV    decimal.Decimal `json:"vo"`
+	}
+)
+
+func NewTickerChannel(sym exchange.OptionSymbol) *TickerChannel {
+	return &TickerChannel{
+		sym: sym,
+	}
+}
+
+func (tc *TickerChannel) String() string {
+	return fmt.Sprintf("%s@ticker", tc.sym.String())
+}
+
+func NewMarkPriceChannel(underlying string) *MarkPriceChannel {
+	return &MarkPriceChannel{
+		underlying: strings.ToUpper(underlying),
+	}
+}
+
+func (mc *MarkPriceChannel) String() string {
+	return fmt.Sprintf("%s@markPrice", mc.underlying)
+}
+
+// ParseTicker parse ticker message to exchange.Ticker with the *TickerNotify as Raw field
+func ParseTicker(msg []byte) (*exchange.Ticker, error) {
+	var tn TickerNotify
+	if err := json.Unmarshal(msg, &tn); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal ticker fail")
+	}
+	return tn.Transform()
+}
+
+func (tn *TickerNotify) Transform() (*exchange.Ticker, error) {
+	sym, err := ParseSymbol(tn.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	return &exchange.Ticker{
+		Symbol:      sym,
+		BestBid:     tn.BestBid,
+		BestBidSize: tn.BestBidSize,
+		BestAsk:     tn.BestAsk,
+		BestAskSize: tn.BestAskSize,
+		MarkPrice:   tn.MarkPrice,
+		LastPrice:   tn.Close,
+		Time:        time.Unix(0, tn.EventTime*int64(time.Millisecond)),
+		Raw:         tn,
+	}, nil
+}
+
+// ParseMarkPrice parse markPrice message which is an array of all the options of the underlying
+func ParseMarkPrice(msg []byte) ([]*MarkPrice, error) {
+	var ret []*MarkPrice
+	if err := json.Unmarshal(msg, &ret); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal markPrice fail")
+	}
+	return ret, nil
+}
+
+func (mp *MarkPrice) Time() time.Time {
+	return time.Unix(0, mp.EventTime*int64(time.Millisecond))
+}
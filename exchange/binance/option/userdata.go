@@ -0,0 +1,202 @@
+package option
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	// AccountUpdate ACCOUNT_UPDATE event of the listenKey stream
+	AccountUpdate struct {
+		Balances  *exchange.Balances
+		Updates   []*exchange.BalanceUpdate
+		Positions []*exchange.Position
+		Time      time.Time
+	}
+
+	// OrderTradeUpdate ORDER_TRADE_UPDATE event of the listenKey stream, Trades
+	// is the fills carried by the orders
+	OrderTradeUpdate struct {
+		Orders []*exchange.Order
+		Trades []*exchange.Trade
+		Time   time.Time
+	}
+)
+
+const (
+	defaultMarginAsset = "USDT"
+)
+
+func ParseAccountUpdate(msg []byte) (*AccountUpdate, error) {
+	var resp wsResp
+	if err := json.Unmarshal(msg, &resp); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal account update fail")
+	}
+
+	ret := &AccountUpdate{
+		Balances: &exchange.Balances{
+			Balances: map[string]*exchange.Balance{},
+			Raw:      &resp,
+		},
+		Positions: make([]*exchange.Position, len(resp.Position)),
+		Time:      time.Unix(0, resp.TS*int64(time.Millisecond)),
+	}
+
+	for i := range resp.Account {
+		balance := resp.Account[i].Transfer()
+		ret.Balances.Add(balance)
+		ret.Updates = append(ret.Updates, exchange.NewBalanceUpdate(balance, exchange.BalanceFieldAll,
+			exchange.BalanceReasonOther, ret.Time, &resp.Account[i]))
+	}
+
+	for i := range resp.Position {
+		pos, err := resp.Position[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		ret.Positions[i] = pos
+	}
+	return ret, nil
+}
+
+// BalanceUpdates implement exchange.BalanceUpdater
+func (au *AccountUpdate) BalanceUpdates() []*exchange.BalanceUpdate {
+	return au.Updates
+}
+
+// PositionUpdates implement exchange.PositionUpdater
+func (au *AccountUpdate) PositionUpdates() []*exchange.Position {
+	return au.Positions
+}
+
+// OrderUpdates implement exchange.OrderUpdater
+func (ou *OrderTradeUpdate) OrderUpdates() []*exchange.Order {
+	return ou.Orders
+}
+
+func ParseOrderTradeUpdate(msg []byte) (*OrderTradeUpdate, error) {
+	var resp wsResp
+	if err := json.Unmarshal(msg, &resp); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal order update fail")
+	}
+
+	ret := &OrderTradeUpdate{
+		Orders: make([]*exchange.Order, len(resp.Order)),
+		Time:   time.Unix(0, resp.TS*int64(time.Millisecond)),
+	}
+	for i := range resp.Order {
+		order, trades, err := resp.Order[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		ret.Orders[i] = order
+		ret.Trades = append(ret.Trades, trades...)
+	}
+	return ret, nil
+}
+
+func (ra *respAccount) Transfer() *exchange.Balance {
+	currency := ra.Asset
+	if currency == "" {
+		currency = defaultMarginAsset
+	}
+	currency = exchange.CurrencyFormat(currency)
+
+	equity := ra.Total.Add(ra.UnrealizedPNL)
+	frozen := ra.OrderFrozen.Add(ra.PositionFrozen)
+	return &exchange.Balance{
+		Currency: currency,
+		Total:    ra.Total,
+		Equitity: equity,
+		Frozen:   frozen,
+		Free:     equity.Sub(frozen),
+	}
+}
+
+// Transfer convert position of listenKey stream, negative quantity means short position
+func (rp *respPosition) Transfer() (*exchange.Position, error) {
+	sym, err := ParseSymbol(rp.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	var side exchange.PositionSide = exchange.PositionSideLong
+	if rp.TotalQty.IsNegative() {
+		side = exchange.PositionSideShort
+	}
+
+	return &exchange.Position{
+		Symbol:        sym,
+		Side:          side,
+		AvgOpenPrice:  rp.AvgPrice,
+		Position:      rp.TotalQty.Abs(),
+		AvailPosition: rp.ReducibleQty.Abs(),
+		Raw:           rp,
+	}, nil
+}
+
+// Transfer convert order of listenKey stream with its fills, negative quantity means sell order
+func (ro *respOrder) Transfer() (*exchange.Order, []*exchange.Trade, error) {
+	sym, err := ParseSymbol(ro.Symbol)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	status, ok := bnOrderStatusToStatus[OrderStatus(ro.Status)]
+	if !ok {
+		return nil, nil, errors.Errorf("unkown orderStatus=%d", ro.Status)
+	}
+
+	typ := exchange.OrderTypeLimit
+	if ro.Type != "" {
+		if typ, ok = bnOrderTypeToType[ro.Type]; !ok {
+			return nil, nil, errors.Errorf("unkown orderType='%s'", ro.Type)
+		}
+	}
+
+	side := exchange.OrderSideBuy
+	if ro.Qty.IsNegative() {
+		side = exchange.OrderSideSell
+	}
+
+	ts := time.Unix(0, ro.Time*int64(time.Millisecond))
+	order := &exchange.Order{
+		ID:       exchange.NewStrID(ro.OrderID),
+		ClientID: exchange.NewStrID(ro.ClientID),
+		Symbol:   sym,
+		Status:   status,
+		Side:     side,
+		Type:     typ,
+		Price:    ro.Price,
+		Amount:   ro.Qty.Abs(),
+		Filled:   ro.ExeQty.Abs(),
+		Fee:      ro.Fee,
+		Created:  ts,
+		Updated:  ts,
+		Raw:      ro,
+	}
+	if !order.Filled.IsZero() {
+		order.AvgPrice = ro.ExeValue.Abs().Div(order.Filled)
+	}
+
+	trades := make([]*exchange.Trade, len(ro.Filled))
+	for i := range ro.Filled {
+		fi := &ro.Filled[i]
+		trades[i] = &exchange.Trade{
+			ID:      fi.TradeID,
+			OrderID: ro.OrderID,
+			Symbol:  sym,
+			Price:   fi.Price,
+			Amount:  fi.Qty.Abs(),
+			Fee:     fi.Fee.Neg(),
+			Time:    time.Unix(0, fi.TradeTime*int64(time.Millisecond)),
+			Side:    side,
+			IsMaker: fi.Maker == 1,
+			Raw:     fi,
+		}
+	}
+	return order, trades, nil
+}
@@ -2,12 +2,14 @@ package option
 
 import (
 	"context"
-	"fmt"
+	"time"
 
+	"github.com/go-kit/log/level"
 	"github.com/pkg/errors"
 	"github.com/szmcdull/ccexgo/exchange"
 	"github.com/szmcdull/ccexgo/exchange/binance"
 	"github.com/szmcdull/ccexgo/internal/rpc"
+	"github.com/szmcdull/ccexgo/misc/ctxlog"
 )
 
 type (
@@ -15,8 +17,35 @@ type (
 		*binance.WSClient
 		data chan interface{}
 	}
+
+	// MarketWSClient public market data client, depth ticker and markPrice notify
+	// is pushed to data as *exchange.WSNotify. on *DepthGap the snapshot is fetched
+	// in background and the resynced book is pushed as depth notify
+	MarketWSClient struct {
+		*binance.NotifyClient
+		codec    *CodeC
+		snapshot DepthSnapshotFunc
+	}
+)
+
+const (
+	MarketWSAddr     = "wss://vstream.binance.com/ws"
+	TestMarketWSAddr = "wss://testnetws.binanceops.com/ws"
+
+	depthResyncInterval = time.Second
 )
 
+// NewWSClient return a wsclient which decode listenKey stream to
+// *AccountUpdate and *OrderTradeUpdate
+func NewWSClient(data chan interface{}, key, secret string) *WSClient {
+	ret := &WSClient{
+		data: data,
+	}
+	ret.WSClient = binance.NewWSClient(NewCodeC(), ret, NewRestClient(key, secret))
+
+	return ret
+}
+
 // NewTestWSClient return a wsclient which connect to binance option testnet
 func NewTestWSClient(data chan interface{}, key, secret string) *WSClient {
 	ret := &WSClient{
@@ -28,7 +57,65 @@ func NewTestWSClient(data chan interface{}, key, secret string) *WSClient {
 }
 
 func (wl *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
-	fmt.Printf("%s %+v\n", notify.Method, notify.Params)
+	wn := &exchange.WSNotify{
+		Exchange: binance.Exchange,
+		Chan:     notify.Method,
+		Data:     notify.Params,
+	}
+	select {
+	case wl.data <- wn:
+	default:
+	}
+}
+
+func NewMarketWSClient(data chan interface{}) *MarketWSClient {
+	return newMarketWSClient(MarketWSAddr, data, NewRestClient("", "").DepthSnapshot)
+}
+
+func NewTestMarketWSClient(data chan interface{}) *MarketWSClient {
+	return newMarketWSClient(TestMarketWSAddr, data, NewTestRestClient("", "").DepthSnapshot)
+}
+
+func newMarketWSClient(addr string, data chan interface{}, snapshot DepthSnapshotFunc) *MarketWSClient {
+	ret := &MarketWSClient{
+		codec:    NewCodeC(),
+		snapshot: snapshot,
+	}
+	ret.NotifyClient = binance.NewNotifyClient(addr, ret.codec, data, ret)
+	return ret
+}
+
+func (mc *MarketWSClient) Handle(ctx context.Context, notify *rpc.Notify) {
+	mc.Push(notify.Method, notify.Params)
+	if gap, ok := notify.Params.(*DepthGap); ok {
+		go mc.resync(ctx, gap.Symbol)
+	}
+}
+
+// resync fetch the snapshot until it's applied or ctx done, the diffs are buffered
+// by the codec meanwhile
+func (mc *MarketWSClient) resync(ctx context.Context, symbol string) {
+	for {
+		ds, err := mc.snapshot(ctx, symbol)
+		if err == nil {
+			var book *exchange.OrderBook
+			if book, err = mc.codec.ApplyDepthSnapshot(symbol, ds); err == nil {
+				if book != nil {
+					mc.Push(EventDepth, book)
+				}
+				return
+			}
+		}
+
+		logger := ctxlog.GetSafeLog(ctx)
+		level.Warn(logger).Log("message", "resync depth fail", "symbol", symbol, "err", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(depthResyncInterval):
+		}
+	}
 }
 
 func (wl *WSClient) Subscribe(ctx context.Context, channels ...exchange.Channel) error {
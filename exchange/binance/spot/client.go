@@ -5,11 +5,12 @@ import "github.com/szmcdull/ccexgo/exchange/binance"
 type (
 	RestClient struct {
 		*binance.RestClient
+		listenKey string
 	}
 )
 
 func NewRestClient(key, secret string) *RestClient {
 	return &RestClient{
-		binance.NewRestClient(key, secret, "api.binance.com"),
+		RestClient: binance.NewRestClient(key, secret, "api.binance.com"),
 	}
 }
@@ -0,0 +1,55 @@
+package spot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	ListenKeyResp struct {
+		ListenKey string `json:"listenKey"`
+	}
+)
+
+const (
+	ListenKeyEndPoint = "/api/v3/userDataStream"
+)
+
+// GetListenKeyAddr create a listenKey and return the user data stream address
+func (rc *RestClient) GetListenKeyAddr(ctx context.Context) (string, error) {
+	var ret ListenKeyResp
+	if err := rc.Request(ctx, http.MethodPost, ListenKeyEndPoint, nil, nil, false, &ret); err != nil {
+		return "", errors.WithMessage(err, "request listenKey fail")
+	}
+
+	rc.listenKey = ret.ListenKey
+	return fmt.Sprintf("%s/%s", WSClientEndPoint, ret.ListenKey), nil
+}
+
+func (rc *RestClient) PersistListenKey(ctx context.Context) error {
+	var ret map[string]interface{}
+
+	if err := rc.Request(ctx, http.MethodPut, ListenKeyEndPoint, rc.listenKeyValues(), nil, false, &ret); err != nil {
+		return errors.WithMessage(err, "persist listenKey fail")
+	}
+	return nil
+}
+
+func (rc *RestClient) DeleteListenKey(ctx context.Context) error {
+	var ret map[string]interface{}
+
+	if err := rc.Request(ctx, http.MethodDelete, ListenKeyEndPoint, rc.listenKeyValues(), nil, false, &ret); err != nil {
+		return errors.WithMessage(err, "delete listenKey fail")
+	}
+	return nil
+}
+
+func (rc *RestClient) listenKeyValues() url.Values {
+	values := url.Values{}
+	values.Add("listenKey", rc.listenKey)
+	return values
+}
@@ -0,0 +1,571 @@
+package spot
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	AddOrderReq struct {
+		*binance.RestReq
+	}
+
+	AddOCOOrderReq struct {
+		*binance.RestReq
+	}
+
+	OrderReq struct {
+		*binance.RestReq
+	}
+
+	OpenOrdersReq struct {
+		*binance.RestReq
+	}
+
+	Fill struct {
+		Price           decimal.Decimal `json:"price"`
+		Qty             decimal.Decimal `json:"qty"`
+		Commission      decimal.Decimal `json:"commission"`
+		CommissionAsset string          `json:"commissionAsset"`
+		TradeID         int64           `json:"tradeId"`
+	}
+
+	OrderResp struct {
+		binance.APIError                    //in case of error
+		Symbol              string          `json:"symbol"`
+		OrderID             int64           `json:"orderId"`
+		OrderListID         int64           `json:"orderListId"`
+		ClientOrderID       string          `json:"clientOrderId"`
+		OrigClientOrderID   string          `json:"origClientOrderId"`
+		TransactTime        int64           `json:"transactTime"`
+		Price               decimal.Decimal `json:"price"`
+		OrigQty             decimal.Decimal `json:"origQty"`
+		ExecutedQty         decimal.Decimal `json:"executedQty"`
+		CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+		Status              string          `json:"status"`
+		TimeInForce         string          `json:"timeInForce"`
+		Type                string          `json:"type"`
+		Side                string          `json:"side"`
+		StopPrice           decimal.Decimal `json:"stopPrice"`
+		Time                int64           `json:"time"`
+		UpdateTime          int64           `json:"updateTime"`
+		Fills               []Fill          `json:"fills"`
+	}
+
+	OCOOrder struct {
+		Symbol        string `json:"symbol"`
+		OrderID       int64  `json:"orderId"`
+		ClientOrderID string `json:"clientOrderId"`
+	}
+
+	OCOOrderResp struct {
+		binance.APIError              //in case of error
+		OrderListID       int64       `json:"orderListId"`
+		ContingencyType   string      `json:"contingencyType"`
+		ListStatusType    string      `json:"listStatusType"`
+		ListOrderStatus   string      `json:"listOrderStatus"`
+		ListClientOrderID string      `json:"listClientOrderId"`
+		TransactionTime   int64       `json:"transactionTime"`
+		Symbol            string      `json:"symbol"`
+		Orders            []OCOOrder  `json:"orders"`
+		OrderReports      []OrderResp `json:"orderReports"`
+	}
+)
+
+const (
+	OrderEndPoint      = "/api/v3/order"
+	OCOOrderEndPoint   = "/api/v3/order/oco"
+	OpenOrdersEndPoint = "/api/v3/openOrders"
+
+	SideBuy  = "BUY"
+	SideSell = "SELL"
+
+	OrderTypeLimit           = "LIMIT"
+	OrderTypeMarket          = "MARKET"
+	OrderTypeStopLoss        = "STOP_LOSS"
+	OrderTypeStopLossLimit   = "STOP_LOSS_LIMIT"
+	OrderTypeTakeProfit      = "TAKE_PROFIT"
+	OrderTypeTakeProfitLimit = "TAKE_PROFIT_LIMIT"
+	OrderTypeLimitMaker      = "LIMIT_MAKER"
+
+	TimeInForceGTC = "GTC"
+	TimeInForceIOC = "IOC"
+	TimeInForceFOK = "FOK"
+
+	NewOrderRespTypeACK    = "ACK"
+	NewOrderRespTypeResult = "RESULT"
+	NewOrderRespTypeFull   = "FULL"
+)
+
+var (
+	OrderType2ExType = map[string]exchange.OrderType{
+		OrderTypeLimit:           exchange.OrderTypeLimit,
+		OrderTypeLimitMaker:      exchange.OrderTypeLimit,
+		OrderTypeMarket:          exchange.OrderTypeMarket,
+		OrderTypeStopLoss:        exchange.OrderTypeStopMarket,
+		OrderTypeTakeProfit:      exchange.OrderTypeStopMarket,
+		OrderTypeStopLossLimit:   exchange.OrderTypeStopLimit,
+		OrderTypeTakeProfitLimit: exchange.OrderTypeStopLimit,
+	}
+
+	Status2ExStatus = map[string]exchange.OrderStatus{
+		"NEW":              exchange.OrderStatusOpen,
+		"PARTIALLY_FILLED": exchange.OrderStatusOpen,
+		"PENDING_CANCEL":   exchange.OrderStatusOpen,
+		"FILLED":           exchange.OrderStatusDone,
+		"CANCELED":         exchange.OrderStatusCancel,
+		"REJECTED":         exchange.OrderStatusFailed,
+		"EXPIRED":          exchange.OrderStatusCancel,
+	}
+
+	Side2ExSide = map[string]exchange.OrderSide{
+		SideBuy:  exchange.OrderSideBuy,
+		SideSell: exchange.OrderSideSell,
+	}
+)
+
+// NewAddOrderReq according symbol, side, type
+func NewAddOrderReq(symbol string, side string, typ string) *AddOrderReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	req.AddFields("side", side)
+	req.AddFields("type", typ)
+
+	return &AddOrderReq{
+		RestReq: req,
+	}
+}
+
+func (req *AddOrderReq) TimeInForce(tif string) *AddOrderReq {
+	req.AddFields("timeInForce", tif)
+	return req
+}
+
+func (req *AddOrderReq) Price(prc decimal.Decimal) *AddOrderReq {
+	req.AddFields("price", prc.String())
+	return req
+}
+
+func (req *AddOrderReq) Quantity(q decimal.Decimal) *AddOrderReq {
+	req.AddFields("quantity", q.String())
+	return req
+}
+
+// QuoteOrderQty specific quote amount for market order
+func (req *AddOrderReq) QuoteOrderQty(q decimal.Decimal) *AddOrderReq {
+	req.AddFields("quoteOrderQty", q.String())
+	return req
+}
+
+func (req *AddOrderReq) StopPrice(prc decimal.Decimal) *AddOrderReq {
+	req.AddFields("stopPrice", prc.String())
+	return req
+}
+
+func (req *AddOrderReq) NewClientOrderID(id string) *AddOrderReq {
+	req.AddFields("newClientOrderId", id)
+	return req
+}
+
+// NewOrderRespType ACK, RESULT or FULL. fills are only returned with FULL
+func (req *AddOrderReq) NewOrderRespType(typ string) *AddOrderReq {
+	req.AddFields("newOrderRespType", typ)
+	return req
+}
+
+// NewAddOCOOrderReq build one-cancels-the-other order request. price is the limit
+// maker order price and stopPrice is the trigger price of the stop loss order
+func NewAddOCOOrderReq(symbol string, side string, quantity, price, stopPrice decimal.Decimal) *AddOCOOrderReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	req.AddFields("side", side)
+	req.AddFields("quantity", quantity.String())
+	req.AddFields("price", price.String())
+	req.AddFields("stopPrice", stopPrice.String())
+
+	return &AddOCOOrderReq{
+		RestReq: req,
+	}
+}
+
+// StopLimitPrice make the stop loss order a STOP_LOSS_LIMIT order, stopLimitTimeInForce is required
+func (req *AddOCOOrderReq) StopLimitPrice(prc decimal.Decimal, tif string) *AddOCOOrderReq {
+	req.AddFields("stopLimitPrice", prc.String())
+	req.AddFields("stopLimitTimeInForce", tif)
+	return req
+}
+
+func (req *AddOCOOrderReq) ListClientOrderID(id string) *AddOCOOrderReq {
+	req.AddFields("listClientOrderId", id)
+	return req
+}
+
+func (req *AddOCOOrderReq) LimitClientOrderID(id string) *AddOCOOrderReq {
+	req.AddFields("limitClientOrderId", id)
+	return req
+}
+
+func (req *AddOCOOrderReq) StopClientOrderID(id string) *AddOCOOrderReq {
+	req.AddFields("stopClientOrderId", id)
+	return req
+}
+
+func (req *AddOCOOrderReq) NewOrderRespType(typ string) *AddOCOOrderReq {
+	req.AddFields("newOrderRespType", typ)
+	return req
+}
+
+func NewOrderReq(symbol string) *OrderReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	return &OrderReq{
+		RestReq: req,
+	}
+}
+
+func (r *OrderReq) OrderID(id int64) *OrderReq {
+	r.AddFields("orderId", id)
+	return r
+}
+
+func (r *OrderReq) OrigClientOrderID(id string) *OrderReq {
+	r.AddFields("origClientOrderId", id)
+	return r
+}
+
+// NewOpenOrdersReq query open orders, all symbols are queried if symbol is empty
+func NewOpenOrdersReq(symbol string) *OpenOrdersReq {
+	req := binance.NewRestReq()
+	if symbol != "" {
+		req.AddFields("symbol", symbol)
+	}
+	return &OpenOrdersReq{
+		RestReq: req,
+	}
+}
+
+func (rc *RestClient) AddOrder(ctx context.Context, req *AddOrderReq) (*OrderResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodPost, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "add order fail")
+	}
+
+	return &ret, nil
+}
+
+func (rc *RestClient) AddOCOOrder(ctx context.Context, req *AddOCOOrderReq) (*OCOOrderResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get param fail")
+	}
+
+	var ret OCOOrderResp
+	if err := rc.Request(ctx, http.MethodPost, OCOOrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "add oco order fail")
+	}
+
+	return &ret, nil
+}
+
+func (rc *RestClient) GetOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	var ret OrderResp
+	if err := rc.GetRequest(ctx, OrderEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get order req fail")
+	}
+
+	return &ret, nil
+}
+
+func (rc *RestClient) DeleteOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get req fail")
+	}
+
+	var ret OrderResp
+	if err := rc.Request(ctx, http.MethodDelete, OrderEndPoint, values, nil, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "cancel order fail")
+	}
+
+	return &ret, nil
+}
+
+func (rc *RestClient) OpenOrders(ctx context.Context, req *OpenOrdersReq) ([]OrderResp, error) {
+	var ret []OrderResp
+	if err := rc.GetRequest(ctx, OpenOrdersEndPoint, req, true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get open orders fail")
+	}
+
+	return ret, nil
+}
+
+func (resp *OrderResp) Transfer() (*exchange.Order, error) {
+	return resp.TransferWith(func(symbol string) (exchange.Symbol, error) {
+		return ParseSymbol(symbol)
+	})
+}
+
+// TransferWith transfer order with specific SymbolParser
+func (resp *OrderResp) TransferWith(parseSymbol SymbolParser) (*exchange.Order, error) {
+	symbol, err := parseSymbol(resp.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	typ, ok := OrderType2ExType[resp.Type]
+	if !ok {
+		return nil, errors.Errorf("unknown resp type=%s", resp.Type)
+	}
+
+	status, ok := Status2ExStatus[resp.Status]
+	if !ok {
+		return nil, errors.Errorf("unknown resp status=%s", resp.Status)
+	}
+
+	side, ok := Side2ExSide[resp.Side]
+	if !ok {
+		return nil, errors.Errorf("unknown side=%s", resp.Side)
+	}
+
+	var avgPrice decimal.Decimal
+	if !resp.ExecutedQty.IsZero() {
+		avgPrice = resp.CummulativeQuoteQty.Div(resp.ExecutedQty)
+	}
+
+	clientID := resp.ClientOrderID
+	if resp.OrigClientOrderID != "" {
+		clientID = resp.OrigClientOrderID
+	}
+
+	ret := &exchange.Order{
+		ID:       exchange.NewIntID(resp.OrderID),
+		ClientID: exchange.NewStrID(clientID),
+		Symbol:   symbol,
+		Amount:   resp.OrigQty,
+		Filled:   resp.ExecutedQty,
+		Price:    resp.Price,
+		AvgPrice: avgPrice,
+		Type:     typ,
+		Side:     side,
+		Status:   status,
+		Raw:      resp,
+	}
+
+	if resp.TransactTime != 0 {
+		ret.Created = tconv.Milli2Time(resp.TransactTime)
+		ret.Updated = ret.Created
+	} else {
+		ret.Created = tconv.Milli2Time(resp.Time)
+		ret.Updated = tconv.Milli2Time(resp.UpdateTime)
+	}
+
+	//fee can only be summed if all fills charged in the same asset
+	for i, f := range resp.Fills {
+		if i == 0 {
+			ret.FeeCurrency = f.CommissionAsset
+		} else if f.CommissionAsset != ret.FeeCurrency {
+			ret.Fee = decimal.Zero
+			ret.FeeCurrency = ""
+			break
+		}
+		ret.Fee = ret.Fee.Add(f.Commission)
+	}
+
+	return ret, nil
+}
+
+// Trades return fills of the order, only available with newOrderRespType=FULL
+func (resp *OrderResp) Trades() ([]*exchange.Trade, error) {
+	symbol, err := ParseSymbol(resp.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	side, ok := Side2ExSide[resp.Side]
+	if !ok {
+		return nil, errors.Errorf("unknown side=%s", resp.Side)
+	}
+
+	ret := make([]*exchange.Trade, len(resp.Fills))
+	for i := range resp.Fills {
+		f := &resp.Fills[i]
+		ret[i] = &exchange.Trade{
+			ID:          strconv.FormatInt(f.TradeID, 10),
+			OrderID:     strconv.FormatInt(resp.OrderID, 10),
+			Symbol:      symbol,
+			Price:       f.Price,
+			Amount:      f.Qty,
+			Fee:         f.Commission.Neg(),
+			FeeCurrency: f.CommissionAsset,
+			Time:        tconv.Milli2Time(resp.TransactTime),
+			Side:        side,
+			Raw:         f,
+		}
+	}
+	return ret, nil
+}
+
+// NewCreateOrderReq build AddOrderReq from exchange.OrderRequest, the request
+// is shared by spot and margin orders
+func NewCreateOrderReq(req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*AddOrderReq, error) {
+	var side string
+	switch req.Side {
+	case exchange.OrderSideBuy:
+		side = SideBuy
+
+	case exchange.OrderSideSell:
+		side = SideSell
+
+	default:
+		return nil, errors.Errorf("unsupport side=%s", req.Side)
+	}
+
+	var (
+		typ string
+		tif string
+	)
+	switch req.Type {
+	case exchange.OrderTypeLimit:
+		typ = OrderTypeLimit
+		tif = TimeInForceGTC
+
+	case exchange.OrderTypeMarket:
+		typ = OrderTypeMarket
+
+	default:
+		return nil, errors.Errorf("unsupport type=%s", req.Type)
+	}
+
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				if typ != OrderTypeLimit {
+					return nil, errors.Errorf("post only is only support for limit order")
+				}
+				typ = OrderTypeLimitMaker
+				tif = ""
+			}
+
+		case *exchange.TimeInForceOption:
+			if typ != OrderTypeLimit {
+				return nil, errors.Errorf("time in force is only support for limit order")
+			}
+			switch t.Flag {
+			case exchange.TimeInForceGTC:
+				tif = TimeInForceGTC
+
+			case exchange.TimeInForceIOC:
+				tif = TimeInForceIOC
+
+			case exchange.TimeInForceFOK:
+				tif = TimeInForceFOK
+			}
+
+		default:
+			return nil, errors.Errorf("unsupport option %+v", opt)
+		}
+	}
+
+	or := NewAddOrderReq(req.Symbol.String(), side, typ).Quantity(req.Amount).NewOrderRespType(NewOrderRespTypeFull)
+	if typ != OrderTypeMarket {
+		or.Price(req.Price)
+	}
+	if tif != "" {
+		or.TimeInForce(tif)
+	}
+	if req.ClientID != nil {
+		or.NewClientOrderID(req.ClientID.String())
+	}
+	return or, nil
+}
+
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	or, err := NewCreateOrderReq(req, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.AddOrder(ctx, or)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transfer()
+}
+
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.GetOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transfer()
+}
+
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := rc.DeleteOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transfer()
+}
+
+// FetchOpenOrders return open orders of the symbol
+func (rc *RestClient) FetchOpenOrders(ctx context.Context, sym exchange.Symbol) ([]*exchange.Order, error) {
+	resp, err := rc.OpenOrders(ctx, NewOpenOrdersReq(sym.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, 0, len(resp))
+	for i := range resp {
+		o, err := resp[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, o)
+	}
+	return ret, nil
+}
+
+// orderReq build OrderReq by order ID, ClientID is used if ID is not set
+func orderReq(order *exchange.Order) (*OrderReq, error) {
+	req := NewOrderReq(order.Symbol.String())
+	if order.ID != nil {
+		id, err := strconv.ParseInt(order.ID.String(), 10, 64)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad orderID=%s", order.ID.String())
+		}
+		return req.OrderID(id), nil
+	}
+
+	if order.ClientID != nil {
+		return req.OrigClientOrderID(order.ClientID.String()), nil
+	}
+
+	return nil, errors.Errorf("order id or client id is required")
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/szmcdull/ccexgo/exchange"
 )
 
@@ -17,13 +18,27 @@ type (
 	}
 
 	//Symbol info
-	//TODO: refactor Symbol add more info pricePrecison ...
 	Symbol struct {
-		Symbol              string `json:"symbol"`
-		BaseAsset           string `json:"baseAsset"`
-		QuoteAsset          string `json:"quoteAsset"`
-		BaseAssetPrecision  int    `json:"baseAssetPrecision"`
-		QuoteAssetPrecision int    `json:"quoteAssetPrecision"`
+		Symbol              string   `json:"symbol"`
+		Status              string   `json:"status"`
+		BaseAsset           string   `json:"baseAsset"`
+		QuoteAsset          string   `json:"quoteAsset"`
+		BaseAssetPrecision  int      `json:"baseAssetPrecision"`
+		QuoteAssetPrecision int      `json:"quoteAssetPrecision"`
+		OrderTypes          []string `json:"orderTypes"`
+		OcoAllowed          bool     `json:"ocoAllowed"`
+		Filters             []Filter `json:"filters"`
+	}
+
+	Filter struct {
+		FilterType  string          `json:"filterType"`
+		MinPrice    decimal.Decimal `json:"minPrice"`
+		MaxPrice    decimal.Decimal `json:"maxPrice"`
+		TickSize    decimal.Decimal `json:"tickSize"`
+		StepSize    decimal.Decimal `json:"stepSize"`
+		MaxQty      decimal.Decimal `json:"maxQty"`
+		MinQty      decimal.Decimal `json:"minQty"`
+		MinNotional decimal.Decimal `json:"minNotional"`
 	}
 
 	ExchangeInfo struct {
@@ -31,6 +46,13 @@ type (
 	}
 )
 
+const (
+	priceFilter = "PRICE_FILTER"
+	lotSize     = "LOT_SIZE"
+	minNotional = "MIN_NOTIONAL"
+	notional    = "NOTIONAL"
+)
+
 var (
 	ErrPair   = errors.New("symbol pair not support")
 	symbolMap = map[string]exchange.SpotSymbol{}
@@ -96,11 +118,31 @@ func ParseSymbol(sym string) (exchange.SpotSymbol, error) {
 
 func (sym *Symbol) Parse() (exchange.SpotSymbol, error) {
 	return &SpotSymbol{
-		exchange.NewBaseSpotSymbol(sym.BaseAsset, sym.QuoteAsset, exchange.SymbolConfig{}, sym),
+		exchange.NewBaseSpotSymbol(sym.BaseAsset, sym.QuoteAsset, sym.Config(), sym),
 		sym.Symbol,
 	}, nil
 }
 
+// Config build exchange.SymbolConfig from symbol filters
+func (sym *Symbol) Config() exchange.SymbolConfig {
+	cfg := exchange.SymbolConfig{}
+	for _, f := range sym.Filters {
+		switch f.FilterType {
+		case priceFilter:
+			cfg.PricePrecision = f.TickSize
+
+		case lotSize:
+			cfg.AmountPrecision = f.StepSize
+			cfg.AmountMin = f.MinQty
+			cfg.AmountMax = f.MaxQty
+
+		case minNotional, notional:
+			cfg.ValueMin = f.MinNotional
+		}
+	}
+	return cfg
+}
+
 func (ss *SpotSymbol) String() string {
 	return ss.Symbol
 }
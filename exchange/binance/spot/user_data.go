@@ -0,0 +1,343 @@
+package spot
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+	"github.com/tidwall/gjson"
+)
+
+type (
+	// SymbolParser parse binance symbol string into exchange.Symbol
+	SymbolParser func(symbol string) (exchange.Symbol, error)
+
+	// UserDataCodeC decode binance spot user data stream, margin user data
+	// stream share the same message format with a different SymbolParser
+	UserDataCodeC struct {
+		*binance.CodeC
+		parseSymbol SymbolParser
+	}
+
+	// UserDataWSClient binance spot user data stream client, the listenKey
+	// is created and kept alive by RestClient
+	UserDataWSClient struct {
+		*binance.WSClient
+		data chan interface{}
+	}
+
+	ExecutionReport struct {
+		Event             string
+		EventTS           int64
+		Symbol            string
+		ClientOrderID     string
+		Side              string
+		Type              string
+		TimeInForce       string
+		Quantity          decimal.Decimal
+		Price             decimal.Decimal
+		StopPrice         decimal.Decimal
+		OrigClientOrderID string
+		ExecutionType     string
+		Status            string
+		RejectReason      string
+		OrderID           int64
+		LastQty           decimal.Decimal
+		CumQty            decimal.Decimal
+		LastPrice         decimal.Decimal
+		Commission        decimal.Decimal
+		CommissionAsset   string
+		TransactTS        int64
+		TradeID           int64
+		IsMaker           bool
+		CreateTS          int64
+		CumQuoteQty       decimal.Decimal
+	}
+
+	// OrderUpdate parsed executionReport, Trade is only set when the
+	// execution type is TRADE
+	OrderUpdate struct {
+		Order *exchange.Order
+		Trade *exchange.Trade
+	}
+
+	BalanceUpdate struct {
+		Event   string
+		EventTS int64
+		Asset   string
+		Delta   decimal.Decimal
+		ClearTS int64
+	}
+)
+
+const (
+	ExecutionReportEvent         = "executionReport"
+	OutboundAccountPositionEvent = "outboundAccountPosition"
+	BalanceUpdateEvent           = "balanceUpdate"
+	ListStatusEvent              = "listStatus"
+
+	ExecutionTypeTrade = "TRADE"
+)
+
+func NewUserDataCodeC() *UserDataCodeC {
+	return NewUserDataCodeCWithParser(func(symbol string) (exchange.Symbol, error) {
+		return ParseSymbol(symbol)
+	})
+}
+
+func NewUserDataCodeCWithParser(parser SymbolParser) *UserDataCodeC {
+	return &UserDataCodeC{
+		CodeC:       binance.NewCodeC(),
+		parseSymbol: parser,
+	}
+}
+
+// Decode binance spot user data notify
+func (cc *UserDataCodeC) Decode(raw []byte) (rpc.Response, error) {
+	return cc.DecodeByCB(raw, func(g *gjson.Result) (rpc.Response, error) {
+		event := g.Get("e").String()
+		switch event {
+		case ExecutionReportEvent:
+			er, err := ParseExecutionReport(g)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid execution report")
+			}
+			update, err := er.Parse(cc.parseSymbol)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid execution report")
+			}
+			return &rpc.Notify{Method: event, Params: update}, nil
+
+		case OutboundAccountPositionEvent:
+			updates, err := ParseAccountPositionUpdates(g)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid account position")
+			}
+			return &rpc.Notify{Method: event, Params: updates}, nil
+
+		case BalanceUpdateEvent:
+			update, err := ParseBalanceUpdate(g)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid balance update")
+			}
+			return &rpc.Notify{Method: event, Params: update}, nil
+
+		case ListStatusEvent:
+			return &rpc.Notify{Method: event, Params: g.Raw}, nil
+		}
+
+		return nil, errors.Errorf("bad notify msg=%s", g.Raw)
+	})
+}
+
+// decimalParser parse decimal fields of a notify and keep the first error
+type decimalParser struct {
+	err error
+}
+
+func (dp *decimalParser) parse(g *gjson.Result, key string) decimal.Decimal {
+	raw := g.Get(key).String()
+	ret, err := decimal.NewFromString(raw)
+	if err != nil && dp.err == nil {
+		dp.err = errors.WithMessagef(err, "parse field %s='%s' fail", key, raw)
+	}
+	return ret
+}
+
+func ParseExecutionReport(g *gjson.Result) (*ExecutionReport, error) {
+	var dp decimalParser
+	ret := &ExecutionReport{
+		Event:             g.Get("e").String(),
+		EventTS:           g.Get("E").Int(),
+		Symbol:            g.Get("s").String(),
+		ClientOrderID:     g.Get("c").String(),
+		Side:              g.Get("S").String(),
+		Type:              g.Get("o").String(),
+		TimeInForce:       g.Get("f").String(),
+		Quantity:          dp.parse(g, "q"),
+		Price:             dp.parse(g, "p"),
+		StopPrice:         dp.parse(g, "P"),
+		OrigClientOrderID: g.Get("C").String(),
+		ExecutionType:     g.Get("x").String(),
+		Status:            g.Get("X").String(),
+		RejectReason:      g.Get("r").String(),
+		OrderID:           g.Get("i").Int(),
+		LastQty:           dp.parse(g, "l"),
+		CumQty:            dp.parse(g, "z"),
+		LastPrice:         dp.parse(g, "L"),
+		Commission:        dp.parse(g, "n"),
+		CommissionAsset:   g.Get("N").String(),
+		TransactTS:        g.Get("T").Int(),
+		TradeID:           g.Get("t").Int(),
+		IsMaker:           g.Get("m").Bool(),
+		CreateTS:          g.Get("O").Int(),
+		CumQuoteQty:       dp.parse(g, "Z"),
+	}
+	if dp.err != nil {
+		return nil, dp.err
+	}
+	return ret, nil
+}
+
+// Parse execution report into exchange.Order and exchange.Trade
+func (er *ExecutionReport) Parse(parseSymbol SymbolParser) (*OrderUpdate, error) {
+	symbol, err := parseSymbol(er.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	typ, ok := OrderType2ExType[er.Type]
+	if !ok {
+		return nil, errors.Errorf("unknown order type=%s", er.Type)
+	}
+
+	status, ok := Status2ExStatus[er.Status]
+	if !ok {
+		return nil, errors.Errorf("unknown order status=%s", er.Status)
+	}
+
+	side, ok := Side2ExSide[er.Side]
+	if !ok {
+		return nil, errors.Errorf("unknown side=%s", er.Side)
+	}
+
+	var avgPrice decimal.Decimal
+	if !er.CumQty.IsZero() {
+		avgPrice = er.CumQuoteQty.Div(er.CumQty)
+	}
+
+	//for canceled order c is the client id of the cancel request
+	clientID := er.ClientOrderID
+	if er.OrigClientOrderID != "" {
+		clientID = er.OrigClientOrderID
+	}
+
+	ret := &OrderUpdate{
+		Order: &exchange.Order{
+			ID:       exchange.NewIntID(er.OrderID),
+			ClientID: exchange.NewStrID(clientID),
+			Symbol:   symbol,
+			Amount:   er.Quantity,
+			Filled:   er.CumQty,
+			Price:    er.Price,
+			AvgPrice: avgPrice,
+			Created:  tconv.Milli2Time(er.CreateTS),
+			Updated:  tconv.Milli2Time(er.TransactTS),
+			Type:     typ,
+			Side:     side,
+			Status:   status,
+			Raw:      er,
+		},
+	}
+
+	if er.ExecutionType == ExecutionTypeTrade {
+		ret.Trade = &exchange.Trade{
+			ID:          strconv.FormatInt(er.TradeID, 10),
+			OrderID:     strconv.FormatInt(er.OrderID, 10),
+			Symbol:      symbol,
+			Price:       er.LastPrice,
+			Amount:      er.LastQty,
+			Fee:         er.Commission.Neg(),
+			FeeCurrency: er.CommissionAsset,
+			Time:        tconv.Milli2Time(er.TransactTS),
+			Side:        side,
+			IsMaker:     er.IsMaker,
+			Raw:         er,
+		}
+	}
+	return ret, nil
+}
+
+// OrderUpdates implement exchange.OrderUpdater
+func (ou *OrderUpdate) OrderUpdates() []*exchange.Order {
+	return []*exchange.Order{ou.Order}
+}
+
+// ParseAccountPosition parse outboundAccountPosition into exchange.Balances
+// which only contains assets changed
+func ParseAccountPosition(g *gjson.Result) (*exchange.Balances, error) {
+	var dp decimalParser
+	ret := exchange.NewBalances()
+	g.Get("B").ForEach(func(key, value gjson.Result) bool {
+		free := dp.parse(&value, "f")
+		locked := dp.parse(&value, "l")
+		ret.Add(&exchange.Balance{
+			Currency: value.Get("a").String(),
+			Total:    free.Add(locked),
+			Free:     free,
+			Frozen:   locked,
+		})
+		return dp.err == nil
+	})
+	if dp.err != nil {
+		return nil, dp.err
+	}
+	ret.Raw = g.Raw
+	return ret, nil
+}
+
+// ParseAccountPositionUpdates parse outboundAccountPosition into exchange.BalanceUpdate
+// of the changed assets, the reason is not pushed
+func ParseAccountPositionUpdates(g *gjson.Result) ([]*exchange.BalanceUpdate, error) {
+	var (
+		dp  decimalParser
+		ret []*exchange.BalanceUpdate
+	)
+	ts := tconv.Milli2Time(g.Get("u").Int())
+	fields := exchange.BalanceFieldTotal | exchange.BalanceFieldFree | exchange.BalanceFieldFrozen
+	g.Get("B").ForEach(func(key, value gjson.Result) bool {
+		free := dp.parse(&value, "f")
+		locked := dp.parse(&value, "l")
+		ret = append(ret, exchange.NewBalanceUpdate(&exchange.Balance{
+			Currency: value.Get("a").String(),
+			Total:    free.Add(locked),
+			Free:     free,
+			Frozen:   locked,
+		}, fields, exchange.BalanceReasonOther, ts, g.Raw))
+		return dp.err == nil
+	})
+	if dp.err != nil {
+		return nil, dp.err
+	}
+	return ret, nil
+}
+
+func ParseBalanceUpdate(g *gjson.Result) (*BalanceUpdate, error) {
+	var dp decimalParser
+	ret := &BalanceUpdate{
+		Event:   g.Get("e").String(),
+		EventTS: g.Get("E").Int(),
+		Asset:   g.Get("a").String(),
+		Delta:   dp.parse(g, "d"),
+		ClearTS: g.Get("T").Int(),
+	}
+	if dp.err != nil {
+		return nil, dp.err
+	}
+	return ret, nil
+}
+
+func NewUserDataWSClient(key, secret string, data chan interface{}) *UserDataWSClient {
+	return NewUserDataWSClientWithCodeC(NewUserDataCodeC(), NewRestClient(key, secret), data)
+}
+
+func NewUserDataWSClientWithCodeC(codec *UserDataCodeC, client binance.ListenKeyClient, data chan interface{}) *UserDataWSClient {
+	ret := &UserDataWSClient{
+		data: data,
+	}
+	ret.WSClient = binance.NewWSClient(codec, ret, client)
+	return ret
+}
+
+func (ws *UserDataWSClient) Handle(ctx context.Context, notify *rpc.Notify) {
+	msg := &exchange.WSNotify{Exchange: binance.Exchange, Chan: notify.Method, Data: notify.Params}
+	select {
+	case ws.data <- msg:
+	default:
+	}
+}
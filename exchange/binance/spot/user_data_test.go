@@ -0,0 +1,74 @@
+package spot
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func TestDecodeExecutionReport(t *testing.T) {
+	sym := &Symbol{Symbol: "ETHBTC", BaseAsset: "ETH", QuoteAsset: "BTC"}
+	ss, _ := sym.Parse()
+	symbolMap[ss.String()] = ss
+
+	raw := `{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW",
+	"S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","P":"0.00000000","F":"0.00000000",
+	"g":-1,"C":"","x":"TRADE","X":"PARTIALLY_FILLED","r":"NONE","i":4293153,"l":"0.40000000",
+	"z":"0.40000000","L":"0.10264410","n":"0.00040000","N":"ETH","T":1499405658657,"t":1234,"I":8641984,
+	"w":true,"m":true,"M":false,"O":1499405658657,"Z":"0.04105764","Y":"0.04105764","Q":"0.00000000"}`
+
+	resp, err := NewUserDataCodeC().Decode([]byte(raw))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+
+	update := resp.(*rpc.Notify).Params.(*OrderUpdate)
+	order := update.Order
+	if order.ID.String() != "4293153" || order.ClientID.String() != "mUvoqJxFIILMdfAW5iGSOW" ||
+		order.Status != exchange.OrderStatusOpen || order.Side != exchange.OrderSideBuy ||
+		!order.Filled.Equal(decimal.RequireFromString("0.4")) ||
+		!order.AvgPrice.Equal(decimal.RequireFromString("0.1026441")) {
+		t.Errorf("bad order %+v", *order)
+	}
+
+	trade := update.Trade
+	if trade == nil || trade.ID != "1234" || !trade.IsMaker ||
+		!trade.Fee.Equal(decimal.RequireFromString("-0.0004")) || trade.FeeCurrency != "ETH" {
+		t.Errorf("bad trade %+v", trade)
+	}
+}
+
+func TestDecodeAccountPosition(t *testing.T) {
+	raw := `{"e":"outboundAccountPosition","E":1564034571105,"u":1564034571073,
+	"B":[{"a":"ETH","f":"10000.000000","l":"1.000000"},{"a":"btc","f":"0.5","l":"0"}]}`
+
+	resp, err := NewUserDataCodeC().Decode([]byte(raw))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+
+	updates := resp.(*rpc.Notify).Params.([]*exchange.BalanceUpdate)
+	if len(updates) != 2 || updates[1].Currency != "BTC" || updates[0].Time.UnixMilli() != 1564034571073 {
+		t.Fatalf("bad updates %+v", updates)
+	}
+
+	lb := exchange.NewLiveBalances(nil)
+	if n := lb.Handle(updates); n != 2 {
+		t.Errorf("bad applied %d", n)
+	}
+
+	b, err := lb.Get("ETH")
+	if err != nil || !b.Total.Equal(decimal.RequireFromString("10001")) ||
+		!b.Frozen.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("bad balance %+v", b)
+	}
+}
+
+func TestDecodeEmptyField(t *testing.T) {
+	raw := `{"e":"balanceUpdate","E":1573200697110,"a":"BTC","d":"","T":1573200697068}`
+	if _, err := NewUserDataCodeC().Decode([]byte(raw)); err == nil {
+		t.Errorf("error expected for empty delta")
+	}
+}
@@ -8,23 +8,28 @@ type (
 	//RestClient struct
 	RestClient struct {
 		*binance.RestClient
-		side *GetPositionSideResp
+		side   *GetPositionSideResp
+		wsAddr string
 	}
 )
 
 const (
 	SwapAPIHost     string = "fapi.binance.com"
 	SwapTestAPIHost string = "testnet.binancefuture.com"
+	SwapWSHost      string = "fstream.binance.com"
+	SwapTestWSHost  string = "stream.binancefuture.com"
 )
 
 func NewRestClient(key, secret string) *RestClient {
 	return &RestClient{
 		RestClient: binance.NewRestClient(key, secret, SwapAPIHost),
+		wsAddr:     SwapWSHost,
 	}
 }
 
 func NewTestRestClient(key, secret string) *RestClient {
 	return &RestClient{
 		RestClient: binance.NewRestClient(key, secret, SwapTestAPIHost),
+		wsAddr:     SwapTestWSHost,
 	}
 }
@@ -0,0 +1,47 @@
+package swap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	ListenKeyResp struct {
+		ListenKey string `json:"listenKey"`
+	}
+)
+
+const (
+	ListenKeyEndPoint = "/fapi/v1/listenKey"
+)
+
+// GetListenKeyAddr create or extend listenKey and return the user data stream address
+func (rc *RestClient) GetListenKeyAddr(ctx context.Context) (string, error) {
+	var ret ListenKeyResp
+	if err := rc.Request(ctx, http.MethodPost, ListenKeyEndPoint, nil, nil, false, &ret); err != nil {
+		return "", errors.WithMessage(err, "request listenKey fail")
+	}
+
+	return fmt.Sprintf("wss://%s/ws/%s", rc.wsAddr, ret.ListenKey), nil
+}
+
+func (rc *RestClient) PersistListenKey(ctx context.Context) error {
+	var ret map[string]interface{}
+
+	if err := rc.Request(ctx, http.MethodPut, ListenKeyEndPoint, nil, nil, false, &ret); err != nil {
+		return errors.WithMessage(err, "persist listenKey fail")
+	}
+	return nil
+}
+
+func (rc *RestClient) DeleteListenKey(ctx context.Context) error {
+	var ret map[string]interface{}
+
+	if err := rc.Request(ctx, http.MethodDelete, ListenKeyEndPoint, nil, nil, false, &ret); err != nil {
+		return errors.WithMessage(err, "delete listenKey fail")
+	}
+	return nil
+}
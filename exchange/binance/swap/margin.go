@@ -0,0 +1,188 @@
+package swap
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+)
+
+type (
+	SetLeverageReq struct {
+		*binance.RestReq
+	}
+
+	SetLeverageResp struct {
+		Code             int             `json:"code"`
+		Message          string          `json:"msg"`
+		Leverage         int             `json:"leverage"`
+		MaxNotionalValue decimal.Decimal `json:"maxNotionalValue"`
+		Symbol           string          `json:"symbol"`
+	}
+
+	SetMarginTypeReq struct {
+		*binance.RestReq
+	}
+
+	SetMarginTypeResp struct {
+		Code    int    `json:"code"`
+		Message string `json:"msg"`
+	}
+)
+
+const (
+	LeveragePath   = "/fapi/v1/leverage"
+	MarginTypePath = "/fapi/v1/marginType"
+
+	MarginTypeIsolated = "ISOLATED"
+	MarginTypeCrossed  = "CROSSED"
+)
+
+func NewSetLeverageReq(symbol string, leverage int) *SetLeverageReq {
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	req.AddFields("leverage", leverage)
+	return &SetLeverageReq{
+		RestReq: req,
+	}
+}
+
+func NewSetMarginTypeReq(symbol string, mode exchange.PositionMode) (*SetMarginTypeReq, error) {
+	typ, err := MarginType(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	req := binance.NewRestReq()
+	req.AddFields("symbol", symbol)
+	req.AddFields("marginType", typ)
+	return &SetMarginTypeReq{
+		RestReq: req,
+	}, nil
+}
+
+// MarginType return binance marginType of the position mode
+func MarginType(mode exchange.PositionMode) (string, error) {
+	switch mode {
+	case exchange.PositionModeFixed:
+		return MarginTypeIsolated, nil
+
+	case exchange.PositionModeCross:
+		return MarginTypeCrossed, nil
+
+	default:
+		return "", exchange.NewBadArg("unknown margin mode", mode)
+	}
+}
+
+func (rc *RestClient) SetLeverageRaw(ctx context.Context, req *SetLeverageReq) (*SetLeverageResp, error) {
+	return SetLeverage(ctx, rc.RestClient, LeveragePath, req)
+}
+
+func (rc *RestClient) SetMarginType(ctx context.Context, req *SetMarginTypeReq) error {
+	return SetMarginType(ctx, rc.RestClient, MarginTypePath, req)
+}
+
+// SetLeverage set leverage of the symbol, the leverage should be integer
+func (rc *RestClient) SetLeverage(ctx context.Context, sym exchange.Symbol, leverage decimal.Decimal) error {
+	if !leverage.Equal(leverage.Truncate(0)) {
+		return exchange.NewBadArg("leverage should be integer", leverage)
+	}
+	_, err := rc.SetLeverageRaw(ctx, NewSetLeverageReq(sym.String(), int(leverage.IntPart())))
+	return err
+}
+
+func (rc *RestClient) GetLeverage(ctx context.Context, sym exchange.Symbol) (decimal.Decimal, error) {
+	pos, err := rc.accountPosition(ctx, sym)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return pos.Leverage, nil
+}
+
+func (rc *RestClient) SetMarginMode(ctx context.Context, sym exchange.Symbol, mode exchange.PositionMode) error {
+	req, err := NewSetMarginTypeReq(sym.String(), mode)
+	if err != nil {
+		return err
+	}
+	return rc.SetMarginType(ctx, req)
+}
+
+func (rc *RestClient) GetMarginMode(ctx context.Context, sym exchange.Symbol) (exchange.PositionMode, error) {
+	pos, err := rc.accountPosition(ctx, sym)
+	if err != nil {
+		return exchange.PositionModeCross, err
+	}
+	if pos.Isolated {
+		return exchange.PositionModeFixed, nil
+	}
+	return exchange.PositionModeCross, nil
+}
+
+func (rc *RestClient) SetPositionMode(ctx context.Context, hedge bool) error {
+	return rc.SetPositionSide(ctx, NewSetPositionSideRequest(hedge))
+}
+
+func (rc *RestClient) GetPositionMode(ctx context.Context) (bool, error) {
+	side, err := rc.GetPositionSide(ctx, NewGetPositionSideRequest())
+	if err != nil {
+		return false, err
+	}
+	return side.DualSidePosition, nil
+}
+
+func (rc *RestClient) accountPosition(ctx context.Context, sym exchange.Symbol) (*AccountPosition, error) {
+	account, err := rc.Account(ctx, NewAccountReq())
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := account.GetPosition(sym.String())
+	if err != nil {
+		return nil, err
+	}
+	return &pos[0], nil
+}
+
+// SetLeverage send set leverage request to the endPoint, it's shared by usdt and coin margined futures
+func SetLeverage(ctx context.Context, rc *binance.RestClient, endPoint string, req *SetLeverageReq) (*SetLeverageResp, error) {
+	values, err := req.Values()
+	if err != nil {
+		return nil, errors.WithMessage(err, "get request values fail")
+	}
+
+	var resp SetLeverageResp
+	if err := rc.Request(ctx, http.MethodPost, endPoint, values, nil, true, &resp); err != nil {
+		if err = binance.CheckMarginError(err); err != nil {
+			return nil, errors.WithMessage(err, "set leverage fail")
+		}
+	}
+
+	if err := binance.CheckMarginCode(resp.Code, resp.Message); err != nil {
+		return nil, errors.WithMessage(err, "set leverage fail")
+	}
+	return &resp, nil
+}
+
+// SetMarginType send set margin type request to the endPoint, it's shared by usdt and coin margined futures
+func SetMarginType(ctx context.Context, rc *binance.RestClient, endPoint string, req *SetMarginTypeReq) error {
+	values, err := req.Values()
+	if err != nil {
+		return errors.WithMessage(err, "get request values fail")
+	}
+
+	var resp SetMarginTypeResp
+	if err := rc.Request(ctx, http.MethodPost, endPoint, values, nil, true, &resp); err != nil {
+		if err = binance.CheckMarginError(err); err != nil {
+			return errors.WithMessage(err, "set margin type fail")
+		}
+	}
+
+	if err := binance.CheckMarginCode(resp.Code, resp.Message); err != nil {
+		return errors.WithMessage(err, "set margin type fail")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func TestSetLeverageFraction(t *testing.T) {
+	rc := NewRestClient("", "")
+	err := rc.SetLeverage(context.Background(), nil, decimal.RequireFromString("2.5"))
+	if !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("fractional leverage should be rejected %v", err)
+	}
+}
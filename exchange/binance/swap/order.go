@@ -19,7 +19,7 @@ type (
 
 	OrderResp struct {
 		binance.APIError                 //in case of error
-		ClientOrderID    string          `json:"newOrderId"`
+		ClientOrderID    string          `json:"clientOrderId"`
 		CumQty           decimal.Decimal `json:"cumQty"`
 		CumQuote         decimal.Decimal `json:"cumQuote"`
 		ExecutedQty      decimal.Decimal `json:"executedQty"`
@@ -59,12 +59,25 @@ const (
 	OrderTypeMarket   = "MARKET"
 	OrderTypeLimit    = "LIMIT"
 	TimeInForce       = "GTC"
+
+	OrderTypeStop               = "STOP"
+	OrderTypeStopMarket         = "STOP_MARKET"
+	OrderTypeTakeProfit         = "TAKE_PROFIT"
+	OrderTypeTakeProfitMarket   = "TAKE_PROFIT_MARKET"
+	OrderTypeTrailingStopMarket = "TRAILING_STOP_MARKET"
+	OrderTypeLiquidation        = "LIQUIDATION"
 )
 
 var (
 	OrderType2ExType = map[string]exchange.OrderType{
-		OrderTypeLimit:  exchange.OrderTypeLimit,
-		OrderTypeMarket: exchange.OrderTypeMarket,
+		OrderTypeLimit:              exchange.OrderTypeLimit,
+		OrderTypeMarket:             exchange.OrderTypeMarket,
+		OrderTypeLiquidation:        exchange.OrderTypeMarket,
+		OrderTypeStop:               exchange.OrderTypeStopLimit,
+		OrderTypeTakeProfit:         exchange.OrderTypeStopLimit,
+		OrderTypeStopMarket:         exchange.OrderTypeStopMarket,
+		OrderTypeTakeProfitMarket:   exchange.OrderTypeStopMarket,
+		OrderTypeTrailingStopMarket: exchange.OrderTypeStopMarket,
 	}
 
 	ExType2OrderType = map[exchange.OrderType]string{
@@ -73,12 +86,14 @@ var (
 	}
 
 	Status2ExStatus = map[string]exchange.OrderStatus{
-		"NEW":               exchange.OrderStatusOpen,
-		"PARTIALLY_FILLED ": exchange.OrderStatusOpen,
-		"FILLED":            exchange.OrderStatusDone,
-		"CANCELED":          exchange.OrderStatusCancel,
-		"REJECTED":          exchange.OrderStatusFailed,
-		"EXPIRED":           exchange.OrderStatusFailed,
+		"NEW":              exchange.OrderStatusOpen,
+		"PARTIALLY_FILLED": exchange.OrderStatusOpen,
+		"NEW_INSURANCE":    exchange.OrderStatusOpen,
+		"NEW_ADL":          exchange.OrderStatusOpen,
+		"FILLED":           exchange.OrderStatusDone,
+		"CANCELED":         exchange.OrderStatusCancel,
+		"REJECTED":         exchange.OrderStatusFailed,
+		"EXPIRED":          exchange.OrderStatusFailed,
 	}
 )
 
@@ -104,6 +119,17 @@ func (req *AddOrderReq) PositionSide(side string) *AddOrderReq {
 	return req
 }
 
+// ReduceOnly can only be sent in one-way mode
+func (req *AddOrderReq) ReduceOnly(reduceOnly bool) *AddOrderReq {
+	req.AddFields("reduceOnly", reduceOnly)
+	return req
+}
+
+func (req *AddOrderReq) NewClientOrderID(id string) *AddOrderReq {
+	req.AddFields("newClientOrderId", id)
+	return req
+}
+
 func (req *AddOrderReq) Price(prc decimal.Decimal) *AddOrderReq {
 	req.AddFields("price", prc.String())
 	return req
@@ -141,6 +167,11 @@ func (r *OrderReq) OrderID(id int64) *OrderReq {
 	return r
 }
 
+func (r *OrderReq) OrigClientOrderID(id string) *OrderReq {
+	r.AddFields("origClientOrderId", id)
+	return r
+}
+
 func (cl *RestClient) GetOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
 	var ret OrderResp
 	if err := cl.GetRequest(ctx, OrderEndPoint, req, true, &ret); err != nil {
@@ -186,36 +217,14 @@ func (resp *OrderResp) Transfer() (*exchange.Order, error) {
 		return nil, errors.Errorf("unknown resp status=%s", resp.Status)
 	}
 
-	if resp.PositionSide == PositionSideBoth {
-		if resp.Side == SideBuy {
-			side = exchange.OrderSideBuy
-		} else if resp.Side == SideSell {
-			side = exchange.OrderSideSell
-		} else {
-			return nil, errors.Errorf("unknown side=%s", resp.Side)
-		}
-	} else if resp.PositionSide == PositionSideLong {
-		if resp.Side == SideBuy {
-			side = exchange.OrderSideBuy
-		} else if resp.Side == SideSell {
-			side = exchange.OrderSideCloseLong
-		} else {
-			return nil, errors.Errorf("unknown side=%s", resp.Side)
-		}
-	} else if resp.PositionSide == PositionSideShort {
-		if resp.Side == SideBuy {
-			side = exchange.OrderSideCloseShort
-		} else if resp.Side == SideSell {
-			side = exchange.OrderSideSell
-		} else {
-			return nil, errors.Errorf("unknown side=%s", resp.Side)
-		}
-	} else {
-		return nil, errors.Errorf("unknown positionSide=%s", resp.PositionSide)
+	side, err = ParseOrderSide(resp.Side, resp.PositionSide, resp.ReduceOnly)
+	if err != nil {
+		return nil, err
 	}
 
 	return &exchange.Order{
 		ID:       exchange.NewIntID(resp.OrderID),
+		ClientID: exchange.NewStrID(resp.ClientOrderID),
 		Symbol:   symbol,
 		Amount:   resp.OrigQty,
 		Price:    resp.Price,
@@ -229,6 +238,59 @@ func (resp *OrderResp) Transfer() (*exchange.Order, error) {
 	}, nil
 }
 
+// ParseSide translate binance side and positionSide into exchange.OrderSide
+func ParseSide(side string, positionSide string) (exchange.OrderSide, error) {
+	return ParseOrderSide(side, positionSide, false)
+}
+
+// ParseOrderSide translate binance side, positionSide and reduceOnly into exchange.OrderSide,
+// reduce only order in one-way mode is closing order
+func ParseOrderSide(side string, positionSide string, reduceOnly bool) (exchange.OrderSide, error) {
+	if side != SideBuy && side != SideSell {
+		return exchange.OrderSideBuy, errors.Errorf("unknown side=%s", side)
+	}
+
+	var ts *exchange.TradeSide
+	switch positionSide {
+	case PositionSideBoth:
+		ts = exchange.ParseTradeSide(side == SideBuy, false, exchange.PositionSideLong, reduceOnly)
+
+	case PositionSideLong:
+		ts = exchange.ParseTradeSide(side == SideBuy, true, exchange.PositionSideLong, false)
+
+	case PositionSideShort:
+		ts = exchange.ParseTradeSide(side == SideBuy, true, exchange.PositionSideShort, false)
+
+	default:
+		return exchange.OrderSideBuy, errors.Errorf("unknown positionSide=%s", positionSide)
+	}
+	return ts.OrderSide(), nil
+}
+
+// OrderSideParam translate exchange.OrderSide into binance side and positionSide. closing
+// order is sent as reduce only in one-way mode
+func OrderSideParam(side exchange.OrderSide, dualSide bool) (bnSide string, positionSide string, reduceOnly bool, err error) {
+	ts, err := exchange.NewTradeSide(side, dualSide)
+	if err != nil {
+		return
+	}
+
+	bnSide = SideSell
+	if ts.IsBuy() {
+		bnSide = SideBuy
+	}
+
+	positionSide = PositionSideBoth
+	if dualSide {
+		positionSide = PositionSideShort
+		if ts.PositionSide == exchange.PositionSideLong {
+			positionSide = PositionSideLong
+		}
+	}
+	reduceOnly = ts.ReduceOnly()
+	return
+}
+
 func (cl *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest) (*exchange.Order, error) {
 	if cl.side == nil {
 		return nil, errors.Errorf("positionSide not init")
@@ -239,49 +301,9 @@ func (cl *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 		return nil, errors.Errorf("unknown type=%s", req.Type)
 	}
 
-	var (
-		side         string
-		positionSide string
-	)
-
-	//dual side position
-	if cl.side.DualSidePosition {
-		switch req.Side {
-		case exchange.OrderSideBuy:
-			side = SideBuy
-			positionSide = PositionSideLong
-
-		case exchange.OrderSideSell:
-			side = SideSell
-			positionSide = PositionSideShort
-
-		case exchange.OrderSideCloseLong:
-			side = SideSell
-			positionSide = PositionSideLong
-
-		case exchange.OrderSideCloseShort:
-			side = SideBuy
-			positionSide = PositionSideShort
-
-		default:
-			return nil, errors.Errorf("unknown side=%s", req.Side)
-		}
-	} else {
-		positionSide = PositionSideBoth
-		switch req.Side {
-		case exchange.OrderSideBuy:
-			fallthrough
-		case exchange.OrderSideCloseShort:
-			side = SideBuy
-
-		case exchange.OrderSideSell:
-			fallthrough
-		case exchange.OrderSideCloseLong:
-			side = SideSell
-
-		default:
-			return nil, errors.Errorf("unknown side=%s", req.Side)
-		}
+	side, positionSide, reduceOnly, err := OrderSideParam(req.Side, cl.side.DualSidePosition)
+	if err != nil {
+		return nil, err
 	}
 
 	or := NewAddOrderReq(req.Symbol.String(), side, typ)
@@ -291,6 +313,12 @@ func (cl *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 	}
 	or.Quantity(req.Amount)
 	or.PositionSide(positionSide)
+	if reduceOnly {
+		or.ReduceOnly(true)
+	}
+	if req.ClientID != nil {
+		or.NewClientOrderID(req.ClientID.String())
+	}
 
 	resp, err := cl.AddOrder(ctx, or)
 	if err != nil {
@@ -304,16 +332,24 @@ func (cl *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderReques
 	return ret, nil
 }
 
+// FetchOrder fetch order by ID, ClientID is used if ID is not set
 func (cl *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
-	id, err := strconv.ParseInt(order.ID.String(), 10, 64)
-	if err != nil {
-		return nil, errors.WithMessagef(err, "bad orderID=%s", order.ID.String())
+	req := NewOrderReq(order.Symbol.String())
+	if order.ID != nil {
+		id, err := strconv.ParseInt(order.ID.String(), 10, 64)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "bad orderID=%s", order.ID.String())
+		}
+		req.OrderID(id)
+	} else if order.ClientID != nil {
+		req.OrigClientOrderID(order.ClientID.String())
+	} else {
+		return nil, errors.Errorf("order id or client id is required")
 	}
-	req := NewOrderReq(order.Symbol.String()).OrderID(id)
 
 	resp, err := cl.GetOrder(ctx, req)
 	if err != nil {
-		return nil, errors.WithMessagef(err, "get order fail ID=%s", order.ID.String())
+		return nil, errors.WithMessagef(err, "get order fail ID=%v clientID=%v", order.ID, order.ClientID)
 	}
 
 	return resp.Transfer()
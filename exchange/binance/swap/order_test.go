@@ -0,0 +1,32 @@
+package swap
+
+import (
+	"testing"
+
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func TestOrderSideParam(t *testing.T) {
+	for _, dual := range []bool{true, false} {
+		for _, side := range []exchange.OrderSide{exchange.OrderSideBuy, exchange.OrderSideSell,
+			exchange.OrderSideCloseLong, exchange.OrderSideCloseShort} {
+			bnSide, posSide, reduceOnly, err := OrderSideParam(side, dual)
+			if err != nil {
+				t.Fatalf("order side param fail %s", err.Error())
+			}
+
+			if dual && reduceOnly {
+				t.Errorf("reduceOnly should not be set in dual side mode side=%s", side)
+			}
+
+			back, err := ParseOrderSide(bnSide, posSide, reduceOnly)
+			if err != nil {
+				t.Fatalf("parse order side fail %s", err.Error())
+			}
+
+			if back != side {
+				t.Errorf("round trip fail side=%s dual=%v got=%s", side, dual, back)
+			}
+		}
+	}
+}
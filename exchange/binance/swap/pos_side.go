@@ -55,11 +55,13 @@ func (rc *RestClient) SetPositionSide(ctx context.Context, req *SetPositionSideR
 
 	var resp SetPositionSideResp
 	if err := rc.Request(ctx, http.MethodPost, PositionSidePath, values, nil, true, &resp); err != nil {
-		return errors.WithMessage(err, "set position side fail")
+		if err = binance.CheckMarginError(err); err != nil {
+			return errors.WithMessage(err, "set position side fail")
+		}
 	}
 
-	if resp.Code != 200 {
-		return errors.Errorf("set position fail resp=%+v", resp)
+	if err := binance.CheckMarginCode(resp.Code, resp.Message); err != nil {
+		return errors.WithMessage(err, "set position side fail")
 	}
 
 	rc.side = &GetPositionSideResp{
@@ -83,3 +85,8 @@ func NewSetPositionSideRequest(dualSide bool) *SetPositionSideRequest {
 		dualSide: dualSide,
 	}
 }
+
+// DualSide return the position mode to be set
+func (req *SetPositionSideRequest) DualSide() bool {
+	return req.dualSide
+}
@@ -0,0 +1,474 @@
+package swap
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/binance"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+	"github.com/szmcdull/ccexgo/misc/ctxlog"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+	"github.com/tidwall/gjson"
+)
+
+type (
+	// SymbolParser parse binance symbol string into exchange.Symbol
+	SymbolParser func(symbol string) (exchange.Symbol, error)
+
+	// UserDataCodeC decode binance usdⓈ-m futures user data stream, coin-m
+	// futures share the same message format with a different SymbolParser
+	UserDataCodeC struct {
+		*binance.CodeC
+		parseSymbol SymbolParser
+	}
+
+	// UserDataWSClient binance usdⓈ-m futures user data stream client, the
+	// connection is rebuilt with a new listenKey once listenKeyExpired received
+	UserDataWSClient struct {
+		*binance.WSClient
+		data    chan interface{}
+		expired chan struct{}
+	}
+
+	OrderTradeUpdate struct {
+		EventTS         int64
+		TransactTS      int64
+		Symbol          string
+		ClientOrderID   string
+		Side            string
+		Type            string
+		OrigType        string
+		TimeInForce     string
+		Quantity        decimal.Decimal
+		Price           decimal.Decimal
+		AvgPrice        decimal.Decimal
+		StopPrice       decimal.Decimal
+		ExecutionType   string
+		Status          string
+		OrderID         int64
+		LastQty         decimal.Decimal
+		CumQty          decimal.Decimal
+		LastPrice       decimal.Decimal
+		CommissionAsset string
+		Commission      decimal.Decimal
+		TradeID         int64
+		IsMaker         bool
+		ReduceOnly      bool
+		PositionSide    string
+		RealizedProfit  decimal.Decimal
+	}
+
+	// OrderUpdate parsed ORDER_TRADE_UPDATE. LastFilled and LastPrice is the
+	// delta of this update, Trade is only set when the execution type is TRADE
+	OrderUpdate struct {
+		Order       *exchange.Order
+		Trade       *exchange.Trade
+		LastFilled  decimal.Decimal
+		LastPrice   decimal.Decimal
+		RealizedPNL decimal.Decimal
+	}
+
+	// AccountUpdate parsed ACCOUNT_UPDATE, only changed balances and positions
+	// are included. Balance Total is wallet balance and Free is cross wallet balance
+	AccountUpdate struct {
+		Reason    string
+		EventTS   int64
+		Balances  *exchange.Balances
+		Updates   []*exchange.BalanceUpdate
+		Positions []*exchange.Position
+	}
+
+	MarginCall struct {
+		EventTS            int64
+		CrossWalletBalance decimal.Decimal
+		Positions          []*exchange.Position
+	}
+)
+
+const (
+	OrderTradeUpdateEvent = "ORDER_TRADE_UPDATE"
+	AccountUpdateEvent    = "ACCOUNT_UPDATE"
+	MarginCallEvent       = "MARGIN_CALL"
+	ListenKeyExpiredEvent = "listenKeyExpired"
+
+	ExecutionTypeTrade = "TRADE"
+)
+
+var (
+	// reason2BalanceReason map reason of ACCOUNT_UPDATE, others are taken as other
+	reason2BalanceReason = map[string]exchange.BalanceReason{
+		"ORDER":           exchange.BalanceReasonTrade,
+		"FUNDING_FEE":     exchange.BalanceReasonFunding,
+		"DEPOSIT":         exchange.BalanceReasonTransfer,
+		"WITHDRAW":        exchange.BalanceReasonTransfer,
+		"ADMIN_DEPOSIT":   exchange.BalanceReasonTransfer,
+		"ADMIN_WITHDRAW":  exchange.BalanceReasonTransfer,
+		"ASSET_TRANSFER":  exchange.BalanceReasonTransfer,
+		"MARGIN_TRANSFER": exchange.BalanceReasonTransfer,
+	}
+)
+
+func NewUserDataCodeC() *UserDataCodeC {
+	return NewUserDataCodeCWithParser(func(symbol string) (exchange.Symbol, error) {
+		return ParseSymbol(symbol)
+	})
+}
+
+func NewUserDataCodeCWithParser(parser SymbolParser) *UserDataCodeC {
+	return &UserDataCodeC{
+		CodeC:       binance.NewCodeC(),
+		parseSymbol: parser,
+	}
+}
+
+// Decode binance usdⓈ-m futures user data notify
+func (cc *UserDataCodeC) Decode(raw []byte) (rpc.Response, error) {
+	return cc.DecodeByCB(raw, func(g *gjson.Result) (rpc.Response, error) {
+		event := g.Get("e").String()
+		switch event {
+		case OrderTradeUpdateEvent:
+			ou, err := ParseOrderTradeUpdate(g)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid order trade update")
+			}
+			update, err := ou.Parse(cc.parseSymbol)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid order trade update")
+			}
+			return &rpc.Notify{Method: event, Params: update}, nil
+
+		case AccountUpdateEvent:
+			update, err := ParseAccountUpdate(g, cc.parseSymbol)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid account update")
+			}
+			return &rpc.Notify{Method: event, Params: update}, nil
+
+		case MarginCallEvent:
+			mc, err := ParseMarginCall(g, cc.parseSymbol)
+			if err != nil {
+				return nil, errors.WithMessage(err, "invalid margin call")
+			}
+			return &rpc.Notify{Method: event, Params: mc}, nil
+
+		case ListenKeyExpiredEvent:
+			return &rpc.Notify{Method: event, Params: g.Get("E").Int()}, nil
+		}
+
+		return nil, errors.Errorf("bad notify msg=%s", g.Raw)
+	})
+}
+
+// ParseOrderTradeUpdate parse ORDER_TRADE_UPDATE, commission is not pushed if there is no commission
+func ParseOrderTradeUpdate(g *gjson.Result) (*OrderTradeUpdate, error) {
+	var dp decimalParser
+	o := g.Get("o")
+	ret := &OrderTradeUpdate{
+		EventTS:         g.Get("E").Int(),
+		TransactTS:      g.Get("T").Int(),
+		Symbol:          o.Get("s").String(),
+		ClientOrderID:   o.Get("c").String(),
+		Side:            o.Get("S").String(),
+		Type:            o.Get("o").String(),
+		OrigType:        o.Get("ot").String(),
+		TimeInForce:     o.Get("f").String(),
+		Quantity:        dp.parse(&o, "q"),
+		Price:           dp.parse(&o, "p"),
+		AvgPrice:        dp.parse(&o, "ap"),
+		StopPrice:       dp.parse(&o, "sp"),
+		ExecutionType:   o.Get("x").String(),
+		Status:          o.Get("X").String(),
+		OrderID:         o.Get("i").Int(),
+		LastQty:         dp.parse(&o, "l"),
+		CumQty:          dp.parse(&o, "z"),
+		LastPrice:       dp.parse(&o, "L"),
+		CommissionAsset: o.Get("N").String(),
+		Commission:      dp.optional(&o, "n"),
+		TradeID:         o.Get("t").Int(),
+		IsMaker:         o.Get("m").Bool(),
+		ReduceOnly:      o.Get("R").Bool(),
+		PositionSide:    o.Get("ps").String(),
+		RealizedProfit:  dp.optional(&o, "rp"),
+	}
+	if dp.err != nil {
+		return nil, dp.err
+	}
+	return ret, nil
+}
+
+// Parse order trade update into exchange.Order and exchange.Trade
+func (ou *OrderTradeUpdate) Parse(parseSymbol SymbolParser) (*OrderUpdate, error) {
+	symbol, err := parseSymbol(ou.Symbol)
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	typ, ok := OrderType2ExType[ou.Type]
+	if !ok {
+		return nil, errors.Errorf("unknown order type=%s", ou.Type)
+	}
+
+	status, ok := Status2ExStatus[ou.Status]
+	if !ok {
+		return nil, errors.Errorf("unknown order status=%s", ou.Status)
+	}
+
+	side, err := ParseOrderSide(ou.Side, ou.PositionSide, ou.ReduceOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := tconv.Milli2Time(ou.TransactTS)
+	ret := &OrderUpdate{
+		Order: &exchange.Order{
+			ID:          exchange.NewIntID(ou.OrderID),
+			ClientID:    exchange.NewStrID(ou.ClientOrderID),
+			Symbol:      symbol,
+			Amount:      ou.Quantity,
+			Filled:      ou.CumQty,
+			Price:       ou.Price,
+			AvgPrice:    ou.AvgPrice,
+			FeeCurrency: ou.CommissionAsset,
+			Updated:     ts,
+			Type:        typ,
+			Side:        side,
+			Status:      status,
+			Raw:         ou,
+		},
+		LastFilled:  ou.LastQty,
+		LastPrice:   ou.LastPrice,
+		RealizedPNL: ou.RealizedProfit,
+	}
+
+	if ou.ExecutionType == ExecutionTypeTrade {
+		ret.Trade = &exchange.Trade{
+			ID:          strconv.FormatInt(ou.TradeID, 10),
+			OrderID:     strconv.FormatInt(ou.OrderID, 10),
+			Symbol:      symbol,
+			Price:       ou.LastPrice,
+			Amount:      ou.LastQty,
+			Fee:         ou.Commission.Neg(),
+			FeeCurrency: ou.CommissionAsset,
+			Time:        ts,
+			Side:        side,
+			IsMaker:     ou.IsMaker,
+			Raw:         ou,
+		}
+	}
+	return ret, nil
+}
+
+func ParseAccountUpdate(g *gjson.Result, parseSymbol SymbolParser) (*AccountUpdate, error) {
+	var dp decimalParser
+	a := g.Get("a")
+	ret := &AccountUpdate{
+		Reason:   a.Get("m").String(),
+		EventTS:  g.Get("E").Int(),
+		Balances: exchange.NewBalances(),
+	}
+
+	reason := reason2BalanceReason[ret.Reason]
+	ts := tconv.Milli2Time(g.Get("T").Int())
+	for _, b := range a.Get("B").Array() {
+		balance := &exchange.Balance{
+			Currency: b.Get("a").String(),
+			Total:    dp.parse(&b, "wb"),
+			Free:     dp.parse(&b, "cw"),
+		}
+		ret.Balances.Add(balance)
+		ret.Updates = append(ret.Updates, exchange.NewBalanceUpdate(balance,
+			exchange.BalanceFieldTotal|exchange.BalanceFieldFree, reason, ts, b.Raw))
+	}
+	if dp.err != nil {
+		return nil, dp.err
+	}
+	ret.Balances.Raw = a.Raw
+
+	for _, p := range a.Get("P").Array() {
+		pos, err := parsePosition(&p, parseSymbol)
+		if err != nil {
+			return nil, err
+		}
+		ret.Positions = append(ret.Positions, pos)
+	}
+	return ret, nil
+}
+
+// BalanceUpdates implement exchange.BalanceUpdater
+func (au *AccountUpdate) BalanceUpdates() []*exchange.BalanceUpdate {
+	return au.Updates
+}
+
+// PositionUpdates implement exchange.PositionUpdater
+func (au *AccountUpdate) PositionUpdates() []*exchange.Position {
+	return au.Positions
+}
+
+// OrderUpdates implement exchange.OrderUpdater
+func (ou *OrderUpdate) OrderUpdates() []*exchange.Order {
+	return []*exchange.Order{ou.Order}
+}
+
+func ParseMarginCall(g *gjson.Result, parseSymbol SymbolParser) (*MarginCall, error) {
+	var dp decimalParser
+	ret := &MarginCall{
+		EventTS:            g.Get("E").Int(),
+		CrossWalletBalance: dp.optional(g, "cw"),
+	}
+	if dp.err != nil {
+		return nil, dp.err
+	}
+
+	for _, p := range g.Get("p").Array() {
+		pos, err := parsePosition(&p, parseSymbol)
+		if err != nil {
+			return nil, err
+		}
+		ret.Positions = append(ret.Positions, pos)
+	}
+	return ret, nil
+}
+
+// parsePosition parse position of ACCOUNT_UPDATE and MARGIN_CALL
+func parsePosition(g *gjson.Result, parseSymbol SymbolParser) (*exchange.Position, error) {
+	symbol, err := parseSymbol(g.Get("s").String())
+	if err != nil {
+		return nil, errors.WithMessage(err, "parse symbol fail")
+	}
+
+	var dp decimalParser
+	amt := dp.parse(g, "pa")
+	ret := &exchange.Position{
+		Symbol:        symbol,
+		Mode:          exchange.PositionModeCross,
+		Position:      amt.Abs(),
+		AvgOpenPrice:  dp.optional(g, "ep"),
+		RealizedPNL:   dp.optional(g, "cr"),
+		UNRealizedPNL: dp.parse(g, "up"),
+		Raw:           g.Raw,
+	}
+
+	//isolated in ACCOUNT_UPDATE and ISOLATED in MARGIN_CALL
+	if strings.ToLower(g.Get("mt").String()) == "isolated" {
+		ret.Mode = exchange.PositionModeFixed
+		ret.Margin = dp.parse(g, "iw")
+	}
+
+	if dp.err != nil {
+		return nil, dp.err
+	}
+
+	switch ps := g.Get("ps").String(); ps {
+	case PositionSideLong:
+		ret.Side = exchange.PositionSideLong
+
+	case PositionSideShort:
+		ret.Side = exchange.PositionSideShort
+
+	case PositionSideBoth:
+		if amt.IsNegative() {
+			ret.Side = exchange.PositionSideShort
+		} else {
+			ret.Side = exchange.PositionSideLong
+		}
+
+	default:
+		return nil, errors.Errorf("unknown positionSide=%s", ps)
+	}
+	return ret, nil
+}
+
+// decimalParser parse decimal fields of a notify and keep the first error
+type decimalParser struct {
+	err error
+}
+
+func (dp *decimalParser) parse(g *gjson.Result, key string) decimal.Decimal {
+	raw := g.Get(key).String()
+	ret, err := decimal.NewFromString(raw)
+	if err != nil && dp.err == nil {
+		dp.err = errors.WithMessagef(err, "parse field %s='%s' fail", key, raw)
+	}
+	return ret
+}
+
+// optional parse the field which may not be pushed, zero is returned if not exists
+func (dp *decimalParser) optional(g *gjson.Result, key string) decimal.Decimal {
+	if !g.Get(key).Exists() {
+		return decimal.Zero
+	}
+	return dp.parse(g, key)
+}
+
+func NewUserDataWSClient(key, secret string, data chan interface{}) *UserDataWSClient {
+	return NewUserDataWSClientWithCodeC(NewUserDataCodeC(), NewRestClient(key, secret), data)
+}
+
+func NewTestUserDataWSClient(key, secret string, data chan interface{}) *UserDataWSClient {
+	return NewUserDataWSClientWithCodeC(NewUserDataCodeC(), NewTestRestClient(key, secret), data)
+}
+
+func NewUserDataWSClientWithCodeC(codec *UserDataCodeC, client binance.ListenKeyClient, data chan interface{}) *UserDataWSClient {
+	ret := &UserDataWSClient{
+		data:    data,
+		expired: make(chan struct{}, 1),
+	}
+	ret.WSClient = binance.NewWSClient(codec, ret, client)
+	return ret
+}
+
+// Run connect to user data stream and reconnect when listenKey expired
+func (ws *UserDataWSClient) Run(ctx context.Context) error {
+	if err := ws.WSClient.Run(ctx); err != nil {
+		return err
+	}
+
+	go ws.loop(ctx)
+	return nil
+}
+
+func (ws *UserDataWSClient) Handle(ctx context.Context, notify *rpc.Notify) {
+	if notify.Method == ListenKeyExpiredEvent {
+		select {
+		case ws.expired <- struct{}{}:
+		default:
+		}
+	}
+
+	msg := &exchange.WSNotify{Exchange: binance.Exchange, Chan: notify.Method, Data: notify.Params}
+	select {
+	case ws.data <- msg:
+	default:
+	}
+}
+
+func (ws *UserDataWSClient) loop(ctx context.Context) {
+	logger := ctxlog.GetSafeLog(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ws.expired:
+			for {
+				err := ws.Reconnect(ctx)
+				if err == nil {
+					break
+				}
+				level.Warn(logger).Log("message", "reconnect user data stream fail", "error", err.Error())
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(time.Second):
+				}
+			}
+		}
+	}
+}
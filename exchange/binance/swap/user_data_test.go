@@ -0,0 +1,97 @@
+package swap
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func initTestSymbol() {
+	s := &Symbol{Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}
+	sym, _ := s.Parse()
+	symbolMap[sym.String()] = sym
+}
+
+func TestDecodeOrderTradeUpdate(t *testing.T) {
+	initTestSymbol()
+	raw := `{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"o":{"s":"BTCUSDT",
+	"c":"TEST","S":"SELL","o":"LIMIT","f":"GTC","q":"0.002","p":"9910","ap":"9910","sp":"0",
+	"x":"TRADE","X":"PARTIALLY_FILLED","i":8886774,"l":"0.001","z":"0.001","L":"9910","N":"USDT",
+	"n":"0.0019","T":1568879465651,"t":12345,"b":"0","a":"9.91","m":true,"R":false,"wt":"CONTRACT_PRICE",
+	"ot":"LIMIT","ps":"LONG","cp":false,"rp":"1.5"}}`
+
+	resp, err := NewUserDataCodeC().Decode([]byte(raw))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+
+	update := resp.(*rpc.Notify).Params.(*OrderUpdate)
+	if update.Order.Side != exchange.OrderSideCloseLong || update.Order.Status != exchange.OrderStatusOpen ||
+		!update.LastFilled.Equal(decimal.RequireFromString("0.001")) ||
+		!update.RealizedPNL.Equal(decimal.RequireFromString("1.5")) {
+		t.Errorf("bad order update %+v", *update.Order)
+	}
+
+	if update.Trade == nil || update.Trade.ID != "12345" ||
+		!update.Trade.Fee.Equal(decimal.RequireFromString("-0.0019")) {
+		t.Errorf("bad trade %+v", update.Trade)
+	}
+}
+
+func TestDecodeAccountUpdate(t *testing.T) {
+	initTestSymbol()
+	raw := `{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"a":{"m":"ORDER",
+	"B":[{"a":"USDT","wb":"122624.12345678","cw":"100.12345678","bc":"50.12345678"}],
+	"P":[{"s":"BTCUSDT","pa":"-20","ep":"6563.66500","cr":"0","up":"2850.21200","mt":"isolated",
+	"iw":"13200.70726908","ps":"BOTH"}]}}`
+
+	resp, err := NewUserDataCodeC().Decode([]byte(raw))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+
+	update := resp.(*rpc.Notify).Params.(*AccountUpdate)
+	if update.Reason != "ORDER" || len(update.Positions) != 1 {
+		t.Fatalf("bad account update %+v", *update)
+	}
+
+	pos := update.Positions[0]
+	if pos.Side != exchange.PositionSideShort || pos.Mode != exchange.PositionModeFixed ||
+		!pos.Position.Equal(decimal.NewFromInt(20)) {
+		t.Errorf("bad position %+v", *pos)
+	}
+
+	if b, ok := update.Balances.Balances["USDT"]; !ok || !b.Total.Equal(decimal.RequireFromString("122624.12345678")) {
+		t.Errorf("bad balances %+v", update.Balances.Balances)
+	}
+
+	if len(update.Updates) != 1 || update.Updates[0].Reason != exchange.BalanceReasonTrade ||
+		update.Updates[0].Has(exchange.BalanceFieldFrozen) {
+		t.Errorf("bad balance updates %+v", update.Updates)
+	}
+}
+
+func TestDecodeMalformedField(t *testing.T) {
+	initTestSymbol()
+	for _, raw := range []string{
+		`{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"o":{"s":"BTCUSDT","c":"TEST","S":"SELL",
+		"o":"LIMIT","f":"GTC","q":"bad","p":"9910","ap":"9910","sp":"0","x":"NEW","X":"NEW","i":8886774,"l":"0",
+		"z":"0","L":"0","T":1568879465651,"t":0,"m":false,"R":false,"ot":"LIMIT","ps":"LONG"}}`,
+		`{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"a":{"m":"ORDER",
+		"B":[{"a":"USDT","wb":"","cw":"100"}],"P":[]}}`,
+		`{"e":"MARGIN_CALL","E":1587727187525,"cw":"3.16812045","p":[{"s":"BTCUSDT","ps":"LONG","pa":"1.327",
+		"mt":"CROSSED","iw":"0","mp":"6882.35","up":"x","mm":"1"}]}`,
+	} {
+		if _, err := NewUserDataCodeC().Decode([]byte(raw)); err == nil {
+			t.Errorf("malformed decimal should fail %s", raw)
+		}
+	}
+	// position of margin call has no entry price
+	raw := `{"e":"MARGIN_CALL","E":1587727187525,"cw":"3.16812045","p":[{"s":"BTCUSDT","ps":"LONG","pa":"1.327",
+	"mt":"CROSSED","iw":"0","mp":"6882.35","up":"1.5","mm":"1"}]}`
+	if _, err := NewUserDataCodeC().Decode([]byte(raw)); err != nil {
+		t.Errorf("decode margin call fail %s", err.Error())
+	}
+}
@@ -19,9 +19,11 @@ type (
 		DeleteListenKey(ctx context.Context) error
 	}
 
-	// WSClient common private wsclient for binance
+	// WSClient common private wsclient for binance, the connection is replaced
+	// by Reconnect so it's accessed under mu
 	WSClient struct {
-		rpc.Conn
+		conn    rpc.Conn
+		mu      sync.Mutex
 		handler rpc.Handler
 		codec   rpc.Codec
 		client  ListenKeyClient
@@ -43,18 +45,16 @@ func NewWSClient(codec rpc.Codec, handler rpc.Handler, client ListenKeyClient) *
 }
 
 func (ws *WSClient) Run(ctx context.Context) error {
-	addr, err := ws.client.GetListenKeyAddr(ctx)
-	if err != nil {
-		return errors.WithMessage(err, "get listenKey addr fail")
+	if err := ws.connect(ctx); err != nil {
+		return err
 	}
 
 	logger := ctxlog.GetSafeLog(ctx)
-	level.Debug(logger).Log("message", "get listenKeyAddr", "addr", addr)
-
 	go func() {
 		ticker := time.NewTicker(time.Minute * 59)
 
 		defer func() {
+			ticker.Stop()
 			ws.client.DeleteListenKey(context.Background())
 		}()
 		for {
@@ -71,15 +71,80 @@ func (ws *WSClient) Run(ctx context.Context) error {
 		}
 	}()
 
+	return nil
+}
+
+// Reconnect connect with a new listenKey and close the previous connection, it's
+// used when the listenKey is expired
+func (ws *WSClient) Reconnect(ctx context.Context) error {
+	return ws.connect(ctx)
+}
+
+// Conn return current connection, nil if not connected
+func (ws *WSClient) Conn() rpc.Conn {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	return ws.conn
+}
+
+func (ws *WSClient) Call(ctx context.Context, id string, method string, params interface{}, dest interface{}) error {
+	conn := ws.Conn()
+	if conn == nil {
+		return rpc.ErrNotRunning
+	}
+	return conn.Call(ctx, id, method, params, dest)
+}
+
+// Done return the done channel of current connection, it's nil if not connected
+func (ws *WSClient) Done() <-chan struct{} {
+	conn := ws.Conn()
+	if conn == nil {
+		return nil
+	}
+	return conn.Done()
+}
+
+func (ws *WSClient) Error() error {
+	conn := ws.Conn()
+	if conn == nil {
+		return rpc.ErrNotRunning
+	}
+	return conn.Error()
+}
+
+func (ws *WSClient) Close() error {
+	conn := ws.Conn()
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+func (ws *WSClient) connect(ctx context.Context) error {
+	addr, err := ws.client.GetListenKeyAddr(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "get listenKey addr fail")
+	}
+
+	logger := ctxlog.GetSafeLog(ctx)
+	level.Debug(logger).Log("message", "get listenKeyAddr", "addr", addr)
+
 	stream, err := rpc.NewWebsocketStream(addr, ws.codec)
 	if err != nil {
 		return errors.WithMessage(err, "create websocket stream fail")
 	}
 
 	conn := rpc.NewConn(stream)
-	ws.Conn = conn
+	go conn.Run(ctx, ws.handler)
 
-	go ws.Conn.Run(ctx, ws.handler)
+	ws.mu.Lock()
+	prev := ws.conn
+	ws.conn = conn
+	ws.mu.Unlock()
+
+	if prev != nil {
+		prev.Close()
+	}
 	return nil
 }
 
@@ -0,0 +1,219 @@
+package exchange
+
+import (
+	"context"
+	"crypto/rand"
+	"io"
+	"math/big"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	//ClientIDGenerator generate unique client order id under the length and charset
+	//rule of the exchange. numeric id is IntID and others are StrID
+	ClientIDGenerator struct {
+		prefix  string
+		maxLen  int
+		numeric bool
+		seq     uint32
+	}
+
+	//CreateOrderFunc create order with the request, options should be bound by caller
+	CreateOrderFunc func(ctx context.Context, req *OrderRequest) (*Order, error)
+
+	//OrderSubmitter create order idempotently. client id is generated if not set, on
+	//timeout or network error the order is looked up by client id and only sent
+	//again with the same client id if it does not exist, which is rejected by the
+	//exchange if the previous request arrives late
+	OrderSubmitter struct {
+		create   CreateOrderFunc
+		fetcher  OrderFetcher
+		notFound func(error) bool
+		gen      *ClientIDGenerator
+		timeout  time.Duration
+		retry    int
+		interval time.Duration
+	}
+)
+
+const (
+	clientIDChars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	//clientIDRandLen random suffix of alphanumeric id which make id unique across processes
+	clientIDRandLen = 6
+	//clientIDRandNum random digits of numeric id which make id unique across processes
+	clientIDRandNum = 1000
+)
+
+//NewClientIDGenerator create generator with max length of the id. alphanumeric id is
+//prefix followed by timestamp, sequence and random chars, the prefix is truncated if
+//it's too long. numeric id is millisecond timestamp followed by 3 digits sequence and
+//3 random digits, which is 19 digits and fit in int64
+func NewClientIDGenerator(prefix string, maxLen int, numeric bool) *ClientIDGenerator {
+	return &ClientIDGenerator{
+		prefix:  prefix,
+		maxLen:  maxLen,
+		numeric: numeric,
+	}
+}
+
+//Next return a new client id
+func (g *ClientIDGenerator) Next() OrderID {
+	seq := atomic.AddUint32(&g.seq, 1)
+	now := time.Now()
+	if g.numeric {
+		ms := now.UnixNano() / int64(time.Millisecond)
+		return NewIntID((ms*1000+int64(seq%1000))*clientIDRandNum + randInt(clientIDRandNum))
+	}
+
+	body := strconv.FormatInt(now.UnixNano(), 36) + strconv.FormatUint(uint64(seq%1296), 36) + randChars(clientIDRandLen)
+	prefix := g.prefix
+	if g.maxLen > 0 && len(prefix)+len(body) > g.maxLen {
+		if len(body) > g.maxLen {
+			body = body[len(body)-g.maxLen:]
+		}
+		prefix = prefix[:g.maxLen-len(body)]
+	}
+	return NewStrID(prefix + body)
+}
+
+//NewOrderSubmitter create submitter, timeout is the deadline of each CreateOrder
+//call and retry is the max number of resending
+func NewOrderSubmitter(create CreateOrderFunc, fetcher OrderFetcher, gen *ClientIDGenerator, timeout time.Duration, retry int, interval time.Duration) *OrderSubmitter {
+	return &OrderSubmitter{
+		create:   create,
+		fetcher:  fetcher,
+		gen:      gen,
+		timeout:  timeout,
+		retry:    retry,
+		interval: interval,
+	}
+}
+
+//NotFound set the func which tell whether the lookup error means the order does not
+//exist, such as IsOrderNotFound of exchange packages. errors.Is ErrOrderNotFound is
+//used by default
+func (s *OrderSubmitter) NotFound(f func(error) bool) *OrderSubmitter {
+	s.notFound = f
+	return s
+}
+
+//Submit create the order, the request ClientID is set if empty
+func (s *OrderSubmitter) Submit(ctx context.Context, req *OrderRequest) (*Order, error) {
+	if req.ClientID == nil || req.ClientID.String() == "" {
+		req.ClientID = s.gen.Next()
+	}
+
+	for i := 0; ; i++ {
+		order, err := s.createOrder(ctx, req)
+		if err == nil {
+			return order, nil
+		}
+
+		if !IsUncertain(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		//the order may be accepted by the exchange
+		if err := sleepCtx(ctx, s.interval); err != nil {
+			return nil, err
+		}
+		order, ferr := s.lookup(ctx, req)
+		if ferr == nil {
+			return order, nil
+		}
+
+		if !s.orderNotFound(ferr) {
+			return nil, errors.WithMessagef(err, "order '%s' state unknown, lookup fail %s", req.ClientID.String(), ferr.Error())
+		}
+
+		if i >= s.retry {
+			return nil, errors.WithMessagef(err, "create order '%s' fail after %d retries", req.ClientID.String(), i)
+		}
+	}
+}
+
+func (s *OrderSubmitter) orderNotFound(err error) bool {
+	if s.notFound != nil {
+		return s.notFound(err)
+	}
+	return errors.Is(err, ErrOrderNotFound)
+}
+
+func (s *OrderSubmitter) createOrder(ctx context.Context, req *OrderRequest) (*Order, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	return s.create(ctx, req)
+}
+
+func (s *OrderSubmitter) lookup(ctx context.Context, req *OrderRequest) (*Order, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	order, err := s.fetcher.FetchOrder(ctx, &Order{ClientID: req.ClientID, Symbol: req.Symbol})
+	if err != nil {
+		return nil, err
+	}
+	if order.ClientID == nil || order.ClientID.String() == "" {
+		order.ClientID = req.ClientID
+	}
+	return order, nil
+}
+
+//IsUncertain whether the request may be executed by the exchange while the result
+//is unknown, such as timeout or network error
+func IsUncertain(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var ne net.Error
+	return errors.As(err, &ne)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func randInt(n int64) int64 {
+	ret, err := rand.Int(rand.Reader, big.NewInt(n))
+	if err != nil {
+		return time.Now().UnixNano() % n
+	}
+	return ret.Int64()
+}
+
+func randChars(n int) string {
+	ret := make([]byte, n)
+	max := big.NewInt(int64(len(clientIDChars)))
+	for i := range ret {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			ret[i] = clientIDChars[i]
+			continue
+		}
+		ret[i] = clientIDChars[idx.Int64()]
+	}
+	return string(ret)
+}
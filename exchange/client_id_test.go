@@ -0,0 +1,100 @@
+package exchange
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+var errTestNotFound = errors.New("not found")
+
+type testSubmitFetcher struct {
+	found  bool
+	lookup int
+}
+
+func (tf *testSubmitFetcher) FetchOrder(ctx context.Context, order *Order) (*Order, error) {
+	tf.lookup++
+	if !tf.found {
+		return nil, errors.WithMessage(errTestNotFound, "test")
+	}
+	return &Order{ID: NewStrID("1"), Symbol: order.Symbol, Status: OrderStatusOpen}, nil
+}
+
+func TestClientIDGenerator(t *testing.T) {
+	gen := NewClientIDGenerator("strategyprefixtoolong", 32, false)
+	ids := map[string]bool{}
+	for i := 0; i < 1000; i++ {
+		id := gen.Next().String()
+		if len(id) > 32 {
+			t.Fatalf("id '%s' too long", id)
+		}
+		if ids[id] {
+			t.Fatalf("duplicate id '%s'", id)
+		}
+		ids[id] = true
+	}
+
+	num := NewClientIDGenerator("", 0, true)
+	id := num.Next().String()
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil || len(id) != 19 {
+		t.Errorf("numeric id '%s' fail %v", id, err)
+	}
+
+	//generators of different processes in the same millisecond
+	nums := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		nums[NewClientIDGenerator("", 0, true).Next().String()] = true
+	}
+	if len(nums) < 90 {
+		t.Errorf("numeric id should be random across generators, %d unique", len(nums))
+	}
+}
+
+func TestOrderSubmitter(t *testing.T) {
+	var cids []string
+	fetcher := &testSubmitFetcher{}
+	create := func(ctx context.Context, req *OrderRequest) (*Order, error) {
+		cids = append(cids, req.ClientID.String())
+		if len(cids) < 3 {
+			return nil, errors.WithMessage(context.DeadlineExceeded, "create order")
+		}
+		return &Order{ID: NewStrID("2"), ClientID: req.ClientID}, nil
+	}
+
+	notFound := func(err error) bool { return errors.Is(err, errTestNotFound) }
+	s := NewOrderSubmitter(create, fetcher, NewClientIDGenerator("t", 32, false), 0, 3, 0).NotFound(notFound)
+	order, err := s.Submit(context.Background(), &OrderRequest{Amount: decimal.NewFromInt(1)})
+	if err != nil {
+		t.Fatalf("submit fail %s", err.Error())
+	}
+	if order.ID.String() != "2" || len(cids) != 3 || fetcher.lookup != 2 {
+		t.Errorf("bad result order=%+v create=%d lookup=%d", order, len(cids), fetcher.lookup)
+	}
+	for _, cid := range cids {
+		if cid != cids[0] {
+			t.Errorf("client id changed %v", cids)
+		}
+	}
+
+	//accepted by the exchange while timeout
+	cids = nil
+	fetcher.found = true
+	order, err = s.Submit(context.Background(), &OrderRequest{ClientID: NewStrID("c1")})
+	if err != nil {
+		t.Fatalf("submit fail %s", err.Error())
+	}
+	if order.ID.String() != "1" || order.ClientID.String() != "c1" || len(cids) != 1 {
+		t.Errorf("bad result order=%+v create=%d", order, len(cids))
+	}
+
+	s = NewOrderSubmitter(func(ctx context.Context, req *OrderRequest) (*Order, error) {
+		return nil, errors.New("bad request")
+	}, fetcher, nil, 0, 3, 0)
+	if _, err := s.Submit(context.Background(), &OrderRequest{ClientID: NewStrID("c2")}); err == nil {
+		t.Errorf("error should be returned")
+	}
+}
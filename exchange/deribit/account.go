@@ -1,7 +1,11 @@
 package deribit
 
 import (
+	"context"
+
+	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
 )
 
 type (
@@ -42,6 +46,7 @@ type (
 		FuturesSessionUPL          decimal.Decimal `json:"futures_session_upl"`
 		AvailableFunds             decimal.Decimal `json:"available_funds"`
 		OptionsValue               decimal.Decimal `json:"options_value"`
+		MarginModel                string          `json:"margin_model"`
 	}
 
 	AccountSummaryRequest struct {
@@ -60,3 +65,30 @@ func NewAccountSummaryRequest(currency string) *AccountSummaryRequest {
 		Currency: currency,
 	}
 }
+
+// Transfer transfer account summary or user.portfolio notify into exchange.Balance,
+// the initial margin is taken as frozen
+func (ar *AccountSummaryResp) Transfer() *exchange.Balance {
+	return &exchange.Balance{
+		Currency: exchange.CurrencyFormat(ar.Currency),
+		Equitity: ar.Equity,
+		Total:    ar.Balance,
+		Free:     ar.AvailableFunds,
+		Frozen:   ar.InitialMargin,
+	}
+}
+
+// AccountSummary fetch account summary of the currency
+func (c *Client) AccountSummary(ctx context.Context, currency string) (*AccountSummaryResp, error) {
+	var ret AccountSummaryResp
+	if err := c.call(ctx, PrivateGetAccountSummary, NewAccountSummaryRequest(currency), &ret, true); err != nil {
+		return nil, errors.WithMessage(err, "fetch account summary fail")
+	}
+	return &ret, nil
+}
+
+// SessionPnL return realized and unrealized pnl of current session, which is settled into
+// balance on the next daily settlement
+func (ar *AccountSummaryResp) SessionPnL() decimal.Decimal {
+	return ar.SessinRPL.Add(ar.SessionUPL)
+}
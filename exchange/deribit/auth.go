@@ -3,13 +3,19 @@ package deribit
 import (
 	"context"
 	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/misc/ctxlog"
 )
 
 type (
 	AuthParam struct {
 		GrantType    string `json:"grant_type"`
-		ClientID     string `json:"client_id"`
-		ClientSecret string `json:"client_secret"`
+		ClientID     string `json:"client_id,omitempty"`
+		ClientSecret string `json:"client_secret,omitempty"`
+		RefreshToken string `json:"refresh_token,omitempty"`
+		Scope        string `json:"scope,omitempty"`
 	}
 
 	AuthResult struct {
@@ -29,28 +35,108 @@ type (
 	}
 )
 
+const (
+	PublicAuthMethod = "public/auth"
+
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeRefreshToken      = "refresh_token"
+
+	// tokenCheckInterval is the interval authLoop check whether the token need refresh
+	tokenCheckInterval = time.Second * 5
+)
+
 func (ap *AuthToken) SetToken(token string) {
 	ap.AccessToken = token
 }
 
+// Authenticate implement Authenticator by public/auth over the websocket connection
+func (c *Client) Authenticate(ctx context.Context, param *AuthParam) (*AuthResult, error) {
+	var r AuthResult
+	if err := c.doCall(ctx, PublicAuthMethod, param, &r, false); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// SetTokenManager replace the token manager of the client, which make it possible to share
+// token with RestClient or request specific scopes. the websocket connection is still
+// authenticated by public/auth on the connection with the shared token
+func (c *Client) SetTokenManager(tm *TokenManager) {
+	c.tm = tm
+	c.resetAuth()
+}
+
+func (c *Client) TokenManager() *TokenManager {
+	return c.tm
+}
+
 func (c *Client) getToken(ctx context.Context) (string, error) {
-	now := time.Now()
-	if c.expire.After(now) {
-		return c.accessToken, nil
+	return c.tm.Token(ctx)
+}
+
+// authenticate do public/auth on current connection once, private subscriptions only
+// work on an authenticated connection even if access_token is carried by the request
+func (c *Client) authenticate(ctx context.Context) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	conn := c.Conn
+	if conn != nil && c.authConn == conn {
+		return nil
 	}
 
-	var r AuthResult
-	param := &AuthParam{
-		ClientID:     c.key,
-		ClientSecret: c.secret,
-		GrantType:    "client_credentials",
-	}
-	if err := c.call(ctx, "public/auth", param, &r, false); err != nil {
-		return "", err
-	}
-	c.tokenMu.Lock()
-	defer c.tokenMu.Unlock()
-	c.accessToken = r.AccessToken
-	c.expire = now.Add(time.Duration(r.ExpiresIn-1) * time.Second)
-	return r.AccessToken, nil
+	r, err := c.tm.AuthenticateWith(ctx, c)
+	if err != nil {
+		return errors.WithMessage(err, "authenticate websocket fail")
+	}
+	c.authConn = conn
+	c.refreshAt = time.Now().Add(refreshAfter(r.ExpiresIn))
+	return nil
+}
+
+// refreshSession refresh the token on the authenticated connection before the session expire
+func (c *Client) refreshSession(ctx context.Context, now time.Time) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.authConn == nil || now.Before(c.refreshAt) {
+		return nil
+	}
+
+	r, err := c.tm.AuthenticateWith(ctx, c)
+	if err != nil {
+		return err
+	}
+	c.refreshAt = time.Now().Add(refreshAfter(r.ExpiresIn))
+	return nil
+}
+
+func (c *Client) resetAuth() {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.authConn = nil
+	c.refreshAt = time.Time{}
+}
+
+// authLoop refresh the token on the connection before it expire, which keep the session of
+// the websocket connection and so the private subscriptions alive. it does nothing until the
+// connection is authenticated
+func (c *Client) authLoop(ctx context.Context) {
+	logger := ctxlog.GetSafeLog(ctx)
+	ticker := time.NewTicker(tokenCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-c.Conn.Done():
+			return
+
+		case now := <-ticker.C:
+			if err := c.refreshSession(ctx, now); err != nil {
+				level.Warn(logger).Log("message", "deribit refresh token fail", "error", err.Error())
+			}
+		}
+	}
 }
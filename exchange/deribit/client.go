@@ -16,13 +16,13 @@ import (
 type (
 	Client struct {
 		*exchange.WSClient
-		tokenMu     sync.Mutex
-		accessToken string
-		expire      time.Time
-		seq         int64
-		key         string
-		secret      string
-		data        chan interface{}
+		tm   *TokenManager
+		seq  int64
+		data chan interface{}
+
+		authMu    sync.Mutex
+		authConn  rpc.Conn  //connection authenticated by public/auth
+		refreshAt time.Time //time to refresh the session of authConn
 	}
 
 	//clientReq comment struct which used to build request param
@@ -42,10 +42,9 @@ func NewTestWSClient(key, secret string, data chan interface{}) *Client {
 func newWSClient(addr, key, secret string, data chan interface{}) *Client {
 	codec := &Codec{}
 	ret := &Client{
-		key:    key,
-		secret: secret,
-		data:   data,
+		data: data,
 	}
+	ret.tm = NewTokenManager(ret, key, secret)
 	ret.WSClient = exchange.NewWSClient(addr, codec, ret)
 	return ret
 }
@@ -67,18 +66,43 @@ func (c *Client) Handle(ctx context.Context, notify *rpc.Notify) {
 	}
 }
 
-// Auth is done by client.call
-func (c *Client) Auth(ctx context.Context) error {
+// Run connect to the server and start the loop which re-authenticate before the token expire
+func (c *Client) Run(ctx context.Context) error {
+	if err := c.WSClient.Run(ctx); err != nil {
+		return err
+	}
+
+	go c.authLoop(ctx)
 	return nil
 }
 
+// Auth authenticate the connection by public/auth, it's also done by the first private call
+func (c *Client) Auth(ctx context.Context) error {
+	return c.authenticate(ctx)
+}
+
 // Call genetic method
 func (c *Client) Call(ctx context.Context, method string, params interface{}, dest interface{}, private bool) error {
 	return c.call(ctx, method, params, dest, private)
 }
 
+// call do the request, the token is dropped and login again if the server reply unauthorized
 func (c *Client) call(ctx context.Context, method string, params interface{}, dest interface{}, private bool) error {
+	err := c.doCall(ctx, method, params, dest, private)
+	if private && isUnauthorized(err) {
+		c.tm.Invalidate()
+		c.resetAuth()
+		err = c.doCall(ctx, method, params, dest, private)
+	}
+	return exchange.NewBadExResp(err)
+}
+
+func (c *Client) doCall(ctx context.Context, method string, params interface{}, dest interface{}, private bool) error {
 	if private {
+		if err := c.authenticate(ctx); err != nil {
+			return err
+		}
+
 		ac, err := c.getToken(ctx)
 		if err != nil {
 			return errors.WithMessage(err, "get access token fail")
@@ -97,8 +121,7 @@ func (c *Client) call(ctx context.Context, method string, params interface{}, de
 
 	}
 	id := atomic.AddInt64(&c.seq, 1)
-	err := c.Conn.Call(ctx, strconv.FormatInt(id, 10), method, params, dest)
-	return exchange.NewBadExResp(err)
+	return c.Conn.Call(ctx, strconv.FormatInt(id, 10), method, params, dest)
 }
 
 func newClientReq() *clientReq {
@@ -2,6 +2,9 @@ package deribit
 
 import (
 	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
 )
 
 type (
@@ -12,6 +15,28 @@ type (
 	}
 )
 
+const (
+	ErrCodeOrderNotFound     = 10004
+	ErrCodeNotEnoughFunds    = 10009
+	ErrCodeInvalidInstrument = 10020
+	ErrCodeTooManyRequests   = 10028
+	ErrCodeSystemMaintenance = 11051
+	ErrCodePostOnlyReject    = 11054
+	ErrCodeInvalidCredential = 13004
+)
+
+var (
+	codeErrors = map[int]error{
+		ErrCodeOrderNotFound:     exchange.ErrOrderNotFound,
+		ErrCodeNotEnoughFunds:    exchange.ErrInsufficientBalance,
+		ErrCodeInvalidInstrument: exchange.ErrSymbolNotFound,
+		ErrCodeTooManyRequests:   exchange.ErrRateLimited,
+		ErrCodeSystemMaintenance: exchange.ErrMaintenance,
+		ErrCodePostOnlyReject:    exchange.ErrPostOnlyRejected,
+		ErrCodeInvalidCredential: exchange.ErrInvalidSignature,
+	}
+)
+
 func NewError(code int, msg string) error {
 	return &JRPCError{
 		Code: code,
@@ -22,7 +47,17 @@ func (je *JRPCError) Error() string {
 	return fmt.Sprintf("json rpc error code: %d message: %s", je.Code, je.Msg)
 }
 
+// Is match *JRPCError and the exchange error mapped from the code
 func (js *JRPCError) Is(target error) bool {
-	_, ok := target.(*JRPCError)
-	return ok
+	if _, ok := target.(*JRPCError); ok {
+		return true
+	}
+	err, ok := codeErrors[js.Code]
+	return ok && target == err
+}
+
+// IsOrderNotFound whether the error means the order does not exist
+func IsOrderNotFound(err error) bool {
+	var je *JRPCError
+	return errors.As(err, &je) && je.Code == ErrCodeOrderNotFound
 }
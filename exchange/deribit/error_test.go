@@ -0,0 +1,30 @@
+package deribit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+type errConn struct {
+	rpc.Conn
+	err error
+}
+
+func (ec *errConn) Call(ctx context.Context, id string, method string, params interface{}, dest interface{}) error {
+	return ec.err
+}
+
+func TestCallError(t *testing.T) {
+	c := &Client{
+		WSClient: &exchange.WSClient{Conn: &errConn{err: NewError(ErrCodeOrderNotFound, "order_not_found")}},
+	}
+
+	err := c.call(context.Background(), "public/get_order_state", map[string]interface{}{}, nil, false)
+	if !errors.Is(err, &exchange.ErrBadExResp{}) || !errors.Is(err, exchange.ErrOrderNotFound) || !IsOrderNotFound(err) {
+		t.Errorf("error code should be mapped through the wrapping %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package deribit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	ChartDataResp struct {
+		Status string    `json:"status"`
+		Ticks  []int64   `json:"ticks"`
+		Open   []float64 `json:"open"`
+		High   []float64 `json:"high"`
+		Low    []float64 `json:"low"`
+		Close  []float64 `json:"close"`
+		Volume []float64 `json:"volume"`
+		Cost   []float64 `json:"cost"`
+	}
+
+	ChartDataReq struct {
+		instrument string
+		resolution string
+		start      time.Time
+		end        time.Time
+	}
+)
+
+const (
+	ChartDataEndPoint = "/public/get_tradingview_chart_data"
+
+	ChartStatusOK     = "ok"
+	ChartStatusNoData = "no_data"
+
+	// klineDefaultLimit is the count of klines fetched if start time is not given
+	klineDefaultLimit = 1000
+)
+
+var (
+	klineResolutionMap = map[exchange.KlineResolution]string{
+		exchange.KlineResolution1m:  "1",
+		exchange.KlineResolution5m:  "5",
+		exchange.KlineResolution15m: "15",
+		exchange.KlineResolution30m: "30",
+		exchange.KlineResolution1h:  "60",
+		exchange.KlineResolution1D:  "1D",
+	}
+)
+
+// NewChartDataReq build tradingview chart data request, resolution is minutes such as 1, 60 or 1D
+func NewChartDataReq(instrument string, resolution string, start, end time.Time) *ChartDataReq {
+	return &ChartDataReq{
+		instrument: instrument,
+		resolution: resolution,
+		start:      start,
+		end:        end,
+	}
+}
+
+func (rc *RestClient) ChartData(ctx context.Context, req *ChartDataReq) (*ChartDataResp, error) {
+	values := url.Values{}
+	values.Add("instrument_name", req.instrument)
+	values.Add("resolution", req.resolution)
+	values.Add("start_timestamp", strconv.FormatInt(tconv.Time2Milli(req.start), 10))
+	values.Add("end_timestamp", strconv.FormatInt(tconv.Time2Milli(req.end), 10))
+
+	var ret ChartDataResp
+	if err := rc.Request(ctx, http.MethodGet, ChartDataEndPoint, values, nil, false, &ret); err != nil {
+		return nil, errors.WithMessage(err, "fetch chart data fail")
+	}
+	return &ret, nil
+}
+
+// Klines fetch klines in ascending order, the end time default to now and limit klines
+// before end time is fetched if start time is not given
+func (rc *RestClient) Klines(ctx context.Context, kr *exchange.KlineReq) ([]exchange.Kline, error) {
+	if kr.Symbol == nil {
+		return nil, errors.Errorf("missing symbol")
+	}
+
+	resolution, ok := klineResolutionMap[kr.Resolution]
+	if !ok {
+		return nil, errors.Errorf("unsupport resolution %s", kr.Resolution)
+	}
+
+	end := kr.EndTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := kr.StartTime
+	if start.IsZero() {
+		limit := kr.Limit
+		if limit == 0 {
+			limit = klineDefaultLimit
+		}
+		start = end.Add(-time.Duration(kr.Resolution.Secs()*limit) * time.Second)
+	}
+
+	resp, err := rc.ChartData(ctx, NewChartDataReq(kr.Symbol.String(), resolution, start, end))
+	if err != nil {
+		return nil, err
+	}
+
+	klines, err := resp.Transform(kr.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if kr.Limit != 0 && len(klines) > kr.Limit {
+		klines = klines[len(klines)-kr.Limit:]
+	}
+	return klines, nil
+}
+
+// Transform chart data columns into klines
+func (cr *ChartDataResp) Transform(symbol exchange.Symbol) ([]exchange.Kline, error) {
+	if cr.Status == ChartStatusNoData {
+		return nil, nil
+	}
+
+	l := len(cr.Ticks)
+	if len(cr.Open) != l || len(cr.High) != l || len(cr.Low) != l || len(cr.Close) != l || len(cr.Volume) != l {
+		return nil, errors.Errorf("chart data length mismatch")
+	}
+
+	ret := make([]exchange.Kline, l)
+	for i := range cr.Ticks {
+		ret[i] = exchange.Kline{
+			Symbol: symbol,
+			Open:   cr.Open[i],
+			Close:  cr.Close[i],
+			High:   cr.High[i],
+			Low:    cr.Low[i],
+			Volume: cr.Volume[i],
+			Time:   tconv.Milli2Time(cr.Ticks[i]),
+			Raw:    cr,
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,46 @@
+package deribit
+
+import (
+	"time"
+
+	"github.com/szmcdull/ccexgo/misc/request"
+)
+
+const (
+	RuleNonMatching = "nonMatching"
+	RuleMatching    = "matching"
+)
+
+var (
+	// matchingMethods requests handled by the matching engine which have separate limit
+	matchingMethods = []string{
+		"/private/buy",
+		"/private/sell",
+		"/private/edit",
+		"/private/cancel",
+		"/private/cancel_all",
+		"/private/cancel_all_by_currency",
+		"/private/cancel_all_by_instrument",
+		"/private/cancel_by_label",
+		"/private/close_position",
+	}
+)
+
+// NewLimiter limiter of the credit system with the sustained rate, matching engine
+// requests are 5 per second and others are 20 per second for each account
+func NewLimiter() *request.Limiter {
+	ret := request.NewLimiter(
+		request.Rule{Name: RuleNonMatching, Limit: 20, Interval: time.Second},
+		request.Rule{Name: RuleMatching, Limit: 5, Interval: time.Second},
+	).Default(request.Cost{RuleNonMatching: 1})
+
+	for _, m := range matchingMethods {
+		ret.Endpoint("", m, request.Cost{RuleMatching: 1})
+	}
+	return ret
+}
+
+// SetLimiter replace the limiter, nil disables rate limiting
+func (rc *RestClient) SetLimiter(l *request.Limiter) {
+	rc.limiter = l
+}
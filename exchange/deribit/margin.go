@@ -0,0 +1,114 @@
+package deribit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	ChangeMarginModelReq struct {
+		AuthToken
+		UserID      int    `json:"user_id"`
+		MarginModel string `json:"margin_model"`
+		DryRun      bool   `json:"dry_run,omitempty"`
+	}
+)
+
+const (
+	PrivateChangeMarginModel = "private/change_margin_model"
+
+	MarginModelCrossPM      = "cross_pm"
+	MarginModelCrossSM      = "cross_sm"
+	MarginModelSegregatedPM = "segregated_pm"
+	MarginModelSegregatedSM = "segregated_sm"
+
+	marginModelSegregated = "segregated"
+	marginModelCross      = "cross"
+)
+
+func NewChangeMarginModelReq(userID int, model string) *ChangeMarginModelReq {
+	return &ChangeMarginModelReq{
+		UserID:      userID,
+		MarginModel: model,
+	}
+}
+
+func (cr *ChangeMarginModelReq) SetDryRun(dryRun bool) *ChangeMarginModelReq {
+	cr.DryRun = dryRun
+	return cr
+}
+
+func (c *Client) ChangeMarginModel(ctx context.Context, req *ChangeMarginModelReq) error {
+	var ret interface{}
+	if err := c.call(ctx, PrivateChangeMarginModel, req, &ret, true); err != nil {
+		return errors.WithMessage(err, "change margin model fail")
+	}
+	return nil
+}
+
+// SetLeverage deribit has no leverage setting, the leverage is decided by the margin
+func (c *Client) SetLeverage(ctx context.Context, sym exchange.Symbol, leverage decimal.Decimal) error {
+	return exchange.NewBadArg("leverage is not supported", leverage)
+}
+
+func (c *Client) GetLeverage(ctx context.Context, sym exchange.Symbol) (decimal.Decimal, error) {
+	return decimal.Zero, exchange.NewBadArg("leverage is not supported", sym.String())
+}
+
+// SetMarginMode change the account margin model, segregated model is taken as fixed
+// margin. the standard or portfolio margin of the account is kept
+func (c *Client) SetMarginMode(ctx context.Context, sym exchange.Symbol, mode exchange.PositionMode) error {
+	var prefix string
+	switch mode {
+	case exchange.PositionModeFixed:
+		prefix = marginModelSegregated
+
+	case exchange.PositionModeCross:
+		prefix = marginModelCross
+
+	default:
+		return exchange.NewBadArg("unknown margin mode", mode)
+	}
+
+	summary, err := c.AccountSummary(ctx, settleCurrency(sym.String()))
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(summary.MarginModel, prefix) {
+		return nil
+	}
+
+	suffix := "_sm"
+	if strings.HasSuffix(summary.MarginModel, "_pm") {
+		suffix = "_pm"
+	}
+	return c.ChangeMarginModel(ctx, NewChangeMarginModelReq(summary.ID, prefix+suffix))
+}
+
+func (c *Client) GetMarginMode(ctx context.Context, sym exchange.Symbol) (exchange.PositionMode, error) {
+	summary, err := c.AccountSummary(ctx, settleCurrency(sym.String()))
+	if err != nil {
+		return exchange.PositionModeCross, err
+	}
+	if strings.HasPrefix(summary.MarginModel, marginModelSegregated) {
+		return exchange.PositionModeFixed, nil
+	}
+	return exchange.PositionModeCross, nil
+}
+
+// SetPositionMode deribit only support one way position mode
+func (c *Client) SetPositionMode(ctx context.Context, hedge bool) error {
+	if hedge {
+		return exchange.NewBadArg("hedge position mode is not supported", hedge)
+	}
+	return nil
+}
+
+func (c *Client) GetPositionMode(ctx context.Context) (bool, error) {
+	return false, nil
+}
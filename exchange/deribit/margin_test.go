@@ -0,0 +1,23 @@
+package deribit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+var _ exchange.MarginManager = (*Client)(nil)
+
+func TestPositionMode(t *testing.T) {
+	c := &Client{}
+	ctx := context.Background()
+
+	if err := c.SetPositionMode(ctx, true); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("hedge mode should not be supported %v", err)
+	}
+	if hedge, err := c.GetPositionMode(ctx); err != nil || hedge {
+		t.Errorf("bad position mode")
+	}
+}
@@ -3,6 +3,7 @@ package deribit
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/shopspring/decimal"
@@ -19,6 +20,8 @@ type (
 		Type           string  `json:"type"`
 		PostOnly       bool    `json:"post_only,omitempty"`
 		TimeInForce    string  `json:"time_in_force,omitempty"`
+		ReduceOnly     bool    `json:"reduce_only,omitempty"`
+		Label          string  `json:"label,omitempty"`
 	}
 
 	orderResult struct {
@@ -37,6 +40,9 @@ type (
 		Direction            string          `json:"direction"`
 		FilledAmont          decimal.Decimal `json:"filled_amount"`
 		InstrumentName       string          `json:"instrument_name"`
+		OrderType            string          `json:"order_type"`
+		Label                string          `json:"label"`
+		ReduceOnly           bool            `json:"reduce_only"`
 	}
 
 	OpenOrdersByCurrencyRequest struct {
@@ -49,6 +55,10 @@ type (
 
 const (
 	PrivateGetOpenOrdersByCurrency = "/private/get_open_orders_by_currency"
+	PrivateGetOrderStateByLabel    = "/private/get_order_state_by_label"
+
+	// ClientIDMaxLen max length of order label
+	ClientIDMaxLen = 64
 )
 
 var (
@@ -107,8 +117,14 @@ func (obr *OpenOrdersByCurrencyRequest) MarshalJSON() ([]byte, error) {
 }
 
 func (c *Client) CreateOrder(ctx context.Context, req *exchange.OrderRequest, opts ...exchange.OrderReqOption) (*exchange.Order, error) {
+	// deribit position is one way, closing order is sent as reduce only
+	ts, err := exchange.NewTradeSide(req.Side, false)
+	if err != nil {
+		return nil, err
+	}
+
 	var method string
-	if req.Side == exchange.OrderSideBuy {
+	if ts.IsBuy() {
 		method = "/private/buy"
 	} else {
 		method = "/private/sell"
@@ -119,6 +135,10 @@ func (c *Client) CreateOrder(ctx context.Context, req *exchange.OrderRequest, op
 		Amount:         a,
 		InstrumentName: req.Symbol.String(),
 		Type:           type2Str[req.Type],
+		ReduceOnly:     ts.ReduceOnly(),
+	}
+	if req.ClientID != nil {
+		param.Label = req.ClientID.String()
 	}
 
 	if req.Type == exchange.OrderTypeLimit || req.Type == exchange.OrderTypeStopLimit {
@@ -151,7 +171,15 @@ func (c *Client) CreateOrder(ctx context.Context, req *exchange.OrderRequest, op
 	return or.Order.transform()
 }
 
+// FetchOrder fetch order by ID, the label is used if ID is not set
 func (c *Client) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	if order.ID == nil {
+		if order.ClientID == nil {
+			return nil, errors.Errorf("order id or label is required")
+		}
+		return c.FetchOrderByLabel(ctx, order.Symbol, order.ClientID.String())
+	}
+
 	param := map[string]interface{}{
 		"order_id": order.ID,
 	}
@@ -163,6 +191,34 @@ func (c *Client) FetchOrder(ctx context.Context, order *exchange.Order) (*exchan
 	return r.transform()
 }
 
+// FetchOrderByLabel fetch the latest order with the label, error with code
+// ErrCodeOrderNotFound is returned if not exists
+func (c *Client) FetchOrderByLabel(ctx context.Context, sym exchange.Symbol, label string) (*exchange.Order, error) {
+	param := map[string]interface{}{
+		"currency": labelCurrency(sym),
+		"label":    label,
+	}
+
+	var r []Order
+	if err := c.call(ctx, PrivateGetOrderStateByLabel, param, &r, true); err != nil {
+		return nil, err
+	}
+
+	var ret *Order
+	for i := range r {
+		if r[i].InstrumentName != sym.String() {
+			continue
+		}
+		if ret == nil || r[i].CreationTimestamp > ret.CreationTimestamp {
+			ret = &r[i]
+		}
+	}
+	if ret == nil {
+		return nil, NewError(ErrCodeOrderNotFound, "order_not_found")
+	}
+	return ret.transform()
+}
+
 func (c *Client) CancelOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
 	param := map[string]interface{}{
 		"order_id": order.ID,
@@ -188,17 +244,29 @@ func (c *Client) OpenOrdersByCurrency(ctx context.Context, req *OpenOrdersByCurr
 func (order *Order) transform() (*exchange.Order, error) {
 	create := tconv.Milli2Time(order.CreationTimestamp)
 	update := tconv.Milli2Time(order.LastUpdatedTimestamp)
-	sym, err := ParseOptionSymbol(order.InstrumentName)
+	sym, err := ParseSymbol(order.InstrumentName)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "parse symbol %s fail", order.InstrumentName)
 	}
+
+	var typ exchange.OrderType
+	for k, v := range type2Str {
+		if v == order.OrderType {
+			typ = k
+			break
+		}
+	}
+
+	side := exchange.ParseTradeSide(order.Direction == "buy", false, exchange.PositionSideLong, order.ReduceOnly).OrderSide()
 	return &exchange.Order{
 		ID:       NewOrderID(order.OrderID),
+		ClientID: NewOrderID(order.Label),
+		Type:     typ,
 		Amount:   order.Amount,
 		Price:    order.Price,
 		AvgPrice: order.AveragePrice,
 		Status:   statusMap[order.OrderState],
-		Side:     directionMap[order.Direction],
+		Side:     side,
 		Created:  create,
 		Updated:  update,
 		Symbol:   sym,
@@ -207,6 +275,21 @@ func (order *Order) transform() (*exchange.Order, error) {
 	}, nil
 }
 
+// NewClientIDGenerator generate order label with the prefix
+func NewClientIDGenerator(prefix string) *exchange.ClientIDGenerator {
+	return exchange.NewClientIDGenerator(prefix, ClientIDMaxLen, false)
+}
+
+// labelCurrency currency of the instrument used by label query, which is the
+// settlement currency of linear instruments like BTC_USDC-PERPETUAL
+func labelCurrency(sym exchange.Symbol) string {
+	name := strings.SplitN(sym.String(), "-", 2)[0]
+	if i := strings.Index(name, "_"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
 func NewOrderID(id string) OrderID {
 	return OrderID(id)
 }
@@ -49,6 +49,8 @@ type (
 const (
 	PrivateGetPosition  = "private/get_position"
 	PrivateGetPositions = "private/get_positions"
+
+	DirectionZero = "zero"
 )
 
 func NewPositionsRequest(currency string, kind string) *PositionsRequest {
@@ -70,22 +72,33 @@ func (pr *PositionResult) Transfer() (*exchange.Position, error) {
 		return nil, errors.WithMessage(err, "parse symbol fail")
 	}
 
-	direction, ok := directionMap[pr.Direction]
-	if !ok {
-		return nil, errors.Errorf("unknown direction='%s'", pr.Direction)
-	}
 	var side exchange.PositionSide
-	if direction == exchange.OrderSideBuy {
+	if pr.Direction == DirectionZero {
+		//closed position pushed by user.changes
 		side = exchange.PositionSideLong
 	} else {
-		side = exchange.PositionSideShort
+		direction, ok := directionMap[pr.Direction]
+		if !ok {
+			return nil, errors.Errorf("unknown direction='%s'", pr.Direction)
+		}
+		if direction == exchange.OrderSideBuy {
+			side = exchange.PositionSideLong
+		} else {
+			side = exchange.PositionSideShort
+		}
 	}
 
 	return &exchange.Position{
-		Symbol:       symbol,
-		Side:         side,
-		AvgOpenPrice: pr.AveragePrice,
-		Position:     pr.Size.Abs(), //deribit short position amount is negative
-		RealizedPNL:  pr.RealizedProfitLoss,
+		Symbol:           symbol,
+		Side:             side,
+		Mode:             exchange.PositionModeCross,
+		LiquidationPrice: pr.EstimatedLiquidationPrice,
+		AvgOpenPrice:     pr.AveragePrice,
+		Margin:           pr.InitialMargin,
+		Position:         pr.Size.Abs(), //deribit short position amount is negative
+		RealizedPNL:      pr.RealizedProfitLoss,
+		UNRealizedPNL:    pr.FloatingProfitLoss,
+		Leverage:         decimal.NewFromInt(int64(pr.Leverage)),
+		Raw:              pr,
 	}, nil
 }
@@ -10,13 +10,14 @@ import (
 	"net/url"
 
 	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/misc/request"
 )
 
 type (
 	RestClient struct {
-		key    string
-		secret string
-		prefix string
+		tm      *TokenManager
+		prefix  string
+		limiter *request.Limiter
 	}
 )
 
@@ -29,18 +30,59 @@ func NewTestRestClient(key, secret string) *RestClient {
 }
 
 func newRestClientWithPrefix(key, secret, prefix string) *RestClient {
-	return &RestClient{
-		key:    key,
-		secret: secret,
-		prefix: prefix,
+	ret := &RestClient{
+		prefix:  prefix,
+		limiter: NewLimiter(),
 	}
+	ret.tm = NewTokenManager(ret, key, secret)
+	return ret
 }
 
+// SetTokenManager replace the token manager, which make it possible to share token with Client
+func (rc *RestClient) SetTokenManager(tm *TokenManager) {
+	rc.tm = tm
+}
+
+func (rc *RestClient) TokenManager() *TokenManager {
+	return rc.tm
+}
+
+// Authenticate implement Authenticator by rest public/auth
+func (rc *RestClient) Authenticate(ctx context.Context, param *AuthParam) (*AuthResult, error) {
+	values := url.Values{}
+	values.Add("grant_type", param.GrantType)
+	if param.ClientID != "" {
+		values.Add("client_id", param.ClientID)
+	}
+	if param.ClientSecret != "" {
+		values.Add("client_secret", param.ClientSecret)
+	}
+	if param.RefreshToken != "" {
+		values.Add("refresh_token", param.RefreshToken)
+	}
+	if param.Scope != "" {
+		values.Add("scope", param.Scope)
+	}
+
+	var r AuthResult
+	if err := rc.Request(ctx, http.MethodGet, "/"+PublicAuthMethod, values, nil, false, &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// Request do the rest request, signed request is authorized by the access token of the
+// token manager, and the token is dropped and login again if the server reply unauthorized
 func (rc *RestClient) Request(ctx context.Context, method string, endPoint string, params url.Values, body io.Reader, signed bool, dst interface{}) error {
-	if signed {
-		return errors.Errorf("signed rest request is not support yet")
+	err := rc.request(ctx, method, endPoint, params, body, signed, dst)
+	if signed && body == nil && isUnauthorized(err) {
+		rc.tm.Invalidate()
+		err = rc.request(ctx, method, endPoint, params, body, signed, dst)
 	}
+	return err
+}
 
+func (rc *RestClient) request(ctx context.Context, method string, endPoint string, params url.Values, body io.Reader, signed bool, dst interface{}) error {
 	url := fmt.Sprintf("%s%s", rc.prefix, endPoint)
 	if len(params) != 0 {
 		url = fmt.Sprintf("%s?%s", url, params.Encode())
@@ -50,10 +92,23 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 		return errors.WithMessage(err, "build request fail")
 	}
 
+	if signed {
+		token, err := rc.tm.Token(ctx)
+		if err != nil {
+			return errors.WithMessage(err, "get access token fail")
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("bearer %s", token))
+	}
+
+	if err := rc.limiter.Wait(ctx, method, endPoint); err != nil {
+		return err
+	}
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return errors.WithMessage(err, "do http request fail")
 	}
+	rc.limiter.Update(resp)
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
@@ -61,16 +116,18 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return errors.Errorf("invalid statusCode %d status %s", resp.StatusCode, resp.Status)
-	}
-
 	var r Response
 	if err := json.Unmarshal(data, &r); err != nil {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return errors.Errorf("invalid statusCode %d status %s", resp.StatusCode, resp.Status)
+		}
 		return errors.WithMessage(err, "unmarshal json error")
 	}
 	if r.Error.Code != 0 {
-		return errors.Errorf("response error code: %d message: %s", r.Error.Code, r.Error.Message)
+		return errors.WithMessage(NewError(r.Error.Code, r.Error.Message), "response error")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("invalid statusCode %d status %s", resp.StatusCode, resp.Status)
 	}
 
 	if err := json.Unmarshal(r.Result, &dst); err != nil {
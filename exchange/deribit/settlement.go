@@ -1,6 +1,16 @@
 package deribit
 
-import "github.com/shopspring/decimal"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
 
 type (
 	SettlementType string
@@ -18,6 +28,12 @@ type (
 		MarkPrice         decimal.Decimal `json:"mark_price"`
 		InstrumentName    string          `json:"instrument_name"`
 		IndexPrice        decimal.Decimal `json:"index_price"`
+		Funding           decimal.Decimal `json:"funding"`
+		Funded            decimal.Decimal `json:"funded"`
+		SessionBankruptcy decimal.Decimal `json:"session_bankruptcy"`
+		SessionTax        decimal.Decimal `json:"session_tax"`
+		SessionTaxRate    decimal.Decimal `json:"session_tax_rate"`
+		Socialized        decimal.Decimal `json:"socialized"`
 	}
 
 	SettlementReq struct {
@@ -30,13 +46,238 @@ type (
 		InstrumentName string         `json:"instrument_name"`
 		Type           SettlementType `json:"type"`
 	}
+
+	// SettlementHistoryReq request of settlement history by currency or instrument
+	SettlementHistoryReq struct {
+		AuthToken
+		*clientReq
+		method   string
+		currency string
+		since    time.Time
+		until    time.Time
+		limit    int
+	}
 )
 
 const (
 	SettlementMethodByInstrument       = "private/get_settlement_history_by_instrument"
+	SettlementMethodByCurrency         = "private/get_settlement_history_by_currency"
 	PublicSettlementMethodByInstrument = "public/get_last_settlements_by_instrument"
 
 	SettlementTypeSettlement SettlementType = "settlement"
 	SettlementTypeDelivery   SettlementType = "delivery"
 	SettlementTypeBankrupcty SettlementType = "bankruptcy"
+
+	// continuationNone is returned when there is no more page
+	continuationNone = "none"
+
+	// maxPageCount is the max count of a page
+	maxPageCount = 1000
 )
+
+// NewSettlementHistoryByCurrencyReq settlement history of all instruments of the currency
+func NewSettlementHistoryByCurrencyReq(currency string) *SettlementHistoryReq {
+	cr := newClientReq()
+	cr.addField("currency", currency)
+
+	return &SettlementHistoryReq{
+		clientReq: cr,
+		method:    SettlementMethodByCurrency,
+		currency:  strings.ToUpper(currency),
+	}
+}
+
+// NewSettlementHistoryByInstrumentReq settlement history of the instrument
+func NewSettlementHistoryByInstrumentReq(instrument string) *SettlementHistoryReq {
+	cr := newClientReq()
+	cr.addField("instrument_name", instrument)
+
+	return &SettlementHistoryReq{
+		clientReq: cr,
+		method:    SettlementMethodByInstrument,
+		currency:  settleCurrency(instrument),
+	}
+}
+
+func (sr *SettlementHistoryReq) Type(typ SettlementType) *SettlementHistoryReq {
+	sr.addField("type", typ)
+	return sr
+}
+
+// Count specific the page size
+func (sr *SettlementHistoryReq) Count(ct int) *SettlementHistoryReq {
+	sr.addField("count", ct)
+	return sr
+}
+
+// Since specific the earliest time of the settlements, paging stop once it is reached
+func (sr *SettlementHistoryReq) Since(since time.Time) *SettlementHistoryReq {
+	sr.since = since
+	return sr
+}
+
+// Until specific the latest time of the settlements, later settlements are skipped
+func (sr *SettlementHistoryReq) Until(until time.Time) *SettlementHistoryReq {
+	sr.until = until
+	return sr
+}
+
+// Limit specific the max number of settlements returned by SettlementHistory, which
+// may take several pages
+func (sr *SettlementHistoryReq) Limit(limit int) *SettlementHistoryReq {
+	sr.limit = limit
+	return sr
+}
+
+func (sr *SettlementHistoryReq) continuation(c string) {
+	sr.addField("continuation", c)
+}
+
+func (sr *SettlementHistoryReq) MarshalJSON() ([]byte, error) {
+	sr.addField("access_token", sr.AuthToken.AccessToken)
+	return sr.clientReq.MarshalJSON()
+}
+
+// SettlementHistory fetch settlements from the latest and follow the continuation
+// until all pages, the since time or the limit are reached
+func (c *Client) SettlementHistory(ctx context.Context, req *SettlementHistoryReq) ([]Settlement, error) {
+	var ret []Settlement
+	for {
+		var resp SettlementResp
+		if err := c.call(ctx, req.method, req, &resp, true); err != nil {
+			return nil, errors.WithMessage(err, "fetch settlement history fail")
+		}
+
+		reached := false
+		for _, s := range resp.Settlements {
+			ts := tconv.Milli2Time(s.Timestamp)
+			if !req.since.IsZero() && ts.Before(req.since) {
+				reached = true
+				continue
+			}
+			if !req.until.IsZero() && ts.After(req.until) {
+				continue
+			}
+			ret = append(ret, s)
+			if req.limit != 0 && len(ret) == req.limit {
+				return ret, nil
+			}
+		}
+
+		if reached || len(resp.Settlements) == 0 ||
+			resp.Continuation == "" || resp.Continuation == continuationNone {
+			return ret, nil
+		}
+		req.continuation(resp.Continuation)
+	}
+}
+
+// Finance fetch settlement history of the symbol. FinanceTypeSettlement and FinanceTypeDelivery
+// return session pnl settled into balance, FinanceTypeOther return the socialized loss of bankruptcy
+// and FinanceTypeFunding return the funding of perpetual from the transaction log
+func (c *Client) Finance(ctx context.Context, params *exchange.FinanceReqParam) ([]exchange.Finance, error) {
+	if params.Symbol == nil {
+		return nil, errors.Errorf("symbol is required")
+	}
+
+	var req *SettlementHistoryReq
+	switch params.Type {
+	case exchange.FinanceTypeFunding:
+		return c.funding(ctx, params)
+
+	case exchange.FinanceTypeSettlement:
+		req = NewSettlementHistoryByInstrumentReq(params.Symbol.String()).Type(SettlementTypeSettlement)
+
+	case exchange.FinanceTypeDelivery:
+		req = NewSettlementHistoryByInstrumentReq(params.Symbol.String()).Type(SettlementTypeDelivery)
+
+	case exchange.FinanceTypeOther:
+		// bankruptcy is not bound to instrument
+		req = NewSettlementHistoryByCurrencyReq(settleCurrency(params.Symbol.String())).Type(SettlementTypeBankrupcty)
+
+	default:
+		return nil, errors.Errorf("unsupport type '%d'", params.Type)
+	}
+
+	if params.Limit != 0 {
+		req.Limit(params.Limit).Count(pageCount(params.Limit))
+	}
+	req.Since(params.StartTime).Until(params.EndTime)
+
+	settlements, err := c.SettlementHistory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []exchange.Finance
+	for i := range settlements {
+		s := &settlements[i]
+		f, err := s.Transform(req.currency)
+		if err != nil {
+			return nil, errors.WithMessage(err, "parse settlement fail")
+		}
+		ret = append(ret, *f)
+	}
+	return ret, nil
+}
+
+// Transform settlement into exchange.Finance, the currency is not returned by deribit
+// so it is given by the caller
+func (s *Settlement) Transform(currency string) (*exchange.Finance, error) {
+	var (
+		typ    exchange.FinanceType
+		amount decimal.Decimal
+		sym    exchange.Symbol
+	)
+
+	switch SettlementType(s.Type) {
+	case SettlementTypeSettlement:
+		typ = exchange.FinanceTypeSettlement
+		amount = s.SessionProfitLoss
+
+	case SettlementTypeDelivery:
+		typ = exchange.FinanceTypeDelivery
+		amount = s.SessionProfitLoss
+
+	case SettlementTypeBankrupcty:
+		typ = exchange.FinanceTypeOther
+		amount = s.Socialized
+
+	default:
+		return nil, errors.Errorf("unknown settlement type '%s'", s.Type)
+	}
+
+	if s.InstrumentName != "" {
+		var err error
+		sym, err = ParseSymbol(s.InstrumentName)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parse symbol %s fail", s.InstrumentName)
+		}
+	}
+
+	return &exchange.Finance{
+		ID:       fmt.Sprintf("%s-%s-%d", s.Type, s.InstrumentName, s.Timestamp),
+		Time:     tconv.Milli2Time(s.Timestamp),
+		Amount:   amount,
+		Currency: currency,
+		Type:     typ,
+		Symbol:   sym,
+		Raw:      s,
+	}, nil
+}
+
+// pageCount return page size for the limit
+func pageCount(limit int) int {
+	if limit > maxPageCount {
+		return maxPageCount
+	}
+	return limit
+}
+
+// settleCurrency return the settlement currency of the instrument, BTC for BTC-PERPETUAL
+// and USDC for BTC_USDC-PERPETUAL
+func settleCurrency(instrument string) string {
+	index := strings.SplitN(instrument, "-", 2)[0]
+	fields := strings.Split(index, "_")
+	return strings.ToUpper(fields[len(fields)-1])
+}
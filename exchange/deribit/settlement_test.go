@@ -0,0 +1,109 @@
+package deribit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func TestSettlementTransform(t *testing.T) {
+	initTestSwapSymbol(t)
+
+	var resp SettlementResp
+	if err := json.Unmarshal([]byte(`{
+		"settlements": [{
+			"type": "settlement",
+			"timestamp": 1550475692526,
+			"session_profit_loss": 0.038358299,
+			"profit_loss": -0.001783937,
+			"position": -66,
+			"mark_price": 121.67,
+			"instrument_name": "BTC-PERPETUAL",
+			"index_price": 119.8
+		}, {
+			"type": "bankruptcy",
+			"timestamp": 1550475692526,
+			"socialized": -0.001,
+			"session_bankruptcy": 0.001
+		}],
+		"continuation": "xY7T6cutS3t2B9YtaDkE6TS379oKnkzTvmEDUnEUP2Msa9xKWNNaT"
+	}`), &resp); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	f, err := resp.Settlements[0].Transform("BTC")
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+	if f.Type != exchange.FinanceTypeSettlement || f.Symbol.String() != "BTC-PERPETUAL" ||
+		!f.Amount.Equal(decimal.RequireFromString("0.038358299")) || f.Currency != "BTC" {
+		t.Errorf("bad finance %+v", *f)
+	}
+
+	f, err = resp.Settlements[1].Transform("BTC")
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+	if f.Type != exchange.FinanceTypeOther || f.Symbol != nil || !f.Amount.Equal(decimal.RequireFromString("-0.001")) {
+		t.Errorf("bad finance %+v", *f)
+	}
+
+	for instrument, currency := range map[string]string{
+		"BTC-PERPETUAL":      "BTC",
+		"ETH-29MAR24-3000-C": "ETH",
+		"BTC_USDC-PERPETUAL": "USDC",
+	} {
+		if c := settleCurrency(instrument); c != currency {
+			t.Errorf("bad settle currency %s for %s", c, instrument)
+		}
+	}
+}
+
+func TestTransactionLogTransform(t *testing.T) {
+	initTestSwapSymbol(t)
+
+	var resp TransactionLogResp
+	if err := json.Unmarshal([]byte(`{
+		"logs": [{
+			"id": 61,
+			"type": "settlement",
+			"timestamp": 1613659830333,
+			"instrument_name": "BTC-PERPETUAL",
+			"currency": "BTC",
+			"interest_pl": -0.00001,
+			"session_rpl": 0.0001,
+			"cashflow": 0.00009,
+			"change": 0.00009,
+			"balance": 1.1
+		}, {
+			"id": 62,
+			"type": "trade",
+			"timestamp": 1613659830334,
+			"instrument_name": "BTC-PERPETUAL",
+			"currency": "BTC",
+			"cashflow": -0.0001
+		}],
+		"continuation": null
+	}`), &resp); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	if resp.Continuation != nil || !resp.Logs[0].IsFunding() || resp.Logs[1].IsFunding() {
+		t.Fatalf("bad logs %+v", resp)
+	}
+
+	f, err := resp.Logs[0].Transform()
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+	if f.Type != exchange.FinanceTypeFunding || f.ID != "61" || f.Currency != "BTC" ||
+		!f.Amount.Equal(decimal.RequireFromString("-0.00001")) || f.Symbol.String() != "BTC-PERPETUAL" {
+		t.Errorf("bad finance %+v", *f)
+	}
+
+	if _, err := resp.Logs[1].Transform(); err == nil {
+		t.Errorf("error expected for trade log")
+	}
+}
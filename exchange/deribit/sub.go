@@ -32,15 +32,23 @@ func (c *Client) UnSubscribe(ctx context.Context, chs ...exchange.Channel) error
 
 func (c *Client) subInternal(ctx context.Context, op string, chs ...exchange.Channel) error {
 	channels := []string{}
+	private := false
 	for _, c := range chs {
 		channels = append(channels, c.String())
+		if isPrivateChannel(c.String()) {
+			private = true
+		}
 	}
 
+	//private channels must be subscribed with access_token by private/subscribe
 	var result []string
 	method := fmt.Sprintf("public/%s", op)
+	if private {
+		method = fmt.Sprintf("private/%s", op)
+	}
 	if err := c.call(ctx, method, map[string]interface{}{
 		"channels": channels,
-	}, &result, false); err != nil {
+	}, &result, private); err != nil {
 		return err
 	}
 
@@ -59,3 +67,7 @@ func (c *Client) subInternal(ctx context.Context, op string, chs ...exchange.Cha
 	}
 	return nil
 }
+
+func isPrivateChannel(ch string) bool {
+	return strings.HasPrefix(ch, userChannelPrefix+".")
+}
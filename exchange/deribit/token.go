@@ -0,0 +1,208 @@
+package deribit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Authenticator perform public/auth through websocket or rest
+	Authenticator interface {
+		Authenticate(ctx context.Context, param *AuthParam) (*AuthResult, error)
+	}
+
+	// TokenManager keep the access token, which can be shared by Client and RestClient.
+	// grants are serialized by grantMu so concurrent callers share one refresh
+	TokenManager struct {
+		grantMu      sync.Mutex
+		mu           sync.Mutex
+		gen          int64 //increased on every grant
+		auth         Authenticator
+		key          string
+		secret       string
+		scopes       []string
+		accessToken  string
+		refreshToken string
+		scope        string
+		expire       time.Time
+		refreshAt    time.Time
+	}
+)
+
+const (
+	// ErrCodeUnauthorized is returned when the access token is invalid or expired
+	ErrCodeUnauthorized = 13009
+
+	ScopeTradeRead      = "trade:read"
+	ScopeTradeReadWrite = "trade:read_write"
+	ScopeWalletRead     = "wallet:read"
+	ScopeAccountRead    = "account:read"
+)
+
+// SessionScope return session:name scope, which make the token bound to the named session
+func SessionScope(name string) string {
+	return fmt.Sprintf("session:%s", name)
+}
+
+// NewTokenManager create token manager which authenticate by auth, scopes such as
+// trade:read or session:name is requested on client_credentials grant
+func NewTokenManager(auth Authenticator, key, secret string, scopes ...string) *TokenManager {
+	return &TokenManager{
+		auth:   auth,
+		key:    key,
+		secret: secret,
+		scopes: scopes,
+	}
+}
+
+// Token return a valid access token, the token is renewed once it reaches refreshAt by
+// refresh_token and client_credentials grant is used if there is no refresh_token or the
+// refresh fail. the still valid token is returned if the renew ahead of expiry fail
+func (tm *TokenManager) Token(ctx context.Context) (string, error) {
+	if token, valid, refresh := tm.tokenState(time.Now()); valid && !refresh {
+		return token, nil
+	}
+
+	tm.grantMu.Lock()
+	defer tm.grantMu.Unlock()
+	// the token may be renewed while waiting for the lock
+	token, valid, refresh := tm.tokenState(time.Now())
+	if valid && !refresh {
+		return token, nil
+	}
+
+	if _, err := tm.renew(ctx, tm.auth); err != nil {
+		if token, valid, _ := tm.tokenState(time.Now()); valid {
+			return token, nil
+		}
+		return "", err
+	}
+	return tm.AccessToken(), nil
+}
+
+// Refresh refresh the token with refresh_token ahead of expiry, fallback to login.
+// it returns once the refresh done by another caller if called concurrently
+func (tm *TokenManager) Refresh(ctx context.Context) error {
+	gen := tm.generation()
+	tm.grantMu.Lock()
+	defer tm.grantMu.Unlock()
+	if tm.generation() != gen {
+		return nil
+	}
+
+	_, err := tm.renew(ctx, tm.auth)
+	return err
+}
+
+// AuthenticateWith refresh or login through auth instead of the Authenticator of the
+// manager, it's used by Client to authenticate its websocket connection with a shared
+// manager. the result is stored so the token is still shared
+func (tm *TokenManager) AuthenticateWith(ctx context.Context, auth Authenticator) (*AuthResult, error) {
+	tm.grantMu.Lock()
+	defer tm.grantMu.Unlock()
+	return tm.renew(ctx, auth)
+}
+
+// NeedRefresh whether the token should be refreshed, it's false if not authenticated yet
+func (tm *TokenManager) NeedRefresh(now time.Time) bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.accessToken != "" && !now.Before(tm.refreshAt)
+}
+
+// Invalidate drop the token and the next Token call will login again
+func (tm *TokenManager) Invalidate() {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.accessToken = ""
+	tm.refreshToken = ""
+	tm.scope = ""
+	tm.expire = time.Time{}
+	tm.refreshAt = time.Time{}
+}
+
+func (tm *TokenManager) AccessToken() string {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.accessToken
+}
+
+// Scope return scope granted by the server
+func (tm *TokenManager) Scope() string {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.scope
+}
+
+// tokenState return the access token, whether it's not expired and whether it should
+// be renewed at now
+func (tm *TokenManager) tokenState(now time.Time) (token string, valid bool, refresh bool) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	valid = tm.accessToken != "" && tm.expire.After(now)
+	return tm.accessToken, valid, !now.Before(tm.refreshAt)
+}
+
+func (tm *TokenManager) generation() int64 {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return tm.gen
+}
+
+// renew refresh the token by refresh_token and login if there is no refresh_token
+// or the refresh fail, grantMu must be held
+func (tm *TokenManager) renew(ctx context.Context, auth Authenticator) (*AuthResult, error) {
+	tm.mu.Lock()
+	refresh := tm.refreshToken
+	tm.mu.Unlock()
+
+	if refresh != "" {
+		if r, err := tm.grant(ctx, auth, &AuthParam{
+			GrantType:    GrantTypeRefreshToken,
+			RefreshToken: refresh,
+		}); err == nil {
+			return r, nil
+		}
+	}
+
+	return tm.grant(ctx, auth, &AuthParam{
+		GrantType:    GrantTypeClientCredentials,
+		ClientID:     tm.key,
+		ClientSecret: tm.secret,
+		Scope:        strings.Join(tm.scopes, " "),
+	})
+}
+
+func (tm *TokenManager) grant(ctx context.Context, auth Authenticator, param *AuthParam) (*AuthResult, error) {
+	now := time.Now()
+	r, err := auth.Authenticate(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.gen++
+	tm.accessToken = r.AccessToken
+	tm.refreshToken = r.RefreshToken
+	tm.scope = r.Scope
+	tm.expire = now.Add(time.Duration(r.ExpiresIn-1) * time.Second)
+	tm.refreshAt = now.Add(refreshAfter(r.ExpiresIn))
+	return r, nil
+}
+
+// refreshAfter return the duration after which the token should be refreshed, which
+// is 80% of expires_in
+func refreshAfter(expiresIn int) time.Duration {
+	return time.Duration(expiresIn) * time.Second * 4 / 5
+}
+
+func isUnauthorized(err error) bool {
+	var je *JRPCError
+	return errors.As(err, &je) && je.Code == ErrCodeUnauthorized
+}
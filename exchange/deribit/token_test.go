@@ -0,0 +1,143 @@
+package deribit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type testAuthenticator struct {
+	params      []AuthParam
+	expiresIn   int
+	failRefresh bool
+	fail        bool
+}
+
+func (ta *testAuthenticator) Authenticate(ctx context.Context, param *AuthParam) (*AuthResult, error) {
+	ta.params = append(ta.params, *param)
+	if ta.fail {
+		return nil, errors.New("auth fail")
+	}
+	if ta.failRefresh && param.GrantType == GrantTypeRefreshToken {
+		return nil, errors.New("refresh fail")
+	}
+	n := len(ta.params)
+	return &AuthResult{
+		AccessToken:  fmt.Sprintf("access%d", n),
+		RefreshToken: fmt.Sprintf("refresh%d", n),
+		ExpiresIn:    ta.expiresIn,
+		Scope:        param.Scope,
+	}, nil
+}
+
+func TestTokenManager(t *testing.T) {
+	ctx := context.Background()
+	ta := &testAuthenticator{expiresIn: 900}
+	tm := NewTokenManager(ta, "key", "secret", SessionScope("test"), ScopeTradeRead)
+
+	token, err := tm.Token(ctx)
+	if err != nil || token != "access1" || tm.Scope() != "session:test trade:read" {
+		t.Fatalf("bad token %s %v scope %s", token, err, tm.Scope())
+	}
+	if p := ta.params[0]; p.GrantType != GrantTypeClientCredentials || p.ClientID != "key" {
+		t.Errorf("bad login param %+v", p)
+	}
+
+	if token, _ := tm.Token(ctx); token != "access1" || len(ta.params) != 1 {
+		t.Errorf("valid token should be reused %s", token)
+	}
+	if tm.NeedRefresh(time.Now()) || !tm.NeedRefresh(time.Now().Add(time.Second*721)) {
+		t.Errorf("token should be refreshed after 80%% of expires_in")
+	}
+
+	if err := tm.Refresh(ctx); err != nil || tm.AccessToken() != "access2" {
+		t.Fatalf("refresh fail %v", err)
+	}
+	if p := ta.params[1]; p.GrantType != GrantTypeRefreshToken || p.RefreshToken != "refresh1" {
+		t.Errorf("bad refresh param %+v", p)
+	}
+
+	tm.Invalidate()
+	if tm.NeedRefresh(time.Now()) {
+		t.Errorf("invalidated token should not be refreshed")
+	}
+	if token, _ := tm.Token(ctx); token != "access3" || ta.params[2].GrantType != GrantTypeClientCredentials {
+		t.Errorf("should login after invalidate %s %+v", token, ta.params[2])
+	}
+
+	ta.failRefresh = true
+	if err := tm.Refresh(ctx); err != nil || tm.AccessToken() != "access5" ||
+		ta.params[4].GrantType != GrantTypeClientCredentials {
+		t.Errorf("should fallback to login when refresh fail %v %+v", err, ta.params)
+	}
+}
+
+func TestTokenManagerRefreshAhead(t *testing.T) {
+	ctx := context.Background()
+	ta := &testAuthenticator{expiresIn: 900}
+	tm := NewTokenManager(ta, "key", "secret")
+	if _, err := tm.Token(ctx); err != nil {
+		t.Fatalf("login fail %v", err)
+	}
+
+	tm.mu.Lock()
+	tm.refreshAt = time.Now().Add(-time.Second)
+	tm.mu.Unlock()
+	if token, err := tm.Token(ctx); err != nil || token != "access2" ||
+		ta.params[1].GrantType != GrantTypeRefreshToken {
+		t.Fatalf("token should be renewed at refreshAt %s %v", token, err)
+	}
+
+	tm.mu.Lock()
+	tm.refreshAt = time.Now().Add(-time.Second)
+	tm.mu.Unlock()
+	ta.fail = true
+	if token, err := tm.Token(ctx); err != nil || token != "access2" || len(ta.params) != 4 {
+		t.Errorf("still valid token should be returned when renew fail %s %v", token, err)
+	}
+
+	tm.mu.Lock()
+	tm.expire = time.Now().Add(-time.Second)
+	tm.mu.Unlock()
+	if _, err := tm.Token(ctx); err == nil {
+		t.Errorf("expired token should not be returned when renew fail")
+	}
+}
+
+func TestTokenManagerSingleFlight(t *testing.T) {
+	ctx := context.Background()
+	ta := &testAuthenticator{expiresIn: 900}
+	tm := NewTokenManager(ta, "key", "secret")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tm.Token(ctx)
+		}()
+	}
+	wg.Wait()
+	if len(ta.params) != 1 {
+		t.Errorf("token should be granted once, got %d", len(ta.params))
+	}
+
+	ws := &testAuthenticator{expiresIn: 900}
+	if _, err := tm.AuthenticateWith(ctx, ws); err != nil || len(ws.params) != 1 ||
+		ws.params[0].RefreshToken != "refresh1" || tm.AccessToken() != "access1" {
+		t.Errorf("authenticate with other authenticator fail %v %+v", err, ws.params)
+	}
+}
+
+func TestIsUnauthorized(t *testing.T) {
+	if !isUnauthorized(errors.WithMessage(NewError(ErrCodeUnauthorized, "unauthorized"), "response error")) {
+		t.Errorf("unauthorized error not detected")
+	}
+	if isUnauthorized(NewError(11050, "bad_request")) || isUnauthorized(nil) {
+		t.Errorf("bad unauthorized check")
+	}
+}
@@ -1,6 +1,11 @@
 package deribit
 
-import "github.com/shopspring/decimal"
+import (
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
 
 type (
 	TradeResult struct {
@@ -45,6 +50,9 @@ type (
 
 const (
 	GetUserTradesByCurrency = "private/get_user_trades_by_currency"
+
+	LiquidityMaker = "M"
+	LiquidityTaker = "T"
 )
 
 func NewGetUserTradesByCurrencyReq(currency string) *GetUserTradesByCurrencyReq {
@@ -90,3 +98,30 @@ func (gr *GetUserTradesByCurrencyReq) Sorting(sr string) *GetUserTradesByCurrenc
 	gr.addField("sorting", sr)
 	return gr
 }
+
+// Transform transform trade into exchange.Trade, the fee paid is negative
+func (tr *TradeResult) Transform() (*exchange.Trade, error) {
+	sym, err := ParseSymbol(tr.InstrumentName)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "parse symbol %s fail", tr.InstrumentName)
+	}
+
+	side, ok := directionMap[tr.Direction]
+	if !ok {
+		return nil, errors.Errorf("unknown direction='%s'", tr.Direction)
+	}
+
+	return &exchange.Trade{
+		ID:          tr.TradeID,
+		OrderID:     tr.OrderID,
+		Symbol:      sym,
+		Price:       tr.Price,
+		Amount:      tr.Amount,
+		Fee:         tr.Fee.Neg(),
+		FeeCurrency: tr.FeeCurrency,
+		Time:        tconv.Milli2Time(tr.Timestamp),
+		Side:        side,
+		IsMaker:     tr.Liquidity == LiquidityMaker,
+		Raw:         tr,
+	}, nil
+}
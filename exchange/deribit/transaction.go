@@ -0,0 +1,184 @@
+package deribit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	TransactionLogResp struct {
+		Logs         []TransactionLog `json:"logs"`
+		Continuation *int64           `json:"continuation"`
+	}
+
+	TransactionLog struct {
+		ID             int64           `json:"id"`
+		Type           string          `json:"type"`
+		Timestamp      int64           `json:"timestamp"`
+		InstrumentName string          `json:"instrument_name"`
+		Currency       string          `json:"currency"`
+		Amount         decimal.Decimal `json:"amount"`
+		Cashflow       decimal.Decimal `json:"cashflow"`
+		Change         decimal.Decimal `json:"change"`
+		Balance        decimal.Decimal `json:"balance"`
+		InterestPL     decimal.Decimal `json:"interest_pl"`
+		SessionRPL     decimal.Decimal `json:"session_rpl"`
+		Info           string          `json:"info"`
+	}
+
+	// TransactionLogReq request of the transaction log of the currency, the logs
+	// are returned from the latest
+	TransactionLogReq struct {
+		AuthToken
+		*clientReq
+		limit int
+	}
+)
+
+const (
+	TransactionLogMethod = "private/get_transaction_log"
+
+	TransactionTypeFunding    = "funding"
+	TransactionTypeSettlement = "settlement"
+)
+
+// NewTransactionLogReq transaction log of the currency between start and end
+func NewTransactionLogReq(currency string, start, end time.Time) *TransactionLogReq {
+	cr := newClientReq()
+	cr.addField("currency", strings.ToUpper(currency))
+	cr.addField("start_timestamp", start.UnixNano()/1e6)
+	cr.addField("end_timestamp", end.UnixNano()/1e6)
+
+	return &TransactionLogReq{
+		clientReq: cr,
+	}
+}
+
+// Query filter the logs by keyword such as settlement or trade
+func (tr *TransactionLogReq) Query(query string) *TransactionLogReq {
+	tr.addField("query", query)
+	return tr
+}
+
+// Count specific the page size
+func (tr *TransactionLogReq) Count(ct int) *TransactionLogReq {
+	tr.addField("count", ct)
+	return tr
+}
+
+// Limit specific the max number of logs returned by TransactionLog, which may take several pages
+func (tr *TransactionLogReq) Limit(limit int) *TransactionLogReq {
+	tr.limit = limit
+	return tr
+}
+
+func (tr *TransactionLogReq) MarshalJSON() ([]byte, error) {
+	tr.addField("access_token", tr.AuthToken.AccessToken)
+	return tr.clientReq.MarshalJSON()
+}
+
+// TransactionLog fetch logs and follow the continuation until all pages or the limit
+// are reached, filter select the logs which count for the limit
+func (c *Client) TransactionLog(ctx context.Context, req *TransactionLogReq, filter func(*TransactionLog) bool) ([]TransactionLog, error) {
+	var ret []TransactionLog
+	for {
+		var resp TransactionLogResp
+		if err := c.call(ctx, TransactionLogMethod, req, &resp, true); err != nil {
+			return nil, errors.WithMessage(err, "fetch transaction log fail")
+		}
+
+		for i := range resp.Logs {
+			if filter != nil && !filter(&resp.Logs[i]) {
+				continue
+			}
+			ret = append(ret, resp.Logs[i])
+			if req.limit != 0 && len(ret) == req.limit {
+				return ret, nil
+			}
+		}
+
+		if len(resp.Logs) == 0 || resp.Continuation == nil {
+			return ret, nil
+		}
+		req.addField("continuation", *resp.Continuation)
+	}
+}
+
+// IsFunding whether the log is funding of perpetual, which is logged as funding or
+// as interest_pl of the settlement
+func (tl *TransactionLog) IsFunding() bool {
+	switch tl.Type {
+	case TransactionTypeFunding:
+		return true
+
+	case TransactionTypeSettlement:
+		return strings.HasSuffix(tl.InstrumentName, "PERPETUAL") && !tl.InterestPL.IsZero()
+	}
+	return false
+}
+
+// Transform funding log into exchange.Finance
+func (tl *TransactionLog) Transform() (*exchange.Finance, error) {
+	if !tl.IsFunding() {
+		return nil, errors.Errorf("unsupport transaction type '%s'", tl.Type)
+	}
+
+	amount := tl.InterestPL
+	if tl.Type == TransactionTypeFunding {
+		amount = tl.Cashflow
+	}
+
+	sym, err := ParseSymbol(tl.InstrumentName)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "parse symbol %s fail", tl.InstrumentName)
+	}
+
+	return &exchange.Finance{
+		ID:       fmt.Sprintf("%d", tl.ID),
+		Time:     tconv.Milli2Time(tl.Timestamp),
+		Amount:   amount,
+		Currency: strings.ToUpper(tl.Currency),
+		Type:     exchange.FinanceTypeFunding,
+		Symbol:   sym,
+		Raw:      tl,
+	}, nil
+}
+
+// funding fetch the funding of the perpetual from the transaction log, the end time
+// default to now
+func (c *Client) funding(ctx context.Context, params *exchange.FinanceReqParam) ([]exchange.Finance, error) {
+	instrument := params.Symbol.String()
+	end := params.EndTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	req := NewTransactionLogReq(settleCurrency(instrument), params.StartTime, end)
+	if params.Limit != 0 {
+		req.Limit(params.Limit).Count(pageCount(params.Limit))
+	}
+
+	logs, err := c.TransactionLog(ctx, req, func(tl *TransactionLog) bool {
+		return tl.InstrumentName == instrument && tl.IsFunding()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]exchange.Finance, 0, len(logs))
+	for i := range logs {
+		f, err := logs[i].Transform()
+		if err != nil {
+			return nil, errors.WithMessage(err, "parse transaction log fail")
+		}
+		ret = append(ret, *f)
+	}
+	return ret, nil
+}
@@ -0,0 +1,265 @@
+package deribit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+type (
+	// ChUser private channel user.{topic}.{target}.{interval}
+	ChUser struct {
+		topic    string
+		target   string
+		interval string
+	}
+
+	UserChangesResult struct {
+		InstrumentName string           `json:"instrument_name"`
+		Orders         []Order          `json:"orders"`
+		Trades         []TradeResult    `json:"trades"`
+		Positions      []PositionResult `json:"positions"`
+	}
+
+	// UserChanges parsed user.changes notify
+	UserChanges struct {
+		Orders    []*exchange.Order
+		Trades    []*exchange.Trade
+		Positions []*exchange.Position
+		Raw       interface{}
+	}
+
+	userParseCB func(data json.RawMessage) (interface{}, error)
+)
+
+const (
+	userChannelPrefix = "user"
+
+	UserTopicOrders    = "orders"
+	UserTopicTrades    = "trades"
+	UserTopicPortfolio = "portfolio"
+	UserTopicChanges   = "changes"
+
+	IntervalRaw   = "raw"
+	Interval100ms = "100ms"
+
+	// KindAny used as kind of user channels to subscribe all kinds
+	KindAny = "any"
+)
+
+var (
+	userParseMap = map[string]userParseCB{
+		UserTopicOrders:    parseUserOrders,
+		UserTopicTrades:    parseUserTrades,
+		UserTopicPortfolio: parseUserPortfolio,
+		UserTopicChanges:   parseUserChanges,
+	}
+)
+
+func init() {
+	reigisterCB(userChannelPrefix, parseNotifyUser)
+}
+
+// NewUserOrdersChannel orders of the instrument, notify *exchange.Order
+func NewUserOrdersChannel(instrument string) exchange.Channel {
+	return &ChUser{
+		topic:    UserTopicOrders,
+		target:   instrument,
+		interval: IntervalRaw,
+	}
+}
+
+// NewUserOrdersByKindChannel orders of kind and currency, KindAny can be used as kind
+func NewUserOrdersByKindChannel(kind, currency string) exchange.Channel {
+	return &ChUser{
+		topic:    UserTopicOrders,
+		target:   fmt.Sprintf("%s.%s", kind, currency),
+		interval: IntervalRaw,
+	}
+}
+
+// NewUserTradesChannel trades of the instrument, notify []*exchange.Trade
+func NewUserTradesChannel(instrument string) exchange.Channel {
+	return &ChUser{
+		topic:    UserTopicTrades,
+		target:   instrument,
+		interval: IntervalRaw,
+	}
+}
+
+// NewUserTradesByKindChannel trades of kind and currency, KindAny can be used as kind
+func NewUserTradesByKindChannel(kind, currency string) exchange.Channel {
+	return &ChUser{
+		topic:    UserTopicTrades,
+		target:   fmt.Sprintf("%s.%s", kind, currency),
+		interval: IntervalRaw,
+	}
+}
+
+// NewUserPortfolioChannel portfolio of the currency, notify *exchange.BalanceUpdate
+func NewUserPortfolioChannel(currency string) exchange.Channel {
+	return &ChUser{
+		topic:  UserTopicPortfolio,
+		target: strings.ToLower(currency),
+	}
+}
+
+// NewUserChangesChannel changes of the instrument, notify *UserChanges
+func NewUserChangesChannel(instrument string) exchange.Channel {
+	return &ChUser{
+		topic:    UserTopicChanges,
+		target:   instrument,
+		interval: IntervalRaw,
+	}
+}
+
+// NewUserChangesByKindChannel changes of kind and currency, KindAny can be used as kind
+func NewUserChangesByKindChannel(kind, currency string) exchange.Channel {
+	return &ChUser{
+		topic:    UserTopicChanges,
+		target:   fmt.Sprintf("%s.%s", kind, currency),
+		interval: IntervalRaw,
+	}
+}
+
+func (cu *ChUser) String() string {
+	if cu.interval == "" {
+		return fmt.Sprintf("%s.%s.%s", userChannelPrefix, cu.topic, cu.target)
+	}
+	return fmt.Sprintf("%s.%s.%s.%s", userChannelPrefix, cu.topic, cu.target, cu.interval)
+}
+
+func parseNotifyUser(resp *Notify) (*rpc.Notify, error) {
+	fields := strings.Split(resp.Channel, ".")
+	if len(fields) < 2 {
+		return nil, errors.Errorf("bad user channel %s", resp.Channel)
+	}
+
+	cb, ok := userParseMap[fields[1]]
+	if !ok {
+		return nil, errors.Errorf("unsupport user channel %s", resp.Channel)
+	}
+
+	param, err := cb(resp.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.Notify{
+		Method: subscriptionMethod,
+		Params: param,
+	}, nil
+}
+
+// parseUserOrders return *exchange.Order for raw interval and []*exchange.Order for others
+func parseUserOrders(data json.RawMessage) (interface{}, error) {
+	if !isArray(data) {
+		var o Order
+		if err := json.Unmarshal(data, &o); err != nil {
+			return nil, errors.WithMessage(err, "unmarshal order fail")
+		}
+		return o.transform()
+	}
+
+	var orders []Order
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal orders fail")
+	}
+	return transformOrders(orders)
+}
+
+func parseUserTrades(data json.RawMessage) (interface{}, error) {
+	var trades []TradeResult
+	if err := json.Unmarshal(data, &trades); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal trades fail")
+	}
+	return transformTrades(trades)
+}
+
+func parseUserPortfolio(data json.RawMessage) (interface{}, error) {
+	var ar AccountSummaryResp
+	if err := json.Unmarshal(data, &ar); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal portfolio fail")
+	}
+
+	// portfolio carry no timestamp nor reason
+	return exchange.NewBalanceUpdate(ar.Transfer(), exchange.BalanceFieldAll, exchange.BalanceReasonOther, time.Time{}, &ar), nil
+}
+
+func parseUserChanges(data json.RawMessage) (interface{}, error) {
+	var ur UserChangesResult
+	if err := json.Unmarshal(data, &ur); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal changes fail")
+	}
+
+	orders, err := transformOrders(ur.Orders)
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := transformTrades(ur.Trades)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*exchange.Position, 0, len(ur.Positions))
+	for i := range ur.Positions {
+		p, err := ur.Positions[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+	}
+
+	return &UserChanges{
+		Orders:    orders,
+		Trades:    trades,
+		Positions: positions,
+		Raw:       &ur,
+	}, nil
+}
+
+// OrderUpdates implement exchange.OrderUpdater
+func (uc *UserChanges) OrderUpdates() []*exchange.Order {
+	return uc.Orders
+}
+
+// PositionUpdates implement exchange.PositionUpdater
+func (uc *UserChanges) PositionUpdates() []*exchange.Position {
+	return uc.Positions
+}
+
+func transformOrders(orders []Order) ([]*exchange.Order, error) {
+	ret := make([]*exchange.Order, 0, len(orders))
+	for i := range orders {
+		o, err := orders[i].transform()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, o)
+	}
+	return ret, nil
+}
+
+func transformTrades(trades []TradeResult) ([]*exchange.Trade, error) {
+	ret := make([]*exchange.Trade, 0, len(trades))
+	for i := range trades {
+		t, err := trades[i].Transform()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+func isArray(data json.RawMessage) bool {
+	trimed := bytes.TrimSpace(data)
+	return len(trimed) != 0 && trimed[0] == '['
+}
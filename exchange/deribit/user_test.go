@@ -0,0 +1,163 @@
+package deribit
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func initTestSwapSymbol(t *testing.T) {
+	ir := &InstrumentResult{
+		Kind:             KindFuture,
+		SettlementPeriod: SettlePeriodPerpetual,
+		BaseCurreny:      "BTC",
+		QuoteCurrency:    "USD",
+		ContractSize:     decimal.NewFromInt(10),
+		InstrumentName:   "BTC-PERPETUAL",
+	}
+	sym, err := ir.Symbol()
+	if err != nil {
+		t.Fatalf("build symbol fail %s", err.Error())
+	}
+
+	symbolMu.Lock()
+	symbolMap[sym.String()] = sym
+	symbolMu.Unlock()
+}
+
+func decodeUserNotify(t *testing.T, raw string) interface{} {
+	resp, err := (&Codec{}).Decode([]byte(raw))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+	return resp.(*rpc.Notify).Params
+}
+
+func TestUserNotify(t *testing.T) {
+	initTestSwapSymbol(t)
+
+	if ch := NewUserOrdersByKindChannel(KindAny, "BTC").String(); ch != "user.orders.any.BTC.raw" || !isPrivateChannel(ch) {
+		t.Errorf("bad channel %s", ch)
+	}
+	if ch := NewUserPortfolioChannel("BTC").String(); ch != "user.portfolio.btc" {
+		t.Errorf("bad channel %s", ch)
+	}
+
+	order := decodeUserNotify(t, `{
+		"jsonrpc": "2.0",
+		"method": "subscription",
+		"params": {
+			"channel": "user.orders.BTC-PERPETUAL.raw",
+			"data": {
+				"time_in_force": "good_til_cancelled",
+				"replaced": false,
+				"reduce_only": false,
+				"price": 10502.52,
+				"post_only": false,
+				"order_type": "limit",
+				"order_state": "open",
+				"order_id": "7",
+				"max_show": 30,
+				"last_update_timestamp": 1592476236713,
+				"label": "abc",
+				"is_liquidation": false,
+				"instrument_name": "BTC-PERPETUAL",
+				"filled_amount": 10,
+				"direction": "sell",
+				"creation_timestamp": 1592476236713,
+				"average_price": 10502.52,
+				"api": false,
+				"amount": 30
+			}
+		}
+	}`).(*exchange.Order)
+	if order.ID.String() != "7" || order.ClientID.String() != "abc" || order.Side != exchange.OrderSideSell ||
+		order.Type != exchange.OrderTypeLimit || order.Status != exchange.OrderStatusOpen ||
+		!order.Filled.Equal(decimal.NewFromInt(10)) || order.Symbol.String() != "BTC-PERPETUAL" {
+		t.Errorf("bad order %+v", *order)
+	}
+
+	changes := decodeUserNotify(t, `{
+		"jsonrpc": "2.0",
+		"method": "subscription",
+		"params": {
+			"channel": "user.changes.BTC-PERPETUAL.raw",
+			"data": {
+				"instrument_name": "BTC-PERPETUAL",
+				"trades": [{
+					"trade_seq": 866638,
+					"trade_id": "1430914",
+					"timestamp": 1605780344032,
+					"price": 17920.5,
+					"order_type": "limit",
+					"order_id": "3398016",
+					"liquidity": "M",
+					"instrument_name": "BTC-PERPETUAL",
+					"fee_currency": "BTC",
+					"fee": 0.00000023,
+					"direction": "sell",
+					"amount": 10
+				}],
+				"positions": [{
+					"total_profit_loss": 1.69711368,
+					"size": -10,
+					"realized_profit_loss": 0.00000023,
+					"leverage": 50,
+					"kind": "future",
+					"instrument_name": "BTC-PERPETUAL",
+					"initial_margin": 0.00001116,
+					"floating_profit_loss": 0,
+					"direction": "sell",
+					"average_price": 17920.5
+				}, {
+					"size": 0,
+					"leverage": 50,
+					"kind": "future",
+					"instrument_name": "BTC-PERPETUAL",
+					"direction": "zero"
+				}],
+				"orders": []
+			}
+		}
+	}`).(*UserChanges)
+	if len(changes.Trades) != 1 || len(changes.Positions) != 2 || len(changes.Orders) != 0 {
+		t.Fatalf("bad changes %+v", *changes)
+	}
+
+	trade := changes.Trades[0]
+	if !trade.IsMaker || trade.Side != exchange.OrderSideSell || trade.OrderID != "3398016" ||
+		!trade.Fee.Equal(decimal.RequireFromString("-0.00000023")) {
+		t.Errorf("bad trade %+v", *trade)
+	}
+
+	position := changes.Positions[0]
+	if position.Side != exchange.PositionSideShort || !position.Position.Equal(decimal.NewFromInt(10)) ||
+		!position.Leverage.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("bad position %+v", *position)
+	}
+
+	update := decodeUserNotify(t, `{
+		"jsonrpc": "2.0",
+		"method": "subscription",
+		"params": {
+			"channel": "user.portfolio.btc",
+			"data": {
+				"total_pl": 0.00000425,
+				"maintenance_margin": 0.00000025,
+				"initial_margin": 0.00000052,
+				"futures_session_upl": 0,
+				"equity": 0.99950082,
+				"currency": "BTC",
+				"balance": 0.99949657,
+				"available_withdrawal_funds": 0.99949605,
+				"available_funds": 0.9995003
+			}
+		}
+	}`).(*exchange.BalanceUpdate)
+	if update.Currency != "BTC" || !update.Free.Equal(decimal.RequireFromString("0.9995003")) ||
+		!update.Frozen.Equal(decimal.RequireFromString("0.00000052")) {
+		t.Errorf("bad balance update %+v", update)
+	}
+}
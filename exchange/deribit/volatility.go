@@ -0,0 +1,196 @@
+package deribit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	VolatilityIndexResp struct {
+		Data         [][]decimal.Decimal `json:"data"`
+		Continuation *int64              `json:"continuation"`
+	}
+
+	// VolatilityCandle DVOL candle
+	VolatilityCandle struct {
+		Time  time.Time
+		Open  decimal.Decimal
+		High  decimal.Decimal
+		Low   decimal.Decimal
+		Close decimal.Decimal
+	}
+
+	VolatilityIndexReq struct {
+		currency   string
+		resolution string
+		start      time.Time
+		end        time.Time
+	}
+
+	HistoricalVolatility struct {
+		Time       time.Time
+		Volatility decimal.Decimal
+	}
+
+	VolatilityIndexResult struct {
+		IndexName  string          `json:"index_name"`
+		Volatility decimal.Decimal `json:"volatility"`
+		Timestamp  int64           `json:"timestamp"`
+	}
+
+	// VolatilityIndexNotify notify of deribit_volatility_index channel
+	VolatilityIndexNotify struct {
+		IndexName  string
+		Volatility decimal.Decimal
+		Time       time.Time
+		Raw        interface{}
+	}
+
+	ChVolatilityIndex struct {
+		index string
+	}
+)
+
+const (
+	VolatilityIndexEndPoint      = "/public/get_volatility_index_data"
+	HistoricalVolatilityEndPoint = "/public/get_historical_volatility"
+
+	// resolution of volatility index data in seconds
+	VolatilityResolution1s  = "1"
+	VolatilityResolution1m  = "60"
+	VolatilityResolution1h  = "3600"
+	VolatilityResolution12h = "43200"
+	VolatilityResolution1D  = "1D"
+)
+
+func init() {
+	reigisterCB("deribit_volatility_index", parseNotifyVolatilityIndex)
+}
+
+// NewVolatilityIndexReq build DVOL request of currency between start and end
+func NewVolatilityIndexReq(currency string, resolution string, start, end time.Time) *VolatilityIndexReq {
+	return &VolatilityIndexReq{
+		currency:   strings.ToUpper(currency),
+		resolution: resolution,
+		start:      start,
+		end:        end,
+	}
+}
+
+func (rc *RestClient) VolatilityIndexData(ctx context.Context, req *VolatilityIndexReq) (*VolatilityIndexResp, error) {
+	values := url.Values{}
+	values.Add("currency", req.currency)
+	values.Add("resolution", req.resolution)
+	values.Add("start_timestamp", strconv.FormatInt(tconv.Time2Milli(req.start), 10))
+	values.Add("end_timestamp", strconv.FormatInt(tconv.Time2Milli(req.end), 10))
+
+	var ret VolatilityIndexResp
+	if err := rc.Request(ctx, http.MethodGet, VolatilityIndexEndPoint, values, nil, false, &ret); err != nil {
+		return nil, errors.WithMessage(err, "fetch volatility index data fail")
+	}
+	return &ret, nil
+}
+
+// VolatilityIndex fetch DVOL candles in ascending order and follow the continuation
+// until the start time is reached
+func (rc *RestClient) VolatilityIndex(ctx context.Context, req *VolatilityIndexReq) ([]VolatilityCandle, error) {
+	var ret []VolatilityCandle
+	r := *req
+	for {
+		resp, err := rc.VolatilityIndexData(ctx, &r)
+		if err != nil {
+			return nil, err
+		}
+
+		candles, err := resp.Transform()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(candles, ret...)
+
+		if resp.Continuation == nil || len(candles) == 0 {
+			return ret, nil
+		}
+		end := tconv.Milli2Time(*resp.Continuation)
+		if !end.After(r.start) || !end.Before(r.end) {
+			return ret, nil
+		}
+		r.end = end
+	}
+}
+
+func (vr *VolatilityIndexResp) Transform() ([]VolatilityCandle, error) {
+	ret := make([]VolatilityCandle, 0, len(vr.Data))
+	for _, d := range vr.Data {
+		if len(d) != 5 {
+			return nil, errors.Errorf("bad volatility candle %v", d)
+		}
+		ret = append(ret, VolatilityCandle{
+			Time:  tconv.Milli2Time(d[0].IntPart()),
+			Open:  d[1],
+			High:  d[2],
+			Low:   d[3],
+			Close: d[4],
+		})
+	}
+	return ret, nil
+}
+
+// HistoricalVolatility fetch historical volatility of the currency in ascending order
+func (rc *RestClient) HistoricalVolatility(ctx context.Context, currency string) ([]HistoricalVolatility, error) {
+	values := url.Values{}
+	values.Add("currency", strings.ToUpper(currency))
+
+	var resp [][2]decimal.Decimal
+	if err := rc.Request(ctx, http.MethodGet, HistoricalVolatilityEndPoint, values, nil, false, &resp); err != nil {
+		return nil, errors.WithMessage(err, "fetch historical volatility fail")
+	}
+
+	ret := make([]HistoricalVolatility, len(resp))
+	for i, r := range resp {
+		ret[i] = HistoricalVolatility{
+			Time:       tconv.Milli2Time(r[0].IntPart()),
+			Volatility: r[1],
+		}
+	}
+	return ret, nil
+}
+
+// NewVolatilityIndexChannel DVOL channel of index such as btc_usd
+func NewVolatilityIndexChannel(index string) *ChVolatilityIndex {
+	return &ChVolatilityIndex{
+		index: index,
+	}
+}
+
+func (cv *ChVolatilityIndex) String() string {
+	return fmt.Sprintf("deribit_volatility_index.%s", cv.index)
+}
+
+func parseNotifyVolatilityIndex(resp *Notify) (*rpc.Notify, error) {
+	var vr VolatilityIndexResult
+	if err := json.Unmarshal(resp.Data, &vr); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal volatility index result")
+	}
+
+	return &rpc.Notify{
+		Method: subscriptionMethod,
+		Params: &VolatilityIndexNotify{
+			IndexName:  vr.IndexName,
+			Volatility: vr.Volatility,
+			Time:       tconv.Milli2Time(vr.Timestamp),
+			Raw:        &vr,
+		},
+	}, nil
+}
@@ -0,0 +1,81 @@
+package deribit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func TestChartDataTransform(t *testing.T) {
+	initTestSwapSymbol(t)
+	sym, _ := ParseSymbol("BTC-PERPETUAL")
+
+	var resp ChartDataResp
+	if err := json.Unmarshal([]byte(`{
+		"volume": [19.007942601, 20.095877981],
+		"ticks": [1554373800000, 1554373860000],
+		"status": "ok",
+		"open": [4963.42, 4986.29],
+		"low": [4728.94, 4726.6],
+		"high": [5185.45, 5250.87],
+		"cost": [19000.0, 23400.0],
+		"close": [5052.95, 5013.59]
+	}`), &resp); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	klines, err := resp.Transform(sym)
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+	if len(klines) != 2 || klines[1].Close != 5013.59 || klines[1].Time.UnixNano()/1e6 != 1554373860000 {
+		t.Errorf("bad klines %+v", klines)
+	}
+}
+
+func TestVolatilityIndex(t *testing.T) {
+	var resp VolatilityIndexResp
+	if err := json.Unmarshal([]byte(`{
+		"data": [
+			[1598019300000, 0.210084879, 0.212217842, 0.210084879, 0.212217842],
+			[1598019360000, 0.212001406, 0.212364649, 0.211752793, 0.212364649]
+		],
+		"continuation": 1598019240000
+	}`), &resp); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	candles, err := resp.Transform()
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+	if len(candles) != 2 || *resp.Continuation != 1598019240000 ||
+		!candles[1].Close.Equal(decimal.RequireFromString("0.212364649")) {
+		t.Errorf("bad candles %+v", candles)
+	}
+
+	r, err := (&Codec{}).Decode([]byte(`{
+		"jsonrpc": "2.0",
+		"method": "subscription",
+		"params": {
+			"channel": "deribit_volatility_index.btc_usd",
+			"data": {
+				"volatility": 129.36,
+				"timestamp": 1619777946007,
+				"index_name": "btc_usd"
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+	vn := r.(*rpc.Notify).Params.(*VolatilityIndexNotify)
+	if vn.IndexName != "btc_usd" || !vn.Volatility.Equal(decimal.RequireFromString("129.36")) {
+		t.Errorf("bad volatility notify %+v", *vn)
+	}
+	if ch := NewVolatilityIndexChannel("btc_usd").String(); ch != "deribit_volatility_index.btc_usd" {
+		t.Errorf("bad channel %s", ch)
+	}
+}
@@ -2,6 +2,8 @@ package exchange
 
 import (
 	"fmt"
+
+	"github.com/pkg/errors"
 )
 
 type (
@@ -15,6 +17,26 @@ type (
 	ErrBadExResp struct {
 		Err error
 	}
+
+	//ErrPositionExists means leverage, margin mode or position mode can not be
+	//changed due to open positions or orders
+	ErrPositionExists struct {
+		Err error
+	}
+)
+
+//errors mapped from error codes of exchanges, test with errors.Is while the venue
+//error carrying raw code and message is kept in the chain
+var (
+	ErrInsufficientBalance  = errors.New("insufficient balance")
+	ErrOrderNotFound        = errors.New("order not found")
+	ErrRateLimited          = errors.New("rate limited")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrTimestampOutOfWindow = errors.New("timestamp out of recv window")
+	ErrPostOnlyRejected     = errors.New("post only order rejected")
+	ErrReduceOnlyRejected   = errors.New("reduce only order rejected")
+	ErrMaintenance          = errors.New("exchange under maintenance")
+	ErrSymbolNotFound       = errors.New("symbol not found")
 )
 
 func NewBadArg(msg string, arg interface{}) error {
@@ -48,3 +70,24 @@ func (ebe *ErrBadExResp) Is(target error) bool {
 	_, ok := target.(*ErrBadExResp)
 	return ok
 }
+
+func (ebe *ErrBadExResp) Unwrap() error {
+	return ebe.Err
+}
+
+func NewPositionExists(err error) error {
+	return &ErrPositionExists{Err: err}
+}
+
+func (epe *ErrPositionExists) Error() string {
+	return fmt.Sprintf("position or order exists %s", epe.Err.Error())
+}
+
+func (epe *ErrPositionExists) Is(target error) bool {
+	_, ok := target.(*ErrPositionExists)
+	return ok
+}
+
+func (epe *ErrPositionExists) Unwrap() error {
+	return epe.Err
+}
@@ -28,4 +28,6 @@ const (
 	FinanceTypeOther FinanceType = iota
 	FinanceTypeFunding
 	FinanceTypeInterest
+	FinanceTypeSettlement
+	FinanceTypeDelivery
 )
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/misc/request"
 )
 
 type (
@@ -22,6 +23,7 @@ type (
 		secret     string
 		subAccount string
 		prefix     string
+		limiter    *request.Limiter
 	}
 
 	Wrap struct {
@@ -59,10 +61,15 @@ func (rc *RestClient) request(ctx context.Context, method string, endPoint strin
 		return err
 	}
 
+	if err := rc.limiter.Wait(ctx, method, endPoint); err != nil {
+		return err
+	}
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
+	rc.limiter.Update(resp)
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
@@ -84,6 +91,11 @@ func (rc *RestClient) request(ctx context.Context, method string, endPoint strin
 	return nil
 }
 
+// SetLimiter set the limiter, requests are not limited by default
+func (rc *RestClient) SetLimiter(l *request.Limiter) {
+	rc.limiter = l
+}
+
 func (rc *RestClient) buildRequest(ctx context.Context, method string, endPoint string, params url.Values, body io.Reader, sign bool) (*http.Request, error) {
 	var (
 		req *http.Request
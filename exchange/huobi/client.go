@@ -33,12 +33,17 @@ type (
 		key     string
 		secret  string
 		apiHost string
+		limiter *request.Limiter
 	}
 
 	RestResponse struct {
-		Status string      `json:"status"`
-		Code   int         `json:"code"`
-		Data   interface{} `json:"data"`
+		Status      string      `json:"status"`
+		Code        int         `json:"code"`
+		ErrCode     int         `json:"err_code"`
+		ErrMsg      string      `json:"err_msg"`
+		SpotErrCode string      `json:"err-code"`
+		SpotErrMsg  string      `json:"err-msg"`
+		Data        interface{} `json:"data"`
 	}
 )
 
@@ -55,7 +60,7 @@ func (rc *RestClient) RequestWithRawResp(ctx context.Context, method string, end
 	if err != nil {
 		return err
 	}
-	return request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
+	return request.DoWithLimiter(rc.limiter, req, func(resp *http.Response, ierr error) error {
 		if ierr != nil {
 			return ierr
 		}
@@ -78,7 +83,7 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 	if err != nil {
 		return err
 	}
-	return request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
+	return request.DoWithLimiter(rc.limiter, req, func(resp *http.Response, ierr error) error {
 		if ierr != nil {
 			return ierr
 		}
@@ -96,12 +101,29 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 		}
 
 		if (rr.Status != "" && rr.Status != "ok") || (rr.Code != 0 && rr.Code != 200) {
+			if rr.ErrCode != 0 {
+				return errors.WithMessagef(NewAPIError(rr.ErrCode, rr.ErrMsg), "rest return error %s", string(content))
+			}
+			if rr.SpotErrCode != "" {
+				return errors.WithMessagef(NewSpotError(rr.SpotErrCode, rr.SpotErrMsg), "rest return error %s", string(content))
+			}
 			return errors.Errorf("rest return error %s", string(content))
 		}
 		return nil
 	})
 }
 
+// SetLimiter replace the limiter, which can be shared with other clients of the same
+// account. spot and contract clients use NewSpotLimiter and NewContractLimiter by
+// default, nil disables rate limiting
+func (rc *RestClient) SetLimiter(l *request.Limiter) {
+	rc.limiter = l
+}
+
+func (rc *RestClient) Limiter() *request.Limiter {
+	return rc.limiter
+}
+
 func (rc *RestClient) Property() exchange.Property {
 	return exchange.Property{
 		Trades: &exchange.TradesProp{
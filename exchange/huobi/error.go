@@ -1,20 +1,99 @@
 package huobi
 
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
 type (
+	//Error error of spot api carry err-code and err-msg, Code is empty for other errors
 	Error struct {
-		msg string
+		Code string
+		msg  string
+	}
+
+	//APIError error carry err_code and err_msg of the derivatives api
+	APIError struct {
+		Code int
+		Msg  string
+	}
+)
+
+const (
+	ErrCodeContractNotExist   = 1014
+	ErrCodeTooManyRequests    = 1032
+	ErrCodeInsufficientMargin = 1047
+	ErrCodeInsufficientClose  = 1048
+	ErrCodeOrderNotExist      = 1061
+)
+
+var (
+	codeErrors = map[int]error{
+		ErrCodeContractNotExist:   exchange.ErrSymbolNotFound,
+		ErrCodeTooManyRequests:    exchange.ErrRateLimited,
+		ErrCodeInsufficientMargin: exchange.ErrInsufficientBalance,
+		ErrCodeInsufficientClose:  exchange.ErrReduceOnlyRejected,
+		ErrCodeOrderNotExist:      exchange.ErrOrderNotFound,
+	}
+
+	spotCodeErrors = map[string]error{
+		"account-frozen-balance-insufficient-error": exchange.ErrInsufficientBalance,
+		"order-accountbalance-error":                exchange.ErrInsufficientBalance,
+		"base-record-invalid":                       exchange.ErrOrderNotFound,
+		"base-symbol-error":                         exchange.ErrSymbolNotFound,
+		"api-signature-not-valid":                   exchange.ErrInvalidSignature,
 	}
 )
 
 func NewError(msg string) error {
-	return &Error{msg}
+	return &Error{msg: msg}
+}
+
+// NewSpotError create error with err-code and err-msg of spot api
+func NewSpotError(code string, msg string) error {
+	return &Error{
+		Code: code,
+		msg:  fmt.Sprintf("huobi error code: %s msg: %s", code, msg),
+	}
 }
 
 func (e *Error) Error() string {
 	return e.msg
 }
 
-func (e *Error) Is(target interface{}) bool {
-	_, ok := target.(*Error)
-	return ok
+// Is match *Error and the exchange error mapped from the code
+func (e *Error) Is(target error) bool {
+	if _, ok := target.(*Error); ok {
+		return true
+	}
+	err, ok := spotCodeErrors[e.Code]
+	return ok && target == err
+}
+
+func NewAPIError(code int, msg string) error {
+	return &APIError{
+		Code: code,
+		Msg:  msg,
+	}
+}
+
+func (ae *APIError) Error() string {
+	return fmt.Sprintf("huobi error code: %d msg: %s", ae.Code, ae.Msg)
+}
+
+// Is match *APIError and the exchange error mapped from the code
+func (ae *APIError) Is(target error) bool {
+	if _, ok := target.(*APIError); ok {
+		return true
+	}
+	err, ok := codeErrors[ae.Code]
+	return ok && target == err
+}
+
+// IsOrderNotFound whether the error means the order does not exist
+func IsOrderNotFound(err error) bool {
+	var ae *APIError
+	return errors.As(err, &ae) && ae.Code == ErrCodeOrderNotExist
 }
@@ -0,0 +1,98 @@
+package future
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	AccountInfoReq struct {
+		symbol string
+	}
+
+	AccountInfo struct {
+		Symbol            string          `json:"symbol"`
+		MarginBalance     decimal.Decimal `json:"margin_balance"`
+		MarginPosition    decimal.Decimal `json:"margin_position"`
+		MarginFrozen      decimal.Decimal `json:"margin_frozen"`
+		MarginAvailable   decimal.Decimal `json:"margin_available"`
+		ProfitReal        decimal.Decimal `json:"profit_real"`
+		ProfitUnreal      decimal.Decimal `json:"profit_unreal"`
+		RiskRate          decimal.Decimal `json:"risk_rate"`
+		LiquidationPrice  decimal.Decimal `json:"liquidation_price"`
+		WithdrawAvailable decimal.Decimal `json:"withdraw_available"`
+		LeverRate         decimal.Decimal `json:"lever_rate"`
+		AdjustFactor      decimal.Decimal `json:"adjust_factor"`
+		MarginStatic      decimal.Decimal `json:"margin_static"`
+	}
+)
+
+const (
+	AccountInfoEndPoint = "/api/v1/contract_account_info"
+)
+
+func NewAccountInfoReq() *AccountInfoReq {
+	return &AccountInfoReq{}
+}
+
+// Symbol specific the currency of the account such as BTC
+func (air *AccountInfoReq) Symbol(symbol string) *AccountInfoReq {
+	air.symbol = symbol
+	return air
+}
+
+func (air *AccountInfoReq) Serialize() ([]byte, error) {
+	m := map[string]string{}
+	if air.symbol != "" {
+		m["symbol"] = air.symbol
+	}
+	return json.Marshal(m)
+}
+
+func (rc *RestClient) AccountInfo(ctx context.Context, req *AccountInfoReq) ([]AccountInfo, error) {
+	var ret []AccountInfo
+	if err := rc.PrivatePostReq(ctx, AccountInfoEndPoint, req, &ret); err != nil {
+		return nil, errors.WithMessage(err, "request contract_account_info fail")
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) FetchBalance(ctx context.Context, currencies ...string) (*exchange.Balances, error) {
+	accountInfo, err := rc.AccountInfo(ctx, NewAccountInfoReq())
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetch future account info fail")
+	}
+
+	bm := map[string]*exchange.Balance{}
+	for i := range accountInfo {
+		a := accountInfo[i]
+		bm[a.Symbol] = &exchange.Balance{
+			Currency: a.Symbol,
+			Total:    a.MarginBalance,
+			Frozen:   a.MarginFrozen,
+			Free:     a.MarginAvailable,
+		}
+	}
+	balances := exchange.NewBalances()
+	balances.Raw = accountInfo
+
+	if len(currencies) != 0 {
+		for _, c := range currencies {
+			b, ok := bm[exchange.CurrencyFormat(c)]
+			if !ok {
+				return nil, errors.Errorf("currency '%s' not support", c)
+			}
+			balances.Add(b)
+		}
+		return balances, nil
+	}
+
+	for _, val := range bm {
+		balances.Add(val)
+	}
+	return balances, nil
+}
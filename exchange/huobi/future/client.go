@@ -1,8 +1,13 @@
 package future
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/szmcdull/ccexgo/exchange/huobi"
 )
 
@@ -10,6 +15,13 @@ type (
 	RestClient struct {
 		*huobi.RestClient
 		futureSymbolMap map[string]*FutureSymbol
+
+		mu     sync.Mutex
+		levers map[string]int //lever_rate cache of symbols
+	}
+
+	Serializer interface {
+		Serialize() ([]byte, error)
 	}
 )
 
@@ -18,12 +30,35 @@ const (
 )
 
 func NewRestClient(key, secret string) *RestClient {
+	rc := huobi.NewRestClient(key, secret, FutureHost)
+	rc.SetLimiter(huobi.NewContractLimiter())
 	return &RestClient{
-		RestClient:      huobi.NewRestClient(key, secret, FutureHost),
+		RestClient:      rc,
 		futureSymbolMap: make(map[string]*FutureSymbol),
+		levers:          make(map[string]int),
 	}
 }
 
 func (rc *RestClient) Init(ctx context.Context) error {
 	return rc.initFutureSymbol(ctx)
 }
+
+// PrivatePostReq send post request to huobi future api. the request body is generate from req param
+// vai json.Marshal() or Serialize()
+func (rc *RestClient) PrivatePostReq(ctx context.Context, endPoint string, req interface{}, dst interface{}) error {
+	var (
+		raw []byte
+		err error
+	)
+
+	if sr, ok := req.(Serializer); ok {
+		raw, err = sr.Serialize()
+	} else {
+		raw, err = json.Marshal(req)
+	}
+	if err != nil {
+		return errors.WithMessage(err, "serialize fail")
+	}
+	buf := bytes.NewBuffer(raw)
+	return rc.Request(ctx, http.MethodPost, endPoint, nil, buf, true, dst)
+}
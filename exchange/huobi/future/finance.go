@@ -0,0 +1,169 @@
+package future
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	FinancialRecordReq struct {
+		symbol    string
+		typ       []string
+		days      int
+		pageIndex int
+		pageSize  int
+	}
+
+	FinancialRecord struct {
+		ID     int64           `json:"id"`
+		TS     int64           `json:"ts"`
+		Symbol string          `json:"symbol"`
+		Type   int             `json:"type"`
+		Amount decimal.Decimal `json:"amount"`
+	}
+
+	FinancialRecordResp struct {
+		FinancialRecord []FinancialRecord `json:"financial_record"`
+		TotalPage       int               `json:"total_page"`
+		CurrentPage     int               `json:"current_page"`
+		TotalSize       int               `json:"total_size"`
+	}
+)
+
+const (
+	FinancialRecordEndPoint = "/api/v1/contract_financial_record"
+
+	FinancialRecordTypeCloseLong       = 3
+	FinancialRecordTypeCloseShort      = 4
+	FinancialRecordTypeOpenFeeTaker    = 5
+	FinancialRecordTypeOpenFeeMaker    = 6
+	FinancialRecordTypeCloseFeeTaker   = 7
+	FinancialRecordTypeCloseFeeMaker   = 8
+	FinancialRecordTypeDeliveryLong    = 9
+	FinancialRecordTypeDeliveryShort   = 10
+	FinancialRecordTypeDeliveryFee     = 11
+	FinancialRecordTypeLiquidateLong   = 12
+	FinancialRecordTypeLiquidateShort  = 13
+	FinancialRecordTypeTransferInSpot  = 14
+	FinancialRecordTypeTransferOutSpot = 15
+)
+
+// NewFinancialRecordReq build financial record request, symbol is the currency such as BTC
+func NewFinancialRecordReq(symbol string) *FinancialRecordReq {
+	return &FinancialRecordReq{
+		symbol: symbol,
+	}
+}
+
+func (frr *FinancialRecordReq) Type(types ...int) *FinancialRecordReq {
+	for _, t := range types {
+		frr.typ = append(frr.typ, strconv.Itoa(t))
+	}
+	return frr
+}
+
+// CreateDate specific the number of days to look back, which should be in [1, 90]
+func (frr *FinancialRecordReq) CreateDate(days int) *FinancialRecordReq {
+	frr.days = days
+	return frr
+}
+
+func (frr *FinancialRecordReq) PageIndex(pi int) *FinancialRecordReq {
+	frr.pageIndex = pi
+	return frr
+}
+
+func (frr *FinancialRecordReq) PageSize(ps int) *FinancialRecordReq {
+	frr.pageSize = ps
+	return frr
+}
+
+func (frr *FinancialRecordReq) Serialize() ([]byte, error) {
+	param := map[string]interface{}{
+		"symbol": frr.symbol,
+	}
+
+	if len(frr.typ) != 0 {
+		param["type"] = strings.Join(frr.typ, ",")
+	}
+
+	if frr.days != 0 {
+		param["create_date"] = frr.days
+	}
+
+	if frr.pageIndex != 0 {
+		param["page_index"] = frr.pageIndex
+	}
+
+	if frr.pageSize != 0 {
+		param["page_size"] = frr.pageSize
+	}
+
+	return json.Marshal(param)
+}
+
+func (rc *RestClient) FinancialRecord(ctx context.Context, req *FinancialRecordReq) (*FinancialRecordResp, error) {
+	var ret FinancialRecordResp
+	if err := rc.PrivatePostReq(ctx, FinancialRecordEndPoint, req, &ret); err != nil {
+		return nil, errors.WithMessage(err, "fetch financial record fail")
+	}
+	return &ret, nil
+}
+
+// Finance fetch financial records of the symbol's currency, delivery futures
+// has no funding so all records are returned as exchange.FinanceTypeOther
+func (rc *RestClient) Finance(ctx context.Context, params *exchange.FinanceReqParam) ([]exchange.Finance, error) {
+	if params.Type != exchange.FinanceTypeOther {
+		return nil, errors.Errorf("unsupport type '%d'", params.Type)
+	}
+
+	fsym, ok := params.Symbol.(exchange.FuturesSymbol)
+	if !ok {
+		return nil, errors.Errorf("futures symbol is required")
+	}
+
+	req := NewFinancialRecordReq(fsym.Index())
+	if params.Limit != 0 {
+		req.PageSize(params.Limit)
+	}
+
+	records, err := rc.FinancialRecord(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []exchange.Finance
+	for i := range records.FinancialRecord {
+		rec := records.FinancialRecord[i].Transform()
+		if !params.StartTime.IsZero() && rec.Time.Before(params.StartTime) {
+			continue
+		}
+		if !params.EndTime.IsZero() && rec.Time.After(params.EndTime) {
+			continue
+		}
+		rec.Symbol = params.Symbol
+		ret = append(ret, *rec)
+	}
+	return ret, nil
+}
+
+// Transform financial record into exchange.Finance, the record is not bound
+// to any contract so Symbol is left empty
+func (fr *FinancialRecord) Transform() *exchange.Finance {
+	return &exchange.Finance{
+		ID:       fmt.Sprintf("%d", fr.ID),
+		Currency: fr.Symbol,
+		Amount:   fr.Amount,
+		Type:     exchange.FinanceTypeOther,
+		Time:     huobi.ParseTS(fr.TS),
+		Raw:      fr,
+	}
+}
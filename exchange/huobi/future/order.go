@@ -0,0 +1,553 @@
+package future
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	OrderReq struct {
+		data map[string]interface{}
+	}
+
+	OrderResp struct {
+		OrderID       int64  `json:"order_id"`
+		OrderIDStr    string `json:"order_id_str"`
+		ClientOrderID int64  `json:"client_order_id"`
+	}
+
+	CancelReq struct {
+		symbol         string
+		orderIDS       []string
+		clientOrderIDS []string
+	}
+
+	CancelError struct {
+		OrderID string `json:"order_id"`
+		ErrCode int    `json:"err_code"`
+		ErrMsg  string `json:"err_msg"`
+	}
+
+	CancelResp struct {
+		Errors    []CancelError `json:"errors"`
+		Successes string        `json:"successes"` //id1,id2,id3 ...
+	}
+
+	OrderInfoReq struct {
+		symbol         string
+		orderIDS       []string
+		clientOrderIDS []string
+	}
+
+	Order struct {
+		Symbol         string          `json:"symbol"`
+		ContractCode   string          `json:"contract_code"`
+		ContractType   string          `json:"contract_type"`
+		Volume         decimal.Decimal `json:"volume"`
+		Price          decimal.Decimal `json:"price"`
+		OrderPriceType string          `json:"order_price_type"`
+		OrderType      int             `json:"order_type"`
+		Direction      string          `json:"direction"`
+		Offset         string          `json:"offset"`
+		LeverRate      int             `json:"lever_rate"`
+		OrderID        int64           `json:"order_id"`
+		OrderIDStr     string          `json:"order_id_str"`
+		ClientOrderID  int64           `json:"client_order_id"`
+		CreatedAt      int64           `json:"created_at"`
+		CanceledAt     int64           `json:"canceled_at"`
+		TradeVolume    decimal.Decimal `json:"trade_volume"`
+		TradeTurnOver  decimal.Decimal `json:"trade_turnover"`
+		Fee            decimal.Decimal `json:"fee"`
+		FeeAsset       string          `json:"fee_asset"`
+		TradeAvgPrice  decimal.Decimal `json:"trade_avg_price"`
+		MarginFrozen   decimal.Decimal `json:"margin_frozen"`
+		Profit         decimal.Decimal `json:"profit"`
+		Status         int             `json:"status"`
+		OrderSource    string          `json:"order_source"`
+	}
+
+	OrderDetailReq struct {
+		data map[string]interface{}
+	}
+
+	OrderDetailTrade struct {
+		ID            string          `json:"id"`
+		TradeID       int64           `json:"trade_id"`
+		TradeVolume   decimal.Decimal `json:"trade_volume"`
+		TradePrice    decimal.Decimal `json:"trade_price"`
+		TradeFee      decimal.Decimal `json:"trade_fee"`
+		FeeAsset      string          `json:"fee_asset"`
+		TradeTurnOver decimal.Decimal `json:"trade_turnover"`
+		Role          string          `json:"role"`
+		CreatedAt     int64           `json:"created_at"`
+	}
+
+	OrderDetail struct {
+		Order
+		Trades      []OrderDetailTrade `json:"trades"`
+		TotalPage   int                `json:"total_page"`
+		CurrentPage int                `json:"current_page"`
+		TotalSize   int                `json:"total_size"`
+	}
+)
+
+const (
+	OrderEndPoint       = "/api/v1/contract_order"
+	CancelEndPoint      = "/api/v1/contract_cancel"
+	OrderInfoEndPoint   = "/api/v1/contract_order_info"
+	OrderDetailEndPoint = "/api/v1/contract_order_detail"
+
+	OrderDirectionBuy  = "buy"
+	OrderDirectionSell = "sell"
+	OrderOffsetOpen    = "open"
+	OrderOffsetClose   = "close"
+
+	OrderPriceLimit    = huobi.OrderPriceLimit
+	OrderPriceMarket   = huobi.OrderPriceMarket
+	OrderPricePostOnly = huobi.OrderPricePostOnly
+	OrderPriceOptimal5 = huobi.OrderPriceOptimal5
+	OrderPriceIOC      = huobi.OrderPriceIOC
+	OrderPriceFOK      = huobi.OrderPriceFOK
+)
+
+var (
+	statusMap = map[int]exchange.OrderStatus{
+		1:  exchange.OrderStatusOpen,
+		2:  exchange.OrderStatusOpen,
+		3:  exchange.OrderStatusOpen,
+		4:  exchange.OrderStatusOpen,
+		5:  exchange.OrderStatusCancel,
+		6:  exchange.OrderStatusDone,
+		7:  exchange.OrderStatusCancel,
+		11: exchange.OrderStatusOpen,
+	}
+
+	typeMap = map[string]exchange.OrderType{
+		OrderPriceLimit:    exchange.OrderTypeLimit,
+		OrderPricePostOnly: exchange.OrderTypeLimit,
+		OrderPriceMarket:   exchange.OrderTypeMarket,
+		OrderPriceOptimal5: exchange.OrderTypeMarket,
+		OrderPriceIOC:      exchange.OrderTypeLimit,
+		OrderPriceFOK:      exchange.OrderTypeLimit,
+	}
+)
+
+// NewOrderReq build order request, contractCode is the code such as BTC200925
+// and volume is in contracts
+func NewOrderReq(contractCode string, volume int, direction string, offset string, lever int, orderPriceType string) *OrderReq {
+	ret := OrderReq{
+		data: make(map[string]interface{}),
+	}
+
+	ret.data["contract_code"] = contractCode
+	ret.data["volume"] = volume
+	ret.data["direction"] = direction
+	ret.data["offset"] = offset
+	ret.data["lever_rate"] = lever
+	ret.data["order_price_type"] = orderPriceType
+	return &ret
+}
+
+func (or *OrderReq) Price(price float64) *OrderReq {
+	or.data["price"] = price
+	return or
+}
+
+func (or *OrderReq) ClientOrderID(id int64) *OrderReq {
+	or.data["client_order_id"] = id
+	return or
+}
+
+func (or *OrderReq) Serialize() ([]byte, error) {
+	return json.Marshal(or.data)
+}
+
+// NewCancelReq build cancel request, symbol is the currency such as BTC
+func NewCancelReq(symbol string) *CancelReq {
+	return &CancelReq{
+		symbol: symbol,
+	}
+}
+
+func (cr *CancelReq) Orders(ids ...string) *CancelReq {
+	cr.orderIDS = append(cr.orderIDS, ids...)
+	return cr
+}
+
+func (cr *CancelReq) ClientOrderIDs(ids ...string) *CancelReq {
+	cr.clientOrderIDS = append(cr.clientOrderIDS, ids...)
+	return cr
+}
+
+func (cr *CancelReq) Serialize() ([]byte, error) {
+	return serializeIDs(cr.symbol, cr.orderIDS, cr.clientOrderIDS)
+}
+
+// NewOrderInfoReq build order info request, symbol is the currency such as BTC
+func NewOrderInfoReq(symbol string) *OrderInfoReq {
+	return &OrderInfoReq{
+		symbol: symbol,
+	}
+}
+
+func (oir *OrderInfoReq) Orders(ids ...string) *OrderInfoReq {
+	oir.orderIDS = append(oir.orderIDS, ids...)
+	return oir
+}
+
+func (oir *OrderInfoReq) ClientOrderIDs(ids ...string) *OrderInfoReq {
+	oir.clientOrderIDS = append(oir.clientOrderIDS, ids...)
+	return oir
+}
+
+func (oir *OrderInfoReq) Serialize() ([]byte, error) {
+	return serializeIDs(oir.symbol, oir.orderIDS, oir.clientOrderIDS)
+}
+
+// NewOrderDetailReq build order detail request, symbol is the currency such as BTC
+func NewOrderDetailReq(symbol string, id int64) *OrderDetailReq {
+	return &OrderDetailReq{
+		data: map[string]interface{}{
+			"symbol":   symbol,
+			"order_id": id,
+		},
+	}
+}
+
+func (odr *OrderDetailReq) CreatedAt(ts int64) *OrderDetailReq {
+	odr.data["created_at"] = ts
+	return odr
+}
+
+func (odr *OrderDetailReq) OrderType(ot int) *OrderDetailReq {
+	odr.data["order_type"] = ot
+	return odr
+}
+
+func (odr *OrderDetailReq) PageIndex(pi int) *OrderDetailReq {
+	odr.data["page_index"] = pi
+	return odr
+}
+
+func (odr *OrderDetailReq) PageSize(ps int) *OrderDetailReq {
+	odr.data["page_size"] = ps
+	return odr
+}
+
+func (odr *OrderDetailReq) Serialize() ([]byte, error) {
+	return json.Marshal(odr.data)
+}
+
+func (rc *RestClient) AddOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	var resp OrderResp
+	if err := rc.PrivatePostReq(ctx, OrderEndPoint, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (rc *RestClient) DeleteOrder(ctx context.Context, req *CancelReq) (*CancelResp, error) {
+	var resp CancelResp
+	if err := rc.PrivatePostReq(ctx, CancelEndPoint, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (rc *RestClient) GetOrder(ctx context.Context, req *OrderInfoReq) ([]Order, error) {
+	var resp []Order
+	if err := rc.PrivatePostReq(ctx, OrderInfoEndPoint, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (rc *RestClient) OrderDetail(ctx context.Context, req *OrderDetailReq) (*OrderDetail, error) {
+	var resp OrderDetail
+	if err := rc.PrivatePostReq(ctx, OrderDetailEndPoint, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// FetchOrder fetch order by ID, ClientID is used if ID is not set
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req, err := orderInfoReq(order)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := rc.GetOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return nil, huobi.NewAPIError(huobi.ErrCodeOrderNotExist, "order not exist")
+	}
+	return orders[0].Transform(rc.ParseSymbol)
+}
+
+// CancelOrder cancel order by ID, ClientID is used if ID is not set
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) error {
+	fsym, ok := order.Symbol.(exchange.FuturesSymbol)
+	if !ok {
+		return errors.Errorf("unsupport symbol '%s'", order.Symbol.String())
+	}
+
+	req := NewCancelReq(fsym.Index())
+	if order.ID != nil {
+		req.Orders(order.ID.String())
+	} else if order.ClientID != nil {
+		req.ClientOrderIDs(order.ClientID.String())
+	} else {
+		return errors.Errorf("order id or client id is required")
+	}
+
+	resp, err := rc.DeleteOrder(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Errors) != 0 {
+		e := resp.Errors[0]
+		return errors.WithMessage(huobi.NewAPIError(e.ErrCode, e.ErrMsg), "cancel order fail")
+	}
+	return nil
+}
+
+// CreateOrder create order with exchange.OrderRequest, the amount is the number of contracts
+// and lever_rate is the current leverage of the symbol. client id should be integer
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	fsym, ok := req.Symbol.(exchange.FuturesSymbol)
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol '%s'", req.Symbol.String())
+	}
+
+	side, err := exchange.NewTradeSide(req.Side, true)
+	if err != nil {
+		return nil, err
+	}
+	direction, offset := DirectionOffset(side)
+
+	param, err := huobi.NewContractOrderParam(req, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	code := fsym.Index()
+	lever, err := rc.leverRate(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	oReq := NewOrderReq(contractCode(fsym), param.Volume, direction, offset, lever, param.PriceType)
+	if req.Type == exchange.OrderTypeLimit {
+		oReq.Price(param.Price)
+	}
+	if param.HasClientID {
+		oReq.ClientOrderID(param.ClientOrderID)
+	}
+
+	resp, err := rc.AddOrder(ctx, oReq)
+	if err != nil {
+		// lever_rate may be changed by others
+		rc.clearLeverRate(code)
+		return nil, err
+	}
+	return huobi.NewCreatedOrder(req, resp.OrderID, resp), nil
+}
+
+// Transform huobi future order into exchange.Order, amount is in contracts
+func (o *Order) Transform(parseSymbol SymbolParser) (*exchange.Order, error) {
+	symbol, err := parseSymbol(o.ContractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := ParseSide(o.Direction, o.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	st, ok := statusMap[o.Status]
+	if !ok {
+		return nil, errors.Errorf("unkown order status %d", o.Status)
+	}
+
+	typ, ok := typeMap[o.OrderPriceType]
+	if !ok {
+		return nil, errors.Errorf("unkown order type %s", o.OrderPriceType)
+	}
+
+	ret := &exchange.Order{
+		ID:          exchange.NewIntID(o.OrderID),
+		Symbol:      symbol,
+		Amount:      o.Volume,
+		Filled:      o.TradeVolume,
+		Price:       o.Price,
+		AvgPrice:    o.TradeAvgPrice,
+		Fee:         o.Fee,
+		FeeCurrency: o.FeeAsset,
+		Created:     huobi.ParseTS(o.CreatedAt),
+		Side:        side,
+		Status:      st,
+		Type:        typ,
+		Raw:         o,
+	}
+
+	if o.ClientOrderID != 0 {
+		ret.ClientID = exchange.NewIntID(o.ClientOrderID)
+	}
+
+	if o.CanceledAt != 0 {
+		ret.Updated = huobi.ParseTS(o.CanceledAt)
+	} else {
+		ret.Updated = ret.Created
+	}
+	return ret, nil
+}
+
+// Transform order detail into exchange.Order, updated time is set to the last trade time
+func (od *OrderDetail) Transform(parseSymbol SymbolParser) (*exchange.Order, error) {
+	ret, err := od.Order.Transform(parseSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if od.CanceledAt == 0 {
+		for _, t := range od.Trades {
+			ts := huobi.ParseTS(t.CreatedAt)
+			if ts.After(ret.Updated) {
+				ret.Updated = ts
+			}
+		}
+	}
+	ret.Raw = od
+	return ret, nil
+}
+
+// ParseSide translate direction and offset into exchange.OrderSide, huobi derivatives
+// always hold long and short position separately
+func ParseSide(direction, offset string) (exchange.OrderSide, error) {
+	if direction != OrderDirectionBuy && direction != OrderDirectionSell {
+		return exchange.OrderSideBuy, errors.Errorf("unkown order direction '%s'", direction)
+	}
+
+	buy := direction == OrderDirectionBuy
+	ts := &exchange.TradeSide{
+		Hedge: true,
+	}
+	switch offset {
+	case OrderOffsetOpen:
+		ts.Offset = exchange.PositionOffsetOpen
+
+	case OrderOffsetClose:
+		ts.Offset = exchange.PositionOffsetClose
+
+	default:
+		return exchange.OrderSideBuy, errors.Errorf("unkown order offset '%s'", offset)
+	}
+
+	ts.PositionSide = exchange.PositionSideShort
+	if buy == (ts.Offset == exchange.PositionOffsetOpen) {
+		ts.PositionSide = exchange.PositionSideLong
+	}
+	return ts.OrderSide(), nil
+}
+
+// DirectionOffset translate exchange.TradeSide into direction and offset, huobi
+// contracts always hold long and short position separately
+func DirectionOffset(ts *exchange.TradeSide) (direction string, offset string) {
+	direction = OrderDirectionSell
+	if ts.IsBuy() {
+		direction = OrderDirectionBuy
+	}
+
+	offset = OrderOffsetOpen
+	if ts.Offset == exchange.PositionOffsetClose {
+		offset = OrderOffsetClose
+	}
+	return
+}
+
+// leverRate return current lever_rate of the symbol such as BTC, which must be
+// used by orders while holding positions. it's cached until an order fail
+func (rc *RestClient) leverRate(ctx context.Context, symbol string) (int, error) {
+	rc.mu.Lock()
+	lever, ok := rc.levers[symbol]
+	rc.mu.Unlock()
+	if ok {
+		return lever, nil
+	}
+
+	infos, err := rc.AccountInfo(ctx, NewAccountInfoReq().Symbol(symbol))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, info := range infos {
+		if strings.EqualFold(info.Symbol, symbol) {
+			lever = int(info.LeverRate.IntPart())
+			rc.mu.Lock()
+			rc.levers[symbol] = lever
+			rc.mu.Unlock()
+			return lever, nil
+		}
+	}
+	return 0, errors.Errorf("no account info for '%s'", symbol)
+}
+
+func (rc *RestClient) clearLeverRate(symbol string) {
+	rc.mu.Lock()
+	delete(rc.levers, symbol)
+	rc.mu.Unlock()
+}
+
+// contractCode return the contract code such as BTC200925
+func contractCode(fsym exchange.FuturesSymbol) string {
+	if fs, ok := fsym.(*FutureSymbol); ok {
+		return fs.ContractCode()
+	}
+	return fmt.Sprintf("%s%s", fsym.Index(), fsym.SettleTime().Format("060102"))
+}
+
+func orderInfoReq(order *exchange.Order) (*OrderInfoReq, error) {
+	fsym, ok := order.Symbol.(exchange.FuturesSymbol)
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol '%s'", order.Symbol.String())
+	}
+
+	req := NewOrderInfoReq(fsym.Index())
+	if order.ID != nil {
+		req.Orders(order.ID.String())
+	} else if order.ClientID != nil {
+		req.ClientOrderIDs(order.ClientID.String())
+	} else {
+		return nil, errors.Errorf("order id or client id is required")
+	}
+	return req, nil
+}
+
+func serializeIDs(symbol string, orderIDS []string, clientOrderIDS []string) ([]byte, error) {
+	data := map[string]string{
+		"symbol": symbol,
+	}
+
+	if len(orderIDS) != 0 {
+		data["order_id"] = strings.Join(orderIDS, ",")
+	}
+
+	if len(clientOrderIDS) != 0 {
+		data["client_order_id"] = strings.Join(clientOrderIDS, ",")
+	}
+
+	return json.Marshal(data)
+}
@@ -0,0 +1,77 @@
+package future
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func initTestClient() *RestClient {
+	rc := NewRestClient("", "")
+	fsym := &FSymbol{
+		Symbol:       "BTC",
+		ContractCode: "BTC200925",
+		ContractSize: decimal.NewFromInt(100),
+		PriceTick:    decimal.RequireFromString("0.01"),
+		DeliveryDate: "20200925",
+		ContractType: "quarter",
+	}
+	st := time.Date(2020, 9, 25, 8, 0, 0, 0, time.UTC)
+	rc.futureSymbolMap[fsym.ContractCode] = newFutureSymbolWithCfg(fsym, st, exchange.FutureTypeCQ)
+	return rc
+}
+
+func TestOrderTransform(t *testing.T) {
+	rc := initTestClient()
+	raw := `[{
+		"symbol": "BTC",
+		"contract_code": "BTC200925",
+		"contract_type": "quarter",
+		"volume": 3,
+		"price": 10000,
+		"order_price_type": "limit",
+		"order_type": 1,
+		"direction": "sell",
+		"offset": "close",
+		"lever_rate": 10,
+		"order_id": 773131315209248768,
+		"order_id_str": "773131315209248768",
+		"client_order_id": null,
+		"created_at": 1604370469629,
+		"canceled_at": 0,
+		"trade_volume": 1,
+		"trade_turnover": 100,
+		"fee": -0.000002,
+		"trade_avg_price": 10000,
+		"margin_frozen": 0,
+		"profit": 0,
+		"status": 4,
+		"order_source": "api",
+		"fee_asset": "BTC"
+	}]`
+	var orders []Order
+	if err := json.Unmarshal([]byte(raw), &orders); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	order, err := orders[0].Transform(rc.ParseSymbol)
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+
+	if order.Side != exchange.OrderSideCloseLong || order.Status != exchange.OrderStatusOpen ||
+		order.Type != exchange.OrderTypeLimit || order.ClientID != nil ||
+		!order.Amount.Equal(decimal.NewFromInt(3)) || !order.Filled.Equal(decimal.NewFromInt(1)) ||
+		order.ID.String() != "773131315209248768" {
+		t.Errorf("bad order %+v", *order)
+	}
+
+	fsym := order.Symbol.(*FutureSymbol)
+	if fsym.ContractCode() != "BTC200925" || fsym.String() != "BTC20200925" ||
+		!fsym.ContractVal().Equal(decimal.NewFromInt(100)) {
+		t.Errorf("bad symbol %s", fsym.String())
+	}
+}
@@ -0,0 +1,118 @@
+package future
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	Position struct {
+		Symbol         string          `json:"symbol"`
+		ContractCode   string          `json:"contract_code"`
+		ContractType   string          `json:"contract_type"`
+		Volume         decimal.Decimal `json:"volume"`
+		Available      decimal.Decimal `json:"available"`
+		Frozen         decimal.Decimal `json:"frozen"`
+		CostOpen       decimal.Decimal `json:"cost_open"`
+		CostHold       decimal.Decimal `json:"cost_hold"`
+		ProfitUnreal   decimal.Decimal `json:"profit_unreal"`
+		ProfitRate     decimal.Decimal `json:"profit_rate"`
+		LeverRate      int             `json:"lever_rate"`
+		PositionMargin decimal.Decimal `json:"position_margin"`
+		Direction      string          `json:"direction"`
+		Profit         decimal.Decimal `json:"profit"`
+		LastPrice      decimal.Decimal `json:"last_price"`
+	}
+
+	PositionInfoReq struct {
+		Symbol string `json:"symbol"`
+	}
+)
+
+const (
+	PositionInfoEndPoint = "/api/v1/contract_position_info"
+)
+
+// NewPositionInfoReq build position request, all positions are returned if symbol is empty
+func NewPositionInfoReq(symbol string) *PositionInfoReq {
+	return &PositionInfoReq{
+		Symbol: symbol,
+	}
+}
+
+func (pir *PositionInfoReq) Serialize() ([]byte, error) {
+	if pir.Symbol != "" {
+		return json.Marshal(pir)
+	}
+	return []byte("{}"), nil
+}
+
+func (rc *RestClient) PositionInfo(ctx context.Context, req *PositionInfoReq) ([]Position, error) {
+	var ret []Position
+	if err := rc.PrivatePostReq(ctx, PositionInfoEndPoint, req, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get position info fail")
+	}
+
+	return ret, nil
+}
+
+// FetchPosition fetch positions of the symbols, all positions are returned if no symbol is given
+func (rc *RestClient) FetchPosition(ctx context.Context, syms ...exchange.Symbol) ([]*exchange.Position, error) {
+	positions, err := rc.PositionInfo(ctx, NewPositionInfoReq(""))
+	if err != nil {
+		return nil, err
+	}
+
+	want := map[string]struct{}{}
+	for _, s := range syms {
+		want[s.String()] = struct{}{}
+	}
+
+	var ret []*exchange.Position
+	for i := range positions {
+		p, err := positions[i].Transfer(rc.ParseSymbol)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(want) != 0 {
+			if _, ok := want[p.Symbol.String()]; !ok {
+				continue
+			}
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func (p *Position) Transfer(parseSymbol SymbolParser) (*exchange.Position, error) {
+	sym, err := parseSymbol(p.ContractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var side exchange.PositionSide
+	if p.Direction == OrderDirectionBuy {
+		side = exchange.PositionSideLong
+	} else if p.Direction == OrderDirectionSell {
+		side = exchange.PositionSideShort
+	} else {
+		return nil, errors.Errorf("unkown direction '%s'", p.Direction)
+	}
+	return &exchange.Position{
+		Symbol:        sym,
+		Side:          side,
+		Mode:          exchange.PositionModeCross,
+		Position:      p.Volume,
+		AvailPosition: p.Available,
+		AvgOpenPrice:  p.CostHold,
+		UNRealizedPNL: p.ProfitUnreal,
+		Margin:        p.PositionMargin,
+		Leverage:      decimal.NewFromInt(int64(p.LeverRate)),
+		Raw:           p,
+	}, nil
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/szmcdull/ccexgo/exchange"
 	"github.com/szmcdull/ccexgo/exchange/huobi"
 )
@@ -24,22 +25,29 @@ type (
 	}
 
 	FSymbol struct {
-		Symbol         string `json:"symbol"`
-		ContractCode   string `json:"contract_code"`
-		DeliveryDate   string `json:"delivery_date"`
-		ContractType   string `json:"contract_type"`
-		ContractStatus int    `json:"contract_status"`
+		Symbol         string          `json:"symbol"`
+		ContractCode   string          `json:"contract_code"`
+		ContractSize   decimal.Decimal `json:"contract_size"`
+		PriceTick      decimal.Decimal `json:"price_tick"`
+		DeliveryDate   string          `json:"delivery_date"`
+		ContractType   string          `json:"contract_type"`
+		ContractStatus int             `json:"contract_status"`
 	}
+
+	// SymbolParser find symbol by contract code such as BTC200925
+	SymbolParser func(code string) (*FutureSymbol, error)
 )
 
 const (
 	ContractStatusOnline = 1
 	timeFmt              = "20060102"
+
+	ContractInfoEndPoint = "/api/v1/contract_contract_info"
 )
 
 func (rc *RestClient) initFutureSymbol(ctx context.Context) error {
 	var resp FutureSymbolResp
-	if err := rc.Request(ctx, http.MethodGet, "/api/v1/contract_contract_info",
+	if err := rc.RequestWithRawResp(ctx, http.MethodGet, ContractInfoEndPoint,
 		nil, nil, false, &resp); err != nil {
 		return err
 	}
@@ -49,7 +57,8 @@ func (rc *RestClient) initFutureSymbol(ctx context.Context) error {
 		return huobi.NewError(string(ret))
 	}
 
-	for _, fsym := range resp.Data {
+	for i := range resp.Data {
+		fsym := resp.Data[i]
 		if fsym.ContractStatus != ContractStatusOnline {
 			continue
 		}
@@ -78,9 +87,10 @@ func (rc *RestClient) initFutureSymbol(ctx context.Context) error {
 		} else {
 			return errors.Errorf("unkown contract_type '%s'", fsym.ContractType)
 		}
-		sym := newFutureSymbol(fsym.Symbol, st, typ)
+		sym := newFutureSymbolWithCfg(&fsym, st, typ)
 		rc.futureSymbolMap[fmt.Sprintf("%s%s", fsym.Symbol, fsym.DeliveryDate)] = sym
 		rc.futureSymbolMap[fmt.Sprintf("%s%s", fsym.Symbol, suffix)] = sym
+		rc.futureSymbolMap[fsym.ContractCode] = sym
 	}
 
 	return nil
@@ -97,12 +107,34 @@ func (rc *RestClient) GetFutureSymbols(index string) []*FutureSymbol {
 	}
 	return ret
 }
-func newFutureSymbol(base string, st time.Time, typ exchange.FutureType) *FutureSymbol {
+
+// ParseSymbol return symbol by contract code, the client should be initialized
+// via Init first
+func (rc *RestClient) ParseSymbol(code string) (*FutureSymbol, error) {
+	sym, ok := rc.futureSymbolMap[code]
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol %s", code)
+	}
+	return sym, nil
+}
+
+// newFutureSymbolWithCfg build symbol with contract size and price tick, amount is in contracts
+func newFutureSymbolWithCfg(fsym *FSymbol, st time.Time, typ exchange.FutureType) *FutureSymbol {
+	cfg := exchange.SymbolConfig{
+		AmountPrecision: decimal.NewFromInt(1),
+		PricePrecision:  fsym.PriceTick,
+		AmountMin:       decimal.NewFromInt(1),
+	}
 	return &FutureSymbol{
-		BaseFutureSymbol: exchange.NewBaseFutureSymbol(strings.ToUpper(base), st, typ),
+		BaseFutureSymbol: exchange.NewBaseFuturesSymbolWithCfgCV(strings.ToUpper(fsym.Symbol), st, typ, cfg, fsym.ContractSize, fsym),
 	}
 }
 
+// ContractCode return contract code used by rest api such as BTC200925
+func (fs *FutureSymbol) ContractCode() string {
+	return fmt.Sprintf("%s%s", fs.Index(), fs.SettleTime().Format("060102"))
+}
+
 func (fs *FutureSymbol) String() string {
 	return fmt.Sprintf("%s%s", fs.Index(), fs.SettleTime().Format(timeFmt))
 }
@@ -0,0 +1,166 @@
+package future
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	MatchResultsReq struct {
+		data map[string]interface{}
+	}
+
+	MatchResult struct {
+		ID               string          `json:"id"`
+		MatchID          int64           `json:"match_id"`
+		OrderID          int64           `json:"order_id"`
+		OrderIDStr       string          `json:"order_id_str"`
+		Symbol           string          `json:"symbol"`
+		ContractType     string          `json:"contract_type"`
+		ContractCode     string          `json:"contract_code"`
+		Direction        string          `json:"direction"`
+		Offset           string          `json:"offset"`
+		TradeVolume      decimal.Decimal `json:"trade_volume"`
+		TradePrice       decimal.Decimal `json:"trade_price"`
+		TradeTurnOver    decimal.Decimal `json:"trade_turnover"`
+		CreateDate       int64           `json:"create_date"`
+		OffsetProfitLoss decimal.Decimal `json:"offset_profitloss"`
+		RealProfit       decimal.Decimal `json:"real_profit"`
+		TradeFee         decimal.Decimal `json:"trade_fee"`
+		Role             string          `json:"role"`
+		FeeAsset         string          `json:"fee_asset"`
+		OrderSource      string          `json:"order_source"`
+	}
+
+	MatchResultsResp struct {
+		Trades      []MatchResult `json:"trades"`
+		TotalPage   int           `json:"total_page"`
+		CurrentPage int           `json:"current_page"`
+		TotalSize   int           `json:"total_size"`
+	}
+)
+
+const (
+	MatchResultsEndPoint = "/api/v1/contract_matchresults"
+
+	TradeTypeAll = 0
+
+	RoleMaker = "maker"
+
+	matchResultsMaxDays = 90
+)
+
+// NewMatchResultsReq build match results request, symbol is the currency such as BTC
+// and days is the number of days to look back which should be in [1, 90]
+func NewMatchResultsReq(symbol string, tradeType int, days int) *MatchResultsReq {
+	return &MatchResultsReq{
+		data: map[string]interface{}{
+			"symbol":      symbol,
+			"trade_type":  tradeType,
+			"create_date": days,
+		},
+	}
+}
+
+func (mrr *MatchResultsReq) ContractCode(code string) *MatchResultsReq {
+	mrr.data["contract_code"] = code
+	return mrr
+}
+
+func (mrr *MatchResultsReq) PageIndex(pi int) *MatchResultsReq {
+	mrr.data["page_index"] = pi
+	return mrr
+}
+
+func (mrr *MatchResultsReq) PageSize(ps int) *MatchResultsReq {
+	mrr.data["page_size"] = ps
+	return mrr
+}
+
+func (mrr *MatchResultsReq) Serialize() ([]byte, error) {
+	return json.Marshal(mrr.data)
+}
+
+func (rc *RestClient) MatchResults(ctx context.Context, req *MatchResultsReq) (*MatchResultsResp, error) {
+	var resp MatchResultsResp
+	if err := rc.PrivatePostReq(ctx, MatchResultsEndPoint, req, &resp); err != nil {
+		return nil, errors.WithMessage(err, "request contract_matchresults fail")
+	}
+	return &resp, nil
+}
+
+// Trades fetch user trades of the symbol, only the first page is fetched
+func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) ([]exchange.Trade, error) {
+	fsym, ok := req.Symbol.(*FutureSymbol)
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol '%s'", req.Symbol.String())
+	}
+
+	days := matchResultsMaxDays
+	if !req.StartTime.IsZero() {
+		days = int(time.Since(req.StartTime)/(time.Hour*24)) + 1
+		if days > matchResultsMaxDays {
+			days = matchResultsMaxDays
+		}
+	}
+
+	mr := NewMatchResultsReq(fsym.Index(), TradeTypeAll, days).ContractCode(fsym.ContractCode())
+	if req.Limit != 0 {
+		mr.PageSize(req.Limit)
+	}
+
+	resp, err := rc.MatchResults(ctx, mr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []exchange.Trade
+	for i := range resp.Trades {
+		t, err := resp.Trades[i].Transform(rc.ParseSymbol)
+		if err != nil {
+			return nil, err
+		}
+
+		if !req.StartTime.IsZero() && t.Time.Before(req.StartTime) {
+			continue
+		}
+		if !req.EndTime.IsZero() && t.Time.After(req.EndTime) {
+			continue
+		}
+		ret = append(ret, *t)
+	}
+	return ret, nil
+}
+
+// Transform match result into exchange.Trade, amount is in contracts
+func (mr *MatchResult) Transform(parseSymbol SymbolParser) (*exchange.Trade, error) {
+	sym, err := parseSymbol(mr.ContractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := ParseSide(mr.Direction, mr.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exchange.Trade{
+		ID:          mr.ID,
+		OrderID:     mr.OrderIDStr,
+		Symbol:      sym,
+		Price:       mr.TradePrice,
+		Amount:      mr.TradeVolume,
+		Fee:         mr.TradeFee,
+		FeeCurrency: mr.FeeAsset,
+		Time:        huobi.ParseTS(mr.CreateDate),
+		Side:        side,
+		IsMaker:     mr.Role == RoleMaker,
+		Raw:         mr,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package huobi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/szmcdull/ccexgo/misc/request"
+)
+
+const (
+	RuleRequests = "requests"
+	RuleTrade    = "trade"
+	RuleRead     = "read"
+)
+
+// NewSpotLimiter limiter of spot api, private requests are limited to 100 per 10
+// seconds by uid and order placement has its own limit
+func NewSpotLimiter() *request.Limiter {
+	return request.NewLimiter(
+		request.Rule{Name: RuleRequests, Limit: 100, Interval: 10 * time.Second},
+	).Default(request.Cost{RuleRequests: 1}).
+		EndpointLimit(http.MethodPost, "/v1/order/orders/place", 100, 2*time.Second).
+		EndpointLimit(http.MethodPost, "/v2/account/transfer", 2, time.Second)
+}
+
+// NewContractLimiter limiter of futures, coin margined swap and usdt margined swap api.
+// private requests are limited by uid to 72 trade and 72 read requests per 3 seconds,
+// which is shared among the contract clients of the same account
+func NewContractLimiter() *request.Limiter {
+	l := request.NewLimiter(
+		request.Rule{Name: RuleTrade, Limit: 72, Interval: 3 * time.Second},
+		request.Rule{Name: RuleRead, Limit: 72, Interval: 3 * time.Second},
+	).Default(request.Cost{RuleRead: 1})
+
+	for _, ep := range []string{
+		"/api/v1/contract_order",
+		"/api/v1/contract_cancel",
+		"/api/v1/contract_switch_lever_rate",
+		"/swap-api/v1/swap_order",
+		"/swap-api/v1/swap_cancel",
+		"/swap-api/v1/swap_switch_lever_rate",
+		"/linear-swap-api/v1/swap_order",
+		"/linear-swap-api/v1/swap_cancel",
+		"/linear-swap-api/v1/swap_switch_lever_rate",
+		"/linear-swap-api/v1/swap_cross_order",
+		"/linear-swap-api/v1/swap_cross_cancel",
+		"/linear-swap-api/v1/swap_cross_switch_lever_rate",
+	} {
+		l.Endpoint(http.MethodPost, ep, request.Cost{RuleTrade: 1})
+	}
+	return l
+}
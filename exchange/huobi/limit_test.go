@@ -0,0 +1,21 @@
+package huobi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestContractLimiter(t *testing.T) {
+	l := NewContractLimiter()
+	for i := 0; i < 72; i++ {
+		if !l.TryAcquire(http.MethodPost, "/swap-api/v1/swap_order") {
+			t.Fatalf("acquire fail at %d", i)
+		}
+	}
+	if l.TryAcquire(http.MethodPost, "/linear-swap-api/v1/swap_cross_order") {
+		t.Errorf("trade limit should be shared among contracts")
+	}
+	if !l.TryAcquire(http.MethodPost, "/swap-api/v1/swap_order_info") {
+		t.Errorf("read requests should not consume trade limit")
+	}
+}
@@ -0,0 +1,141 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	AccountInfoReq struct {
+		contractCode  string
+		marginAccount string
+	}
+
+	// ContractDetail contract of cross account
+	ContractDetail struct {
+		Symbol       string          `json:"symbol"`
+		ContractCode string          `json:"contract_code"`
+		LeverRate    decimal.Decimal `json:"lever_rate"`
+	}
+
+	// AccountInfo is returned by both swap_account_info and swap_cross_account_info,
+	// MarginAvailable and ContractCode are only set for isolated accounts while
+	// ContractDetail is only set for cross accounts
+	AccountInfo struct {
+		Symbol            string           `json:"symbol"`
+		ContractCode      string           `json:"contract_code"`
+		MarginMode        MarginMode       `json:"margin_mode"`
+		MarginAccount     string           `json:"margin_account"`
+		MarginAsset       string           `json:"margin_asset"`
+		MarginBalance     decimal.Decimal  `json:"margin_balance"`
+		MarginStatic      decimal.Decimal  `json:"margin_static"`
+		MarginPosition    decimal.Decimal  `json:"margin_position"`
+		MarginFrozen      decimal.Decimal  `json:"margin_frozen"`
+		MarginAvailable   decimal.Decimal  `json:"margin_available"`
+		ProfitReal        decimal.Decimal  `json:"profit_real"`
+		ProfitUnreal      decimal.Decimal  `json:"profit_unreal"`
+		RiskRate          decimal.Decimal  `json:"risk_rate"`
+		LiquidationPrice  decimal.Decimal  `json:"liquidation_price"`
+		WithdrawAvailable decimal.Decimal  `json:"withdraw_available"`
+		LeverRate         decimal.Decimal  `json:"lever_rate"`
+		AdjustFactor      decimal.Decimal  `json:"adjust_factor"`
+		ContractDetail    []ContractDetail `json:"contract_detail"`
+	}
+)
+
+const (
+	AccountInfoEndPoint      = "/linear-swap-api/v1/swap_account_info"
+	CrossAccountInfoEndPoint = "/linear-swap-api/v1/swap_cross_account_info"
+)
+
+func NewAccountInfoReq() *AccountInfoReq {
+	return &AccountInfoReq{}
+}
+
+// ContractCode filter isolated account by contract code such as BTC-USDT
+func (air *AccountInfoReq) ContractCode(code string) *AccountInfoReq {
+	air.contractCode = code
+	return air
+}
+
+// MarginAccount filter cross account by margin account such as USDT
+func (air *AccountInfoReq) MarginAccount(account string) *AccountInfoReq {
+	air.marginAccount = account
+	return air
+}
+
+func (air *AccountInfoReq) Serialize() ([]byte, error) {
+	m := map[string]string{}
+	if air.contractCode != "" {
+		m["contract_code"] = air.contractCode
+	}
+	if air.marginAccount != "" {
+		m["margin_account"] = air.marginAccount
+	}
+	return json.Marshal(m)
+}
+
+func (rc *RestClient) AccountInfo(ctx context.Context, req *AccountInfoReq) ([]AccountInfo, error) {
+	var ret []AccountInfo
+	ep := rc.endPoint(AccountInfoEndPoint, CrossAccountInfoEndPoint)
+	if err := rc.PrivatePostReq(ctx, ep, req, &ret); err != nil {
+		return nil, errors.WithMessage(err, "request account info fail")
+	}
+	return ret, nil
+}
+
+// FetchBalance fetch balances of the margin accounts. Currency of cross account is the
+// margin asset such as USDT, while isolated account use the margin account such as BTC-USDT
+func (rc *RestClient) FetchBalance(ctx context.Context, currencies ...string) (*exchange.Balances, error) {
+	accountInfo, err := rc.AccountInfo(ctx, NewAccountInfoReq())
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetch linear account info fail")
+	}
+
+	bm := map[string]*exchange.Balance{}
+	for i := range accountInfo {
+		b := accountInfo[i].Transform()
+		bm[b.Currency] = b
+	}
+	balances := exchange.NewBalances()
+	balances.Raw = accountInfo
+
+	if len(currencies) != 0 {
+		for _, c := range currencies {
+			b, ok := bm[exchange.CurrencyFormat(c)]
+			if !ok {
+				return nil, errors.Errorf("currency '%s' not support", c)
+			}
+			balances.Add(b)
+		}
+		return balances, nil
+	}
+
+	for _, val := range bm {
+		balances.Add(val)
+	}
+	return balances, nil
+}
+
+// Transform account info into exchange.Balance, free of cross account is withdraw_available
+func (ai *AccountInfo) Transform() *exchange.Balance {
+	if ai.MarginMode == MarginModeIsolated {
+		return &exchange.Balance{
+			Currency: ai.MarginAccount,
+			Total:    ai.MarginBalance,
+			Frozen:   ai.MarginFrozen,
+			Free:     ai.MarginAvailable,
+		}
+	}
+
+	return &exchange.Balance{
+		Currency: ai.MarginAsset,
+		Total:    ai.MarginBalance,
+		Frozen:   ai.MarginFrozen,
+		Free:     ai.WithdrawAvailable,
+	}
+}
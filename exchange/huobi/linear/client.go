@@ -0,0 +1,87 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	RestClient struct {
+		*huobi.RestClient
+		marginMode MarginMode
+
+		mu     sync.Mutex
+		levers map[string]int //lever_rate cache of contracts
+	}
+
+	Serializer interface {
+		Serialize() ([]byte, error)
+	}
+
+	MarginMode string
+)
+
+const (
+	LinearHost = "api.hbdm.com"
+
+	MarginModeCross    MarginMode = "cross"
+	MarginModeIsolated MarginMode = "isolated"
+)
+
+// NewRestClient return client which trade with cross margin account
+func NewRestClient(key, secret string) *RestClient {
+	return NewRestClientWithHost(key, secret, LinearHost, MarginModeCross)
+}
+
+// NewIsolatedRestClient return client which trade with isolated margin accounts
+func NewIsolatedRestClient(key, secret string) *RestClient {
+	return NewRestClientWithHost(key, secret, LinearHost, MarginModeIsolated)
+}
+
+func NewRestClientWithHost(key, secret, host string, mode MarginMode) *RestClient {
+	rc := huobi.NewRestClient(key, secret, host)
+	rc.SetLimiter(huobi.NewContractLimiter())
+	return &RestClient{
+		RestClient: rc,
+		marginMode: mode,
+		levers:     make(map[string]int),
+	}
+}
+
+func (rc *RestClient) MarginMode() MarginMode {
+	return rc.marginMode
+}
+
+// PrivatePostReq send post request to huobi linear swap api. the request body is generate from req param
+// vai json.Marshal() or Serialize()
+func (rc *RestClient) PrivatePostReq(ctx context.Context, endPoint string, req interface{}, dst interface{}) error {
+	var (
+		raw []byte
+		err error
+	)
+
+	if sr, ok := req.(Serializer); ok {
+		raw, err = sr.Serialize()
+	} else {
+		raw, err = json.Marshal(req)
+	}
+	if err != nil {
+		return errors.WithMessage(err, "serialize fail")
+	}
+	buf := bytes.NewBuffer(raw)
+	return rc.Request(ctx, http.MethodPost, endPoint, nil, buf, true, dst)
+}
+
+// endPoint choose endpoint by the margin mode of the client
+func (rc *RestClient) endPoint(isolated, cross string) string {
+	if rc.marginMode == MarginModeIsolated {
+		return isolated
+	}
+	return cross
+}
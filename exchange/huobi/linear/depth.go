@@ -0,0 +1,89 @@
+package linear
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	DepthHighFreqChannel struct {
+		contractCode string
+		size         int
+	}
+
+	Depth struct {
+		Asks    [][2]float64
+		Bids    [][2]float64
+		Ch      string
+		Event   string
+		ID      int64
+		MrID    int64
+		TS      int64
+		Version int
+	}
+
+	DepthSize int
+)
+
+const (
+	DepthSize20  DepthSize = 20
+	DepthSize150 DepthSize = 150
+)
+
+func NewDepthHighFreq(symbol string, size DepthSize) exchange.Channel {
+	return &DepthHighFreqChannel{
+		contractCode: symbol,
+		size:         int(size),
+	}
+}
+
+func (ch *DepthHighFreqChannel) String() string {
+	return fmt.Sprintf("market.%s.depth.size_%d.high_freq", ch.contractCode, ch.size)
+}
+
+func ParseDepth(raw json.RawMessage) (*exchange.OrderBook, error) {
+	var d Depth
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return nil, err
+	}
+
+	fields := strings.Split(d.Ch, ".")
+	if len(fields) < 2 {
+		return nil, errors.Errorf("invalid ch %s", d.Ch)
+	}
+
+	sym, err := ParseSymbol(fields[1])
+	if err != nil {
+		return nil, err
+	}
+
+	bids := make([]exchange.OrderElem, len(d.Bids))
+	asks := make([]exchange.OrderElem, len(d.Asks))
+
+	for i, b := range d.Bids {
+		bids[i] = exchange.OrderElem{
+			Price:  b[0],
+			Amount: b[1],
+		}
+	}
+
+	for i, a := range d.Asks {
+		asks[i] = exchange.OrderElem{
+			Price:  a[0],
+			Amount: a[1],
+		}
+	}
+
+	return &exchange.OrderBook{
+		Symbol:  sym,
+		Bids:    bids,
+		Asks:    asks,
+		Created: huobi.ParseTS(d.TS),
+		Raw:     &d,
+	}, nil
+}
@@ -0,0 +1,214 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	FinancialRecordRequest struct {
+		marginAccount string
+		contractCode  string
+		typ           []string
+		startTime     int64
+		endTime       int64
+		fromID        int64
+		direct        string
+	}
+
+	FinancialRecord struct {
+		ID                int64           `json:"id"`
+		QueryID           int64           `json:"query_id"`
+		Type              int             `json:"type"`
+		Amount            decimal.Decimal `json:"amount"`
+		TS                int64           `json:"ts"`
+		ContractCode      string          `json:"contract_code"`
+		Asset             string          `json:"asset"`
+		MarginAccount     string          `json:"margin_account"`
+		FaceMarginAccount string          `json:"face_margin_account"`
+	}
+
+	FinancialRecordResponse struct {
+		Code int               `json:"code"`
+		Msg  string            `json:"msg"`
+		TS   int64             `json:"ts"`
+		Data []FinancialRecord `json:"data"`
+	}
+)
+
+const (
+	FinancialRecordEndPoint = "/linear-swap-api/v3/swap_financial_record"
+
+	FinancialRecordTypeFundingIncome  = 30
+	FinancialRecordTypeFundingOutCome = 31
+
+	// CrossMarginAccount is the margin account of cross mode
+	CrossMarginAccount = "USDT"
+)
+
+// NewFinancialRecordRequest build request, marginAccount is USDT for cross mode and
+// contract code such as BTC-USDT for isolated mode
+func NewFinancialRecordRequest(marginAccount string) *FinancialRecordRequest {
+	return &FinancialRecordRequest{
+		marginAccount: marginAccount,
+	}
+}
+
+func (frr *FinancialRecordRequest) Contract(code string) *FinancialRecordRequest {
+	frr.contractCode = code
+	return frr
+}
+
+func (frr *FinancialRecordRequest) Type(types ...int) *FinancialRecordRequest {
+	for _, t := range types {
+		frr.typ = append(frr.typ, strconv.Itoa(t))
+	}
+	return frr
+}
+
+// StartTime specific record query start timestamp in milliseconds
+func (frr *FinancialRecordRequest) StartTime(ts int64) *FinancialRecordRequest {
+	frr.startTime = ts
+	return frr
+}
+
+// EndTime specific record query end timestamp in milliseconds
+func (frr *FinancialRecordRequest) EndTime(ts int64) *FinancialRecordRequest {
+	frr.endTime = ts
+	return frr
+}
+
+func (frr *FinancialRecordRequest) FromID(fromID int64) *FinancialRecordRequest {
+	frr.fromID = fromID
+	return frr
+}
+
+func (frr *FinancialRecordRequest) Direct(direct string) *FinancialRecordRequest {
+	frr.direct = direct
+	return frr
+}
+
+func (frr *FinancialRecordRequest) Serialize() ([]byte, error) {
+	param := map[string]interface{}{
+		"mar_acct": frr.marginAccount,
+	}
+
+	if frr.contractCode != "" {
+		param["contract"] = frr.contractCode
+	}
+
+	if len(frr.typ) != 0 {
+		param["type"] = strings.Join(frr.typ, ",")
+	}
+
+	if frr.startTime != 0 {
+		param["start_time"] = frr.startTime
+	}
+
+	if frr.endTime != 0 {
+		param["end_time"] = frr.endTime
+	}
+
+	if frr.fromID != 0 {
+		param["from_id"] = frr.fromID
+	}
+
+	if frr.direct != "" {
+		param["direct"] = frr.direct
+	}
+
+	return json.Marshal(param)
+}
+
+func (rc *RestClient) FinancialRecord(ctx context.Context, req *FinancialRecordRequest) (*FinancialRecordResponse, error) {
+	var ret FinancialRecordResponse
+
+	body, err := req.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "serialzie request fail")
+	}
+	if err := rc.RequestWithRawResp(ctx, http.MethodPost, FinancialRecordEndPoint, nil, bytes.NewBuffer(body), true, &ret); err != nil {
+		return nil, errors.WithMessage(err, "fetch financial record fail")
+	}
+
+	if ret.Code != huobi.CodeOK {
+		return nil, errors.Errorf("error response code=%d msg=%s", ret.Code, ret.Msg)
+	}
+
+	return &ret, nil
+}
+
+// Finance fetch funding records, symbol is required for isolated mode
+func (rc *RestClient) Finance(ctx context.Context, params *exchange.FinanceReqParam) ([]exchange.Finance, error) {
+	if params.Type != exchange.FinanceTypeFunding {
+		return nil, errors.Errorf("unsupport type '%d'", params.Type)
+	}
+
+	var req *FinancialRecordRequest
+	if rc.marginMode == MarginModeIsolated {
+		if params.Symbol == nil {
+			return nil, errors.Errorf("symbol is required")
+		}
+		req = NewFinancialRecordRequest(params.Symbol.String())
+	} else {
+		req = NewFinancialRecordRequest(CrossMarginAccount)
+	}
+
+	if params.Symbol != nil {
+		req.Contract(params.Symbol.String())
+	}
+	if !params.StartTime.IsZero() {
+		req.StartTime(params.StartTime.UnixNano() / 1e6)
+	}
+	if !params.EndTime.IsZero() {
+		req.EndTime(params.EndTime.UnixNano() / 1e6)
+	}
+	req.Type(FinancialRecordTypeFundingIncome, FinancialRecordTypeFundingOutCome)
+
+	records, err := rc.FinancialRecord(ctx, req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "fetch financial_record fail")
+	}
+
+	var ret []exchange.Finance
+	for i := range records.Data {
+		rec, err := records.Data[i].Transform()
+		if err != nil {
+			return nil, errors.WithMessage(err, "parse financial_record fail")
+		}
+		ret = append(ret, *rec)
+	}
+	return ret, nil
+}
+
+// Transform financial record into exchange.Finance, currently only funding type is support
+func (fr *FinancialRecord) Transform() (*exchange.Finance, error) {
+	symbol, err := ParseSymbol(fr.ContractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if fr.Type != FinancialRecordTypeFundingIncome && fr.Type != FinancialRecordTypeFundingOutCome {
+		return nil, errors.Errorf("unsupport type %d", fr.Type)
+	}
+
+	return &exchange.Finance{
+		ID:       fmt.Sprintf("%d", fr.ID),
+		Symbol:   symbol,
+		Currency: fr.Asset,
+		Amount:   fr.Amount,
+		Type:     exchange.FinanceTypeFunding,
+		Time:     huobi.ParseTS(fr.TS),
+		Raw:      fr,
+	}, nil
+}
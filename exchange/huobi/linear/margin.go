@@ -0,0 +1,132 @@
+package linear
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	SwitchLeverRateReq struct {
+		ContractCode string `json:"contract_code"`
+		LeverRate    int    `json:"lever_rate"`
+	}
+
+	SwitchLeverRateResp struct {
+		ContractCode string `json:"contract_code"`
+		MarginMode   string `json:"margin_mode"`
+		LeverRate    int    `json:"lever_rate"`
+	}
+)
+
+const (
+	SwitchLeverRateEndPoint      = "/linear-swap-api/v1/swap_switch_lever_rate"
+	CrossSwitchLeverRateEndPoint = "/linear-swap-api/v1/swap_cross_switch_lever_rate"
+
+	// ErrCodePositionExists leverage can not be switched due to positions or open orders
+	ErrCodePositionExists = 1045
+)
+
+func NewSwitchLeverRateReq(contractCode string, lever int) *SwitchLeverRateReq {
+	return &SwitchLeverRateReq{
+		ContractCode: contractCode,
+		LeverRate:    lever,
+	}
+}
+
+// SwitchLeverRate switch lever rate of the contract by the margin mode of the client
+func (rc *RestClient) SwitchLeverRate(ctx context.Context, req *SwitchLeverRateReq) (*SwitchLeverRateResp, error) {
+	var resp SwitchLeverRateResp
+	ep := rc.endPoint(SwitchLeverRateEndPoint, CrossSwitchLeverRateEndPoint)
+	if err := rc.PrivatePostReq(ctx, ep, req, &resp); err != nil {
+		rc.clearLeverRate(req.ContractCode)
+		var ae *huobi.APIError
+		if errors.As(err, &ae) && ae.Code == ErrCodePositionExists {
+			err = exchange.NewPositionExists(err)
+		}
+		return nil, errors.WithMessage(err, "switch lever rate fail")
+	}
+
+	rc.mu.Lock()
+	rc.levers[resp.ContractCode] = resp.LeverRate
+	rc.mu.Unlock()
+	return &resp, nil
+}
+
+// SetLeverage switch lever rate of the symbol, the leverage should be integer
+func (rc *RestClient) SetLeverage(ctx context.Context, sym exchange.Symbol, leverage decimal.Decimal) error {
+	if !leverage.Equal(leverage.Truncate(0)) {
+		return exchange.NewBadArg("leverage should be integer", leverage)
+	}
+	_, err := rc.SwitchLeverRate(ctx, NewSwitchLeverRateReq(sym.String(), int(leverage.IntPart())))
+	return err
+}
+
+func (rc *RestClient) GetLeverage(ctx context.Context, sym exchange.Symbol) (decimal.Decimal, error) {
+	lever, err := rc.leverRate(ctx, sym.String())
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromInt(int64(lever)), nil
+}
+
+// leverRate return lever_rate of the contract such as BTC-USDT, which is read
+// from the isolated account or the contract detail of the cross account
+func (rc *RestClient) leverRate(ctx context.Context, contractCode string) (int, error) {
+	rc.mu.Lock()
+	lever, ok := rc.levers[contractCode]
+	rc.mu.Unlock()
+	if ok {
+		return lever, nil
+	}
+
+	req := NewAccountInfoReq()
+	if rc.marginMode == MarginModeIsolated {
+		req.ContractCode(contractCode)
+	} else {
+		parts := strings.SplitN(contractCode, "-", 2)
+		if len(parts) != 2 {
+			return 0, errors.Errorf("bad contract code '%s'", contractCode)
+		}
+		req.MarginAccount(parts[1])
+	}
+
+	infos, err := rc.AccountInfo(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	found := false
+	for _, info := range infos {
+		if rc.marginMode == MarginModeIsolated {
+			if info.ContractCode == contractCode {
+				lever, found = int(info.LeverRate.IntPart()), true
+			}
+			continue
+		}
+
+		for _, cd := range info.ContractDetail {
+			if cd.ContractCode == contractCode {
+				lever, found = int(cd.LeverRate.IntPart()), true
+			}
+		}
+	}
+	if !found {
+		return 0, errors.Errorf("no account info for '%s'", contractCode)
+	}
+
+	rc.mu.Lock()
+	rc.levers[contractCode] = lever
+	rc.mu.Unlock()
+	return lever, nil
+}
+
+func (rc *RestClient) clearLeverRate(contractCode string) {
+	rc.mu.Lock()
+	delete(rc.levers, contractCode)
+	rc.mu.Unlock()
+}
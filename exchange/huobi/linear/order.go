@@ -0,0 +1,438 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+	"github.com/szmcdull/ccexgo/exchange/huobi/future"
+)
+
+type (
+	OrderReq struct {
+		data map[string]interface{}
+	}
+
+	OrderResp struct {
+		OrderID       int64  `json:"order_id"`
+		OrderIDStr    string `json:"order_id_str"`
+		ClientOrderID int64  `json:"client_order_id"`
+	}
+
+	CancelReq struct {
+		contractCode   string
+		orderIDS       []string
+		clientOrderIDS []string
+	}
+
+	CancelError struct {
+		OrderID string `json:"order_id"`
+		ErrCode int    `json:"err_code"`
+		ErrMsg  string `json:"err_msg"`
+	}
+
+	CancelResp struct {
+		Errors    []CancelError `json:"errors"`
+		Successes string        `json:"successes"` //id1,id2,id3 ...
+	}
+
+	OrderInfoReq struct {
+		contractCode   string
+		orderIDS       []string
+		clientOrderIDS []string
+	}
+
+	// Order is shared by rest order info and orders notify
+	Order struct {
+		Symbol         string          `json:"symbol"`
+		ContractCode   string          `json:"contract_code"`
+		Volume         decimal.Decimal `json:"volume"`
+		Price          decimal.Decimal `json:"price"`
+		OrderPriceType string          `json:"order_price_type"`
+		OrderType      int             `json:"order_type"`
+		Direction      string          `json:"direction"`
+		Offset         string          `json:"offset"`
+		LeverRate      int             `json:"lever_rate"`
+		OrderID        int64           `json:"order_id"`
+		OrderIDStr     string          `json:"order_id_str"`
+		ClientOrderID  int64           `json:"client_order_id"`
+		CreatedAt      int64           `json:"created_at"`
+		CanceledAt     int64           `json:"canceled_at"`
+		TradeVolume    decimal.Decimal `json:"trade_volume"`
+		TradeTurnOver  decimal.Decimal `json:"trade_turnover"`
+		Fee            decimal.Decimal `json:"fee"`
+		FeeAsset       string          `json:"fee_asset"`
+		TradeAvgPrice  decimal.Decimal `json:"trade_avg_price"`
+		MarginFrozen   decimal.Decimal `json:"margin_frozen"`
+		Profit         decimal.Decimal `json:"profit"`
+		RealProfit     decimal.Decimal `json:"real_profit"`
+		Status         int             `json:"status"`
+		OrderSource    string          `json:"order_source"`
+		MarginAsset    string          `json:"margin_asset"`
+		MarginMode     MarginMode      `json:"margin_mode"`
+		MarginAccount  string          `json:"margin_account"`
+	}
+
+	OrderDetailReq struct {
+		data map[string]interface{}
+	}
+
+	OrderTrade struct {
+		ID            string          `json:"id"`
+		TradeID       int64           `json:"trade_id"`
+		TradeVolume   decimal.Decimal `json:"trade_volume"`
+		TradePrice    decimal.Decimal `json:"trade_price"`
+		TradeFee      decimal.Decimal `json:"trade_fee"`
+		FeeAsset      string          `json:"fee_asset"`
+		TradeTurnOver decimal.Decimal `json:"trade_turnover"`
+		Role          string          `json:"role"`
+		CreatedAt     int64           `json:"created_at"`
+	}
+
+	OrderDetail struct {
+		Order
+		Trades      []OrderTrade `json:"trades"`
+		TotalPage   int          `json:"total_page"`
+		CurrentPage int          `json:"current_page"`
+		TotalSize   int          `json:"total_size"`
+	}
+)
+
+const (
+	OrderEndPoint            = "/linear-swap-api/v1/swap_order"
+	CrossOrderEndPoint       = "/linear-swap-api/v1/swap_cross_order"
+	CancelEndPoint           = "/linear-swap-api/v1/swap_cancel"
+	CrossCancelEndPoint      = "/linear-swap-api/v1/swap_cross_cancel"
+	OrderInfoEndPoint        = "/linear-swap-api/v1/swap_order_info"
+	CrossOrderInfoEndPoint   = "/linear-swap-api/v1/swap_cross_order_info"
+	OrderDetailEndPoint      = "/linear-swap-api/v1/swap_order_detail"
+	CrossOrderDetailEndPoint = "/linear-swap-api/v1/swap_cross_order_detail"
+)
+
+var (
+	statusMap = map[int]exchange.OrderStatus{
+		1:  exchange.OrderStatusOpen,
+		2:  exchange.OrderStatusOpen,
+		3:  exchange.OrderStatusOpen,
+		4:  exchange.OrderStatusOpen,
+		5:  exchange.OrderStatusCancel,
+		6:  exchange.OrderStatusDone,
+		7:  exchange.OrderStatusCancel,
+		11: exchange.OrderStatusOpen,
+	}
+
+	typeMap = map[string]exchange.OrderType{
+		future.OrderPriceLimit:    exchange.OrderTypeLimit,
+		future.OrderPricePostOnly: exchange.OrderTypeLimit,
+		future.OrderPriceMarket:   exchange.OrderTypeMarket,
+		future.OrderPriceOptimal5: exchange.OrderTypeMarket,
+		future.OrderPriceIOC:      exchange.OrderTypeLimit,
+		future.OrderPriceFOK:      exchange.OrderTypeLimit,
+	}
+)
+
+// NewOrderReq build order request, volume is in contracts
+func NewOrderReq(contractCode string, volume int, direction string, offset string, lever int, orderPriceType string) *OrderReq {
+	ret := OrderReq{
+		data: make(map[string]interface{}),
+	}
+
+	ret.data["contract_code"] = contractCode
+	ret.data["volume"] = volume
+	ret.data["direction"] = direction
+	ret.data["offset"] = offset
+	ret.data["lever_rate"] = lever
+	ret.data["order_price_type"] = orderPriceType
+	return &ret
+}
+
+func (or *OrderReq) Price(price float64) *OrderReq {
+	or.data["price"] = price
+	return or
+}
+
+func (or *OrderReq) ClientOrderID(id int64) *OrderReq {
+	or.data["client_order_id"] = id
+	return or
+}
+
+func (or *OrderReq) Serialize() ([]byte, error) {
+	return json.Marshal(or.data)
+}
+
+func NewCancelReq(contractCode string) *CancelReq {
+	return &CancelReq{
+		contractCode: contractCode,
+	}
+}
+
+func (cr *CancelReq) Orders(ids ...string) *CancelReq {
+	cr.orderIDS = append(cr.orderIDS, ids...)
+	return cr
+}
+
+func (cr *CancelReq) ClientOrderIDs(ids ...string) *CancelReq {
+	cr.clientOrderIDS = append(cr.clientOrderIDS, ids...)
+	return cr
+}
+
+func (cr *CancelReq) Serialize() ([]byte, error) {
+	return serializeIDs(cr.contractCode, cr.orderIDS, cr.clientOrderIDS)
+}
+
+func NewOrderInfoReq(contractCode string) *OrderInfoReq {
+	return &OrderInfoReq{
+		contractCode: contractCode,
+	}
+}
+
+func (oir *OrderInfoReq) Orders(ids ...string) *OrderInfoReq {
+	oir.orderIDS = append(oir.orderIDS, ids...)
+	return oir
+}
+
+func (oir *OrderInfoReq) ClientOrderIDs(ids ...string) *OrderInfoReq {
+	oir.clientOrderIDS = append(oir.clientOrderIDS, ids...)
+	return oir
+}
+
+func (oir *OrderInfoReq) Serialize() ([]byte, error) {
+	return serializeIDs(oir.contractCode, oir.orderIDS, oir.clientOrderIDS)
+}
+
+func NewOrderDetailReq(contractCode string, id int64) *OrderDetailReq {
+	return &OrderDetailReq{
+		data: map[string]interface{}{
+			"contract_code": contractCode,
+			"order_id":      id,
+		},
+	}
+}
+
+func (odr *OrderDetailReq) CreatedAt(ts int64) *OrderDetailReq {
+	odr.data["created_at"] = ts
+	return odr
+}
+
+func (odr *OrderDetailReq) OrderType(ot int) *OrderDetailReq {
+	odr.data["order_type"] = ot
+	return odr
+}
+
+func (odr *OrderDetailReq) PageIndex(pi int) *OrderDetailReq {
+	odr.data["page_index"] = pi
+	return odr
+}
+
+func (odr *OrderDetailReq) PageSize(ps int) *OrderDetailReq {
+	odr.data["page_size"] = ps
+	return odr
+}
+
+func (odr *OrderDetailReq) Serialize() ([]byte, error) {
+	return json.Marshal(odr.data)
+}
+
+func (rc *RestClient) AddOrder(ctx context.Context, req *OrderReq) (*OrderResp, error) {
+	var resp OrderResp
+	if err := rc.PrivatePostReq(ctx, rc.endPoint(OrderEndPoint, CrossOrderEndPoint), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (rc *RestClient) DeleteOrder(ctx context.Context, req *CancelReq) (*CancelResp, error) {
+	var resp CancelResp
+	if err := rc.PrivatePostReq(ctx, rc.endPoint(CancelEndPoint, CrossCancelEndPoint), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (rc *RestClient) GetOrder(ctx context.Context, req *OrderInfoReq) ([]Order, error) {
+	var resp []Order
+	if err := rc.PrivatePostReq(ctx, rc.endPoint(OrderInfoEndPoint, CrossOrderInfoEndPoint), req, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (rc *RestClient) OrderDetail(ctx context.Context, req *OrderDetailReq) (*OrderDetail, error) {
+	var resp OrderDetail
+	if err := rc.PrivatePostReq(ctx, rc.endPoint(OrderDetailEndPoint, CrossOrderDetailEndPoint), req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// FetchOrder fetch order by ID, ClientID is used if ID is not set
+func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req := NewOrderInfoReq(order.Symbol.String())
+	if order.ID != nil {
+		req.Orders(order.ID.String())
+	} else if order.ClientID != nil {
+		req.ClientOrderIDs(order.ClientID.String())
+	} else {
+		return nil, errors.Errorf("order id or client id is required")
+	}
+
+	orders, err := rc.GetOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return nil, huobi.NewAPIError(huobi.ErrCodeOrderNotExist, "order not exist")
+	}
+	return orders[0].Transform()
+}
+
+// CancelOrder cancel order by ID, ClientID is used if ID is not set
+func (rc *RestClient) CancelOrder(ctx context.Context, order *exchange.Order) error {
+	req := NewCancelReq(order.Symbol.String())
+	if order.ID != nil {
+		req.Orders(order.ID.String())
+	} else if order.ClientID != nil {
+		req.ClientOrderIDs(order.ClientID.String())
+	} else {
+		return errors.Errorf("order id or client id is required")
+	}
+
+	resp, err := rc.DeleteOrder(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Errors) != 0 {
+		e := resp.Errors[0]
+		return errors.WithMessage(huobi.NewAPIError(e.ErrCode, e.ErrMsg), "cancel order fail")
+	}
+	return nil
+}
+
+// CreateOrder create order with exchange.OrderRequest by the margin mode of the client,
+// the amount is the number of contracts and lever_rate is the current leverage of the
+// contract. client id should be integer
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	side, err := exchange.NewTradeSide(req.Side, true)
+	if err != nil {
+		return nil, err
+	}
+	direction, offset := future.DirectionOffset(side)
+
+	param, err := huobi.NewContractOrderParam(req, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	code := req.Symbol.String()
+	lever, err := rc.leverRate(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	oReq := NewOrderReq(code, param.Volume, direction, offset, lever, param.PriceType)
+	if req.Type == exchange.OrderTypeLimit {
+		oReq.Price(param.Price)
+	}
+	if param.HasClientID {
+		oReq.ClientOrderID(param.ClientOrderID)
+	}
+
+	resp, err := rc.AddOrder(ctx, oReq)
+	if err != nil {
+		// lever_rate may be changed by others
+		rc.clearLeverRate(code)
+		return nil, err
+	}
+	return huobi.NewCreatedOrder(req, resp.OrderID, resp), nil
+}
+
+// Transform huobi linear swap order into exchange.Order, amount is in contracts
+func (o *Order) Transform() (*exchange.Order, error) {
+	symbol, err := ParseSymbol(o.ContractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := future.ParseSide(o.Direction, o.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	st, ok := statusMap[o.Status]
+	if !ok {
+		return nil, errors.Errorf("unkown order status %d", o.Status)
+	}
+
+	typ, ok := typeMap[o.OrderPriceType]
+	if !ok {
+		return nil, errors.Errorf("unkown order type %s", o.OrderPriceType)
+	}
+
+	ret := &exchange.Order{
+		ID:          exchange.NewIntID(o.OrderID),
+		Symbol:      symbol,
+		Amount:      o.Volume,
+		Filled:      o.TradeVolume,
+		Price:       o.Price,
+		AvgPrice:    o.TradeAvgPrice,
+		Fee:         o.Fee,
+		FeeCurrency: o.FeeAsset,
+		Created:     huobi.ParseTS(o.CreatedAt),
+		Side:        side,
+		Status:      st,
+		Type:        typ,
+		Raw:         o,
+	}
+
+	if o.ClientOrderID != 0 {
+		ret.ClientID = exchange.NewIntID(o.ClientOrderID)
+	}
+
+	if o.CanceledAt != 0 {
+		ret.Updated = huobi.ParseTS(o.CanceledAt)
+	} else {
+		ret.Updated = ret.Created
+	}
+	return ret, nil
+}
+
+// Transform order detail into exchange.Order, updated time is set to the last trade time
+func (od *OrderDetail) Transform() (*exchange.Order, error) {
+	ret, err := od.Order.Transform()
+	if err != nil {
+		return nil, err
+	}
+
+	if od.CanceledAt == 0 {
+		for _, t := range od.Trades {
+			ts := huobi.ParseTS(t.CreatedAt)
+			if ts.After(ret.Updated) {
+				ret.Updated = ts
+			}
+		}
+	}
+	ret.Raw = od
+	return ret, nil
+}
+
+func serializeIDs(contractCode string, orderIDS []string, clientOrderIDS []string) ([]byte, error) {
+	data := map[string]string{
+		"contract_code": contractCode,
+	}
+
+	if len(orderIDS) != 0 {
+		data["order_id"] = strings.Join(orderIDS, ",")
+	}
+
+	if len(clientOrderIDS) != 0 {
+		data["client_order_id"] = strings.Join(clientOrderIDS, ",")
+	}
+
+	return json.Marshal(data)
+}
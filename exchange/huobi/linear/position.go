@@ -0,0 +1,135 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi/future"
+)
+
+type (
+	// Position is shared by rest position info and positions notify
+	Position struct {
+		Symbol         string          `json:"symbol"`
+		ContractCode   string          `json:"contract_code"`
+		Volume         decimal.Decimal `json:"volume"`
+		Available      decimal.Decimal `json:"available"`
+		Frozen         decimal.Decimal `json:"frozen"`
+		CostOpen       decimal.Decimal `json:"cost_open"`
+		CostHold       decimal.Decimal `json:"cost_hold"`
+		ProfitUnreal   decimal.Decimal `json:"profit_unreal"`
+		ProfitRate     decimal.Decimal `json:"profit_rate"`
+		LeverRate      int             `json:"lever_rate"`
+		PositionMargin decimal.Decimal `json:"position_margin"`
+		Direction      string          `json:"direction"`
+		Profit         decimal.Decimal `json:"profit"`
+		LastPrice      decimal.Decimal `json:"last_price"`
+		MarginAsset    string          `json:"margin_asset"`
+		MarginMode     MarginMode      `json:"margin_mode"`
+		MarginAccount  string          `json:"margin_account"`
+	}
+
+	PositionInfoReq struct {
+		ContractCode string `json:"contract_code"`
+	}
+)
+
+const (
+	PositionInfoEndPoint      = "/linear-swap-api/v1/swap_position_info"
+	CrossPositionInfoEndPoint = "/linear-swap-api/v1/swap_cross_position_info"
+)
+
+// NewPositionInfoReq build position request, all positions are returned if code is empty
+func NewPositionInfoReq(code string) *PositionInfoReq {
+	return &PositionInfoReq{
+		ContractCode: code,
+	}
+}
+
+func (pir *PositionInfoReq) Serialize() ([]byte, error) {
+	if pir.ContractCode != "" {
+		return json.Marshal(pir)
+	}
+	return []byte("{}"), nil
+}
+
+func (rc *RestClient) PositionInfo(ctx context.Context, req *PositionInfoReq) ([]Position, error) {
+	var ret []Position
+	ep := rc.endPoint(PositionInfoEndPoint, CrossPositionInfoEndPoint)
+	if err := rc.PrivatePostReq(ctx, ep, req, &ret); err != nil {
+		return nil, errors.WithMessage(err, "get position info fail")
+	}
+
+	return ret, nil
+}
+
+// FetchPosition fetch positions of the symbols, all positions are returned if no symbol is given
+func (rc *RestClient) FetchPosition(ctx context.Context, syms ...exchange.Symbol) ([]*exchange.Position, error) {
+	var code string
+	if len(syms) == 1 {
+		code = syms[0].String()
+	}
+
+	positions, err := rc.PositionInfo(ctx, NewPositionInfoReq(code))
+	if err != nil {
+		return nil, err
+	}
+
+	want := map[string]struct{}{}
+	for _, s := range syms {
+		want[s.String()] = struct{}{}
+	}
+
+	var ret []*exchange.Position
+	for i := range positions {
+		p, err := positions[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(want) != 0 {
+			if _, ok := want[p.Symbol.String()]; !ok {
+				continue
+			}
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func (p *Position) Transfer() (*exchange.Position, error) {
+	sym, err := ParseSymbol(p.ContractCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var side exchange.PositionSide
+	if p.Direction == future.OrderDirectionBuy {
+		side = exchange.PositionSideLong
+	} else if p.Direction == future.OrderDirectionSell {
+		side = exchange.PositionSideShort
+	} else {
+		return nil, errors.Errorf("unkown direction '%s'", p.Direction)
+	}
+
+	var mode exchange.PositionMode = exchange.PositionModeCross
+	if p.MarginMode == MarginModeIsolated {
+		mode = exchange.PositionModeFixed
+	}
+
+	return &exchange.Position{
+		Symbol:        sym,
+		Side:          side,
+		Mode:          mode,
+		Position:      p.Volume,
+		AvailPosition: p.Available,
+		AvgOpenPrice:  p.CostHold,
+		UNRealizedPNL: p.ProfitUnreal,
+		Margin:        p.PositionMargin,
+		Leverage:      decimal.NewFromInt(int64(p.LeverRate)),
+		Raw:           p,
+	}, nil
+}
@@ -0,0 +1,432 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+type (
+	PrivateCodeC struct {
+		*huobi.CodeC
+	}
+
+	PrivateWSClient struct {
+		key    string
+		secret string
+		*exchange.WSClient
+		data chan interface{}
+	}
+
+	Response struct {
+		Op      string `json:"op"`
+		Topic   string `json:"topic"`
+		Cid     string `json:"cid"`
+		ErrCode int    `json:"err-code"`
+	}
+
+	subParam struct {
+		Op    string `json:"op"`
+		Cid   string `json:"cid"`
+		Topic string `json:"topic"`
+	}
+
+	PingResponse struct {
+		Op string `json:"op"`
+		TS string `json:"ts"`
+	}
+
+	AuthResponse struct {
+		Op      string `json:"op"`
+		ErrCode int    `json:"err-code"`
+		TS      int64  `json:"ts"`
+		ErrMsg  string `json:"err-msg"`
+	}
+
+	OrderNotify struct {
+		Order
+		Op     string       `json:"op"`
+		Topic  string       `json:"topic"`
+		TS     int64        `json:"ts"`
+		Trades []OrderTrade `json:"trade"`
+	}
+
+	PositionsNotify struct {
+		Op    string     `json:"op"`
+		Topic string     `json:"topic"`
+		TS    int64      `json:"ts"`
+		Event string     `json:"event"`
+		Data  []Position `json:"data"`
+	}
+
+	AccountsNotify struct {
+		Op    string        `json:"op"`
+		Topic string        `json:"topic"`
+		TS    int64         `json:"ts"`
+		Event string        `json:"event"`
+		Data  []AccountInfo `json:"data"`
+	}
+
+	// OrderUpdate is emitted by both orders and matchOrders topic. Fee of the trades
+	// is only set for the orders topic since matchOrders does not push trade_fee
+	OrderUpdate = huobi.OrderUpdate
+
+	// privateChannel topic of isolated or cross margin account
+	privateChannel struct {
+		isolated string
+		cross    string
+		mode     MarginMode
+		code     string
+	}
+)
+
+const (
+	LinearPrivateAddr = "wss://api.hbdm.com/linear-swap-notification"
+	linearPrivatePath = "/linear-swap-notification"
+
+	TopicOrders           = "orders"
+	TopicOrdersCross      = "orders_cross"
+	TopicMatchOrders      = "matchOrders"
+	TopicMatchOrdersCross = "matchOrders_cross"
+	TopicAccounts         = "accounts"
+	TopicAccountsCross    = "accounts_cross"
+	TopicPositions        = "positions"
+	TopicPositionsCross   = "positions_cross"
+
+	RoleMaker = "maker"
+
+	// AllContracts subscribe notify of all contracts
+	AllContracts = "*"
+)
+
+// NewOrdersChannel return orders channel of the contract, AllContracts can be used as code
+func NewOrdersChannel(mode MarginMode, code string) exchange.Channel {
+	return newPrivateChannel(TopicOrders, TopicOrdersCross, mode, code)
+}
+
+// NewMatchOrdersChannel return matchOrders channel of the contract, AllContracts can be used as code
+func NewMatchOrdersChannel(mode MarginMode, code string) exchange.Channel {
+	return newPrivateChannel(TopicMatchOrders, TopicMatchOrdersCross, mode, code)
+}
+
+// NewAccountsChannel return accounts channel, code is the contract such as BTC-USDT
+// for isolated account and the margin account such as USDT for cross account,
+// AllContracts can be used as code
+func NewAccountsChannel(mode MarginMode, code string) exchange.Channel {
+	return newPrivateChannel(TopicAccounts, TopicAccountsCross, mode, code)
+}
+
+// NewPositionsChannel return positions channel of the contract, AllContracts can be used as code
+func NewPositionsChannel(mode MarginMode, code string) exchange.Channel {
+	return newPrivateChannel(TopicPositions, TopicPositionsCross, mode, code)
+}
+
+func newPrivateChannel(isolated, cross string, mode MarginMode, code string) exchange.Channel {
+	return &privateChannel{
+		isolated: isolated,
+		cross:    cross,
+		mode:     mode,
+		code:     code,
+	}
+}
+
+func (pc *privateChannel) String() string {
+	if pc.mode == MarginModeIsolated {
+		return fmt.Sprintf("%s.%s", pc.isolated, pc.code)
+	}
+	return fmt.Sprintf("%s.%s", pc.cross, pc.code)
+}
+
+func NewPrivateCodeC() *PrivateCodeC {
+	return &PrivateCodeC{
+		CodeC: huobi.NewCodeC(),
+	}
+}
+
+func (pcc *PrivateCodeC) Encode(req rpc.Request) ([]byte, error) {
+	param := req.Params()
+	r, e := json.Marshal(param)
+	return r, e
+}
+
+func (pcc *PrivateCodeC) Decode(raw []byte) (rpc.Response, error) {
+	msg, err := pcc.Decompress(raw)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrivateMessage(msg)
+}
+
+// ParsePrivateMessage parse decompressed message, orders and matchOrders notify is
+// parsed into *OrderUpdate, accounts notify is parsed into *exchange.Balances and
+// positions notify is parsed into []*exchange.Position
+func ParsePrivateMessage(msg []byte) (rpc.Response, error) {
+	var resp Response
+	if err := json.Unmarshal(msg, &resp); err != nil {
+		return nil, err
+	}
+
+	switch resp.Op {
+	case "ping":
+		var pr PingResponse
+		if err := json.Unmarshal(msg, &pr); err != nil {
+			return nil, err
+		}
+		return &rpc.Notify{
+			Method: huobi.MethodPong,
+			Params: pr.TS,
+		}, nil
+
+	case "auth":
+		var ar AuthResponse
+		if err := json.Unmarshal(msg, &ar); err != nil {
+			return nil, err
+		}
+		var err error
+		if ar.ErrCode != 0 {
+			err = errors.Errorf("error happend %s", string(msg))
+		}
+
+		return &rpc.Result{
+			ID:     "auth",
+			Error:  err,
+			Result: msg,
+		}, nil
+
+	case "sub":
+		var err error
+		if resp.ErrCode != 0 {
+			err = errors.Errorf("error happend %s", string(msg))
+		}
+		return &rpc.Result{
+			ID:     resp.Cid,
+			Error:  err,
+			Result: msg,
+		}, nil
+
+	case "notify":
+		var (
+			r   interface{}
+			err error
+		)
+		topic := strings.SplitN(resp.Topic, ".", 2)[0]
+		switch topic {
+		case TopicOrders, TopicOrdersCross:
+			r, err = ParseOrderUpdate(msg)
+
+		case TopicMatchOrders, TopicMatchOrdersCross:
+			r, err = ParseMatchOrderUpdate(msg)
+
+		case TopicAccounts, TopicAccountsCross:
+			r, err = ParseAccounts(msg)
+
+		case TopicPositions, TopicPositionsCross:
+			r, err = parsePositionsNotify(msg)
+
+		default:
+			return nil, errors.Errorf("unkown topic %s", resp.Topic)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &rpc.Notify{
+			Method: resp.Topic,
+			Params: r,
+		}, nil
+	}
+
+	return nil, errors.Errorf("unkown op %s", resp.Op)
+}
+
+func NewPrivateWSClient(key, secret string, data chan interface{}) *PrivateWSClient {
+	ret := &PrivateWSClient{
+		key:    key,
+		secret: secret,
+		data:   data,
+	}
+
+	ret.WSClient = exchange.NewWSClient(LinearPrivateAddr, NewPrivateCodeC(), ret)
+	return ret
+}
+
+func (ws *PrivateWSClient) Run(ctx context.Context) error {
+	if err := ws.WSClient.Run(ctx); err != nil {
+		return err
+	}
+	return ws.Auth(ctx)
+}
+
+func (ws *PrivateWSClient) Auth(ctx context.Context) error {
+	param := ws.genSignatureParmas()
+	var resp Response
+	if err := ws.Call(ctx, "auth", "", param, &resp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Subscribe subscribe channels one by one and wait for the response, the topic
+// is used as cid
+func (ws *PrivateWSClient) Subscribe(ctx context.Context, channels ...exchange.Channel) error {
+	for _, ch := range channels {
+		param := subParam{
+			Op:    "sub",
+			Cid:   ch.String(),
+			Topic: ch.String(),
+		}
+
+		var resp Response
+		if err := ws.Call(ctx, param.Cid, "", param, &resp); err != nil {
+			return errors.WithMessagef(err, "subscribe %s fail", param.Topic)
+		}
+	}
+	return nil
+}
+
+func (ws *PrivateWSClient) Handle(ctx context.Context, notify *rpc.Notify) {
+	if notify.Method == huobi.MethodPong {
+		go func() {
+			ws.Call(ctx, "", "", map[string]interface{}{
+				"op": "pong",
+				"ts": notify.Params,
+			}, nil)
+		}()
+		return
+	}
+
+	d := exchange.WSNotify{
+		Exchange: huobi.Huobi,
+		Chan:     notify.Method,
+		Data:     notify.Params,
+	}
+	select {
+	case ws.data <- &d:
+	default:
+	}
+}
+
+func (ws *PrivateWSClient) genSignatureParmas() map[string]string {
+	ts := time.Now().UTC()
+	ret := map[string]string{
+		"AccessKeyId":      ws.key,
+		"SignatureMethod":  "HmacSHA256",
+		"SignatureVersion": "2",
+		"Timestamp":        ts.Format("2006-01-02T15:04:05"),
+	}
+	values := url.Values{}
+	for k, v := range ret {
+		values.Add(k, v)
+	}
+	sig := huobi.Signature(ws.secret, http.MethodGet, LinearHost, linearPrivatePath, values.Encode())
+
+	ret["Signature"] = sig
+	ret["type"] = "api"
+	ret["op"] = "auth"
+	return ret
+}
+
+func parseOrderNotify(msg []byte) (*exchange.Order, error) {
+	var on OrderNotify
+	if err := json.Unmarshal(msg, &on); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal orders notify fail")
+	}
+
+	order, err := on.Transform()
+	if err != nil {
+		return nil, err
+	}
+
+	if on.CanceledAt == 0 {
+		for _, t := range on.Trades {
+			ts := huobi.ParseTS(t.CreatedAt)
+			if ts.After(order.Updated) {
+				order.Updated = ts
+			}
+		}
+	}
+	order.Raw = &on
+	return order, nil
+}
+
+// ParseOrderUpdate parse orders notify into order and its trades with fee
+func ParseOrderUpdate(msg []byte) (*OrderUpdate, error) {
+	return parseOrderUpdate(msg, true)
+}
+
+// ParseMatchOrderUpdate parse matchOrders notify into order and its trades,
+// Fee and FeeCurrency of the trades are left unset
+func ParseMatchOrderUpdate(msg []byte) (*OrderUpdate, error) {
+	return parseOrderUpdate(msg, false)
+}
+
+func parseOrderUpdate(msg []byte, withFee bool) (*OrderUpdate, error) {
+	order, err := parseOrderNotify(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	notify := order.Raw.(*OrderNotify)
+	trades := make([]*exchange.Trade, len(notify.Trades))
+	for i := range notify.Trades {
+		trade := notify.Trades[i].Transform(order)
+		if withFee {
+			trade.Fee = notify.Trades[i].TradeFee
+			trade.FeeCurrency = notify.Trades[i].FeeAsset
+		}
+		trades[i] = trade
+	}
+
+	return &OrderUpdate{
+		Order:  order,
+		Trades: trades,
+	}, nil
+}
+
+// Transform trade of the order into exchange.Trade without fee, amount is in contracts
+func (ot *OrderTrade) Transform(order *exchange.Order) *exchange.Trade {
+	return huobi.NewOrderTrade(order, ot.ID, ot.TradePrice, ot.TradeVolume, ot.CreatedAt, ot.Role == RoleMaker, ot)
+}
+
+// ParseAccounts parse accounts notify into exchange.Balances, only changed accounts
+// are included
+func ParseAccounts(msg []byte) (*exchange.Balances, error) {
+	var an AccountsNotify
+	if err := json.Unmarshal(msg, &an); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal accounts notify fail")
+	}
+
+	ret := exchange.NewBalances()
+	for i := range an.Data {
+		ret.Add(an.Data[i].Transform())
+	}
+	ret.Raw = &an
+	return ret, nil
+}
+
+func parsePositionsNotify(msg []byte) ([]*exchange.Position, error) {
+	var pn PositionsNotify
+	if err := json.Unmarshal(msg, &pn); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal positions notify fail")
+	}
+
+	ret := make([]*exchange.Position, 0, len(pn.Data))
+	for i := range pn.Data {
+		p, err := pn.Data[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
@@ -0,0 +1,218 @@
+package linear
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func initTestSymbol() {
+	d := &Data{
+		Symbol:            "BTC",
+		ContractCode:      "BTC-USDT",
+		ContractSize:      decimal.RequireFromString("0.001"),
+		PriceTick:         decimal.RequireFromString("0.1"),
+		ContractStatus:    1,
+		SupportMarginMode: SupportMarginModeAll,
+	}
+	contractMap[d.ContractCode] = NewSymbol(d)
+}
+
+func TestParseOrderNotify(t *testing.T) {
+	initTestSymbol()
+	raw := `{
+		"op": "notify",
+		"topic": "orders_cross.btc-usdt",
+		"ts": 1639122053894,
+		"uid": "123456789",
+		"symbol": "BTC",
+		"contract_code": "BTC-USDT",
+		"volume": 10,
+		"price": 48000,
+		"order_price_type": "post_only",
+		"direction": "buy",
+		"offset": "open",
+		"status": 4,
+		"lever_rate": 5,
+		"order_id": 917361800293453824,
+		"order_id_str": "917361800293453824",
+		"client_order_id": 1001,
+		"order_source": "api",
+		"order_type": 1,
+		"created_at": 1639122053813,
+		"trade_volume": 3,
+		"trade_turnover": 144,
+		"fee": -0.0288,
+		"trade_avg_price": 48000,
+		"margin_frozen": 67.2,
+		"profit": 0,
+		"trade": [{
+			"id": "1-2",
+			"trade_id": 2,
+			"trade_volume": 3,
+			"trade_price": 48000,
+			"trade_fee": -0.0288,
+			"trade_turnover": 144,
+			"created_at": 1639122053890,
+			"role": "maker",
+			"fee_asset": "USDT"
+		}],
+		"canceled_at": 0,
+		"fee_asset": "USDT",
+		"margin_asset": "USDT",
+		"margin_mode": "cross",
+		"margin_account": "USDT",
+		"real_profit": 0
+	}`
+
+	resp, err := ParsePrivateMessage([]byte(raw))
+	if err != nil {
+		t.Fatalf("parse fail %s", err.Error())
+	}
+
+	notify := resp.(*rpc.Notify)
+	update := notify.Params.(*OrderUpdate)
+	order := update.Order
+	if notify.Method != "orders_cross.btc-usdt" || order.Side != exchange.OrderSideBuy ||
+		order.Status != exchange.OrderStatusOpen || order.Type != exchange.OrderTypeLimit ||
+		order.ClientID.String() != "1001" || !order.Filled.Equal(decimal.NewFromInt(3)) ||
+		order.Updated.UnixNano()/1e6 != 1639122053890 || order.Symbol.String() != "BTC-USDT" {
+		t.Errorf("bad order %+v", *order)
+	}
+	if len(update.Trades) != 1 || !update.Trades[0].Fee.Equal(decimal.RequireFromString("-0.0288")) ||
+		update.Trades[0].FeeCurrency != "USDT" {
+		t.Errorf("bad trades %+v", update.Trades)
+	}
+}
+
+func TestParsePositionsNotify(t *testing.T) {
+	initTestSymbol()
+	raw := `{
+		"op": "notify",
+		"topic": "positions.btc-usdt",
+		"ts": 1639122053894,
+		"event": "order.match",
+		"data": [{
+			"symbol": "BTC",
+			"contract_code": "BTC-USDT",
+			"volume": 3,
+			"available": 3,
+			"frozen": 0,
+			"cost_open": 48000,
+			"cost_hold": 48000,
+			"profit_unreal": 0.3,
+			"profit_rate": 0.001,
+			"profit": 0.3,
+			"margin_asset": "USDT",
+			"position_margin": 28.8,
+			"lever_rate": 5,
+			"direction": "sell",
+			"last_price": 47900,
+			"margin_mode": "isolated",
+			"margin_account": "BTC-USDT"
+		}]
+	}`
+
+	resp, err := ParsePrivateMessage([]byte(raw))
+	if err != nil {
+		t.Fatalf("parse fail %s", err.Error())
+	}
+
+	positions := resp.(*rpc.Notify).Params.([]*exchange.Position)
+	if len(positions) != 1 {
+		t.Fatalf("bad positions %+v", positions)
+	}
+
+	p := positions[0]
+	if p.Side != exchange.PositionSideShort || p.Mode != exchange.PositionModeFixed ||
+		!p.Position.Equal(decimal.NewFromInt(3)) || !p.Leverage.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("bad position %+v", *p)
+	}
+}
+
+func TestParseMatchOrdersNotify(t *testing.T) {
+	initTestSymbol()
+	raw := `{
+		"op": "notify",
+		"topic": "matchOrders_cross.btc-usdt",
+		"ts": 1639122053894,
+		"uid": "123456789",
+		"symbol": "BTC",
+		"contract_code": "BTC-USDT",
+		"status": 6,
+		"order_id": 917361800293453824,
+		"order_id_str": "917361800293453824",
+		"client_order_id": 1001,
+		"order_type": 1,
+		"trade_volume": 10,
+		"volume": 10,
+		"direction": "sell",
+		"offset": "close",
+		"lever_rate": 5,
+		"price": 48000,
+		"created_at": 1639122053813,
+		"order_source": "api",
+		"order_price_type": "limit",
+		"trade": [{
+			"id": "1-3",
+			"trade_id": 3,
+			"trade_volume": 10,
+			"trade_price": 48000,
+			"trade_turnover": 480,
+			"created_at": 1639122053890,
+			"role": "taker"
+		}],
+		"margin_mode": "cross",
+		"margin_account": "USDT"
+	}`
+
+	resp, err := ParsePrivateMessage([]byte(raw))
+	if err != nil {
+		t.Fatalf("parse fail %s", err.Error())
+	}
+
+	update := resp.(*rpc.Notify).Params.(*OrderUpdate)
+	if update.Order.Status != exchange.OrderStatusDone || update.Order.Side != exchange.OrderSideCloseLong {
+		t.Errorf("bad order %+v", *update.Order)
+	}
+	if len(update.Trades) != 1 || update.Trades[0].IsMaker || update.Trades[0].OrderID != "917361800293453824" ||
+		!update.Trades[0].Amount.Equal(decimal.NewFromInt(10)) || !update.Trades[0].Fee.IsZero() ||
+		update.Trades[0].FeeCurrency != "" {
+		t.Errorf("bad trades %+v", update.Trades)
+	}
+}
+
+func TestParseAccountsNotify(t *testing.T) {
+	raw := `{
+		"op": "notify",
+		"topic": "accounts_cross.usdt",
+		"ts": 1639122053894,
+		"event": "order.match",
+		"data": [{
+			"margin_mode": "cross",
+			"margin_account": "USDT",
+			"margin_asset": "USDT",
+			"margin_balance": 100,
+			"margin_static": 100,
+			"margin_position": 28.8,
+			"margin_frozen": 1.2,
+			"profit_real": 0,
+			"profit_unreal": 0.3,
+			"withdraw_available": 70,
+			"risk_rate": 3.4
+		}]
+	}`
+
+	resp, err := ParsePrivateMessage([]byte(raw))
+	if err != nil {
+		t.Fatalf("parse fail %s", err.Error())
+	}
+
+	balances := resp.(*rpc.Notify).Params.(*exchange.Balances)
+	b, err := balances.Get("USDT")
+	if err != nil || !b.Total.Equal(decimal.NewFromInt(100)) || !b.Free.Equal(decimal.NewFromInt(70)) {
+		t.Errorf("bad balance %+v", b)
+	}
+}
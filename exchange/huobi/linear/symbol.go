@@ -0,0 +1,107 @@
+package linear
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	Symbol struct {
+		*exchange.BaseSwapSymbol
+	}
+
+	Data struct {
+		Symbol            string          `json:"symbol"`
+		ContractCode      string          `json:"contract_code"`
+		ContractSize      decimal.Decimal `json:"contract_size"`
+		PriceTick         decimal.Decimal `json:"price_tick"`
+		CreateDate        string          `json:"create_date"`
+		ContractStatus    int             `json:"contract_status"`
+		SettlementDate    string          `json:"settlement_date"`
+		SupportMarginMode string          `json:"support_margin_mode"`
+	}
+
+	ContractInfo struct {
+		Status string `json:"status"`
+		TS     int64  `json:"ts"`
+		Data   []Data `json:"data"`
+	}
+)
+
+const (
+	ContractEndPoint = "/linear-swap-api/v1/swap_contract_info"
+
+	SupportMarginModeAll = "all"
+)
+
+var (
+	contractMap = map[string]*Symbol{}
+)
+
+// String return contract code such as BTC-USDT
+func (s *Symbol) String() string {
+	return s.Index()
+}
+
+// SupportMarginMode return whether the margin mode can be used to trade the symbol
+func (s *Symbol) SupportMarginMode(mode MarginMode) bool {
+	d := s.Raw().(*Data)
+	return d.SupportMarginMode == SupportMarginModeAll || d.SupportMarginMode == string(mode)
+}
+
+func Init(ctx context.Context) error {
+	rc := NewRestClient("", "")
+
+	var ci ContractInfo
+	if err := rc.RequestWithRawResp(ctx, http.MethodGet, ContractEndPoint, nil, nil, false, &ci); err != nil {
+		return errors.WithMessagef(err, "get contract info fail")
+	}
+
+	if ci.Status != huobi.StatusOK {
+		return errors.Errorf("got huobi linear contract info fail")
+	}
+
+	for i := range ci.Data {
+		symbol := NewSymbol(&ci.Data[i])
+		contractMap[symbol.String()] = symbol
+	}
+
+	return nil
+}
+
+// NewSymbol build symbol from contract info, amount is in contracts
+func NewSymbol(data *Data) *Symbol {
+	return &Symbol{
+		exchange.NewBaseSwapSymbolWithCfg(data.ContractCode, data.ContractSize, exchange.SymbolConfig{
+			AmountPrecision: decimal.NewFromInt(1),
+			PricePrecision:  data.PriceTick,
+			AmountMin:       decimal.NewFromInt(1),
+			AmountMax:       decimal.Zero,
+		}, data),
+	}
+}
+
+// ParseSymbol return symbol by contract code such as BTC-USDT, the package should
+// be initialized via Init first
+func ParseSymbol(sym string) (exchange.SwapSymbol, error) {
+	s, ok := contractMap[strings.ToUpper(sym)]
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol %s", sym)
+	}
+
+	return s, nil
+}
+
+func Symbols() []exchange.SwapSymbol {
+	ret := make([]exchange.SwapSymbol, 0, len(contractMap))
+	for _, s := range contractMap {
+		ret = append(ret, s)
+	}
+	return ret
+}
@@ -0,0 +1,78 @@
+package linear
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	TradeDetailChannel struct {
+		contractCode string
+	}
+)
+
+var (
+	directionMap = map[string]exchange.OrderSide{
+		"buy":  exchange.OrderSideBuy,
+		"sell": exchange.OrderSideSell,
+	}
+)
+
+func NewTradeDetailChannel(symbol string) exchange.Channel {
+	return &TradeDetailChannel{
+		contractCode: symbol,
+	}
+}
+
+func (ch *TradeDetailChannel) String() string {
+	return fmt.Sprintf("market.%s.trade.detail", ch.contractCode)
+}
+
+func IsTradeDetailChannel(ch string) bool {
+	fields := strings.Split(ch, ".")
+	return len(fields) == 4 && fields[0] == "market" && fields[2] == "trade" && fields[3] == "detail"
+}
+
+// ParseTrades parse trade detail tick into public trades, amount is in contracts
+func ParseTrades(ch string, raw json.RawMessage) ([]*exchange.PublicTrade, error) {
+	fields := strings.Split(ch, ".")
+	if len(fields) < 2 {
+		return nil, errors.Errorf("invalid ch %s", ch)
+	}
+
+	sym, err := ParseSymbol(fields[1])
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := huobi.ParseTrades(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.PublicTrade, len(trades))
+	for i := range trades {
+		t := &trades[i]
+		side, ok := directionMap[t.Direction]
+		if !ok {
+			return nil, errors.Errorf("unsupport direction=%s", t.Direction)
+		}
+
+		ret[i] = &exchange.PublicTrade{
+			Symbol: sym,
+			Price:  decimal.NewFromFloat(t.Price),
+			Amount: decimal.NewFromFloat(t.Amount),
+			Side:   side,
+			ID:     fmt.Sprintf("%d", t.ID),
+			Time:   huobi.ParseTS(t.TS),
+			Raw:    t,
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,32 @@
+package linear
+
+import (
+	"context"
+
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi/future"
+)
+
+type (
+	WSClient struct {
+		*future.WSClientDeriv
+	}
+)
+
+const (
+	LinearWSAddr = "wss://api.hbdm.com/linear-swap-ws"
+)
+
+func NewWSClient(data chan interface{}) *WSClient {
+	return &WSClient{
+		WSClientDeriv: future.NewWSClientDeriv(LinearWSAddr, NewCodeC(), data),
+	}
+}
+
+func (ws *WSClient) Subscribe(ctx context.Context, cs ...exchange.Channel) error {
+	var channels []string
+	for _, c := range cs {
+		channels = append(channels, c.String())
+	}
+	return ws.DoSubscribe(ctx, channels)
+}
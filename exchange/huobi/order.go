@@ -0,0 +1,141 @@
+package huobi
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	// ContractOrderParam is the order_price_type, volume, price and client_order_id of
+	// future, swap and linear swap order, which is built from exchange.OrderRequest
+	ContractOrderParam struct {
+		PriceType     string
+		Volume        int
+		Price         float64 //only set for limit order
+		ClientOrderID int64   //only set if HasClientID
+		HasClientID   bool
+	}
+
+	// OrderUpdate is emitted by contract orders and matchOrders topic, Trades is the
+	// fills carried by the notification
+	OrderUpdate struct {
+		Order  *exchange.Order
+		Trades []*exchange.Trade
+	}
+)
+
+const (
+	OrderPriceLimit    = "limit"
+	OrderPriceMarket   = "opponent"
+	OrderPricePostOnly = "post_only"
+	OrderPriceOptimal5 = "optimal_5"
+	OrderPriceIOC      = "ioc"
+	OrderPriceFOK      = "fok"
+)
+
+// NewContractOrderParam check the contract order request and options, the amount
+// should be integer contracts and client id should be integer
+func NewContractOrderParam(req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*ContractOrderParam, error) {
+	if !req.Amount.Equal(req.Amount.Truncate(0)) {
+		return nil, exchange.NewBadArg("amount should be integer contracts", req.Amount)
+	}
+
+	var priceType string
+	switch req.Type {
+	case exchange.OrderTypeLimit:
+		priceType = OrderPriceLimit
+
+	case exchange.OrderTypeMarket:
+		priceType = OrderPriceMarket
+
+	default:
+		return nil, exchange.NewBadArg("unsupport order type", req.Type)
+	}
+
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				if req.Type != exchange.OrderTypeLimit {
+					return nil, exchange.NewBadArg("post only is only support for limit order", opt)
+				}
+				priceType = OrderPricePostOnly
+			}
+
+		case *exchange.TimeInForceOption:
+			if req.Type != exchange.OrderTypeLimit {
+				return nil, exchange.NewBadArg("time in force is only support for limit order", opt)
+			}
+			switch t.Flag {
+			case exchange.TimeInForceIOC:
+				priceType = OrderPriceIOC
+
+			case exchange.TimeInForceFOK:
+				priceType = OrderPriceFOK
+			}
+
+		default:
+			return nil, exchange.NewBadArg("unsupport option", opt)
+		}
+	}
+
+	ret := &ContractOrderParam{
+		PriceType: priceType,
+		Volume:    int(req.Amount.IntPart()),
+	}
+	if req.Type == exchange.OrderTypeLimit {
+		ret.Price, _ = req.Price.Float64()
+	}
+
+	if req.ClientID != nil {
+		cid, err := strconv.ParseInt(req.ClientID.String(), 10, 64)
+		if err != nil {
+			return nil, exchange.NewBadArg("client id should be integer", req.ClientID.String())
+		}
+		ret.ClientOrderID = cid
+		ret.HasClientID = true
+	}
+	return ret, nil
+}
+
+// NewCreatedOrder return the open order of the request which is just placed with orderID
+func NewCreatedOrder(req *exchange.OrderRequest, orderID int64, raw interface{}) *exchange.Order {
+	ts := time.Now()
+	return &exchange.Order{
+		ID:       exchange.NewIntID(orderID),
+		ClientID: req.ClientID,
+		Symbol:   req.Symbol,
+		Amount:   req.Amount,
+		Price:    req.Price,
+		Side:     req.Side,
+		Type:     req.Type,
+		Status:   exchange.OrderStatusOpen,
+		Created:  ts,
+		Updated:  ts,
+		Raw:      raw,
+	}
+}
+
+// NewOrderTrade return the fill of the order, fee is left to the caller since it's
+// not pushed by matchOrders topic
+func NewOrderTrade(order *exchange.Order, id string, price, amount decimal.Decimal, createdAt int64, isMaker bool, raw interface{}) *exchange.Trade {
+	return &exchange.Trade{
+		ID:      id,
+		OrderID: order.ID.String(),
+		Symbol:  order.Symbol,
+		Price:   price,
+		Amount:  amount,
+		Time:    ParseTS(createdAt),
+		Side:    order.Side,
+		IsMaker: isMaker,
+		Raw:     raw,
+	}
+}
+
+// OrderUpdates implement exchange.OrderUpdater
+func (ou *OrderUpdate) OrderUpdates() []*exchange.Order {
+	return []*exchange.Order{ou.Order}
+}
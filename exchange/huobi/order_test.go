@@ -0,0 +1,40 @@
+package huobi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func TestNewContractOrderParam(t *testing.T) {
+	req := &exchange.OrderRequest{
+		ClientID: exchange.NewIntID(1001),
+		Side:     exchange.OrderSideBuy,
+		Type:     exchange.OrderTypeLimit,
+		Price:    decimal.NewFromFloat(48000.5),
+		Amount:   decimal.NewFromInt(3),
+	}
+	param, err := NewContractOrderParam(req, exchange.NewPostOnlyOption(true))
+	if err != nil || param.PriceType != OrderPricePostOnly || param.Volume != 3 || param.Price != 48000.5 ||
+		!param.HasClientID || param.ClientOrderID != 1001 {
+		t.Fatalf("bad param %+v %v", param, err)
+	}
+
+	req.Amount = decimal.NewFromFloat(1.5)
+	if _, err := NewContractOrderParam(req); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("fractional amount should be rejected %v", err)
+	}
+
+	req.Amount = decimal.NewFromInt(1)
+	req.Type = exchange.OrderTypeMarket
+	if _, err := NewContractOrderParam(req, exchange.NewPostOnlyOption(true)); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("post only market order should be rejected %v", err)
+	}
+
+	req.ClientID = exchange.NewStrID("abc")
+	if _, err := NewContractOrderParam(req); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("non integer client id should be rejected %v", err)
+	}
+}
@@ -16,7 +16,9 @@ const (
 )
 
 func NewRestClient(key, secret string) *RestClient {
+	rc := huobi.NewRestClient(key, secret, SpotHost)
+	rc.SetLimiter(huobi.NewSpotLimiter())
 	return &RestClient{
-		RestClient: huobi.NewRestClient(key, secret, SpotHost),
+		RestClient: rc,
 	}
 }
@@ -0,0 +1,104 @@
+package spot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/misc/tconv"
+)
+
+type (
+	AccountsUpdateChannel struct {
+		mode AccountsUpdateMode
+	}
+
+	AccountsUpdateMode int
+
+	AccountUpdate struct {
+		Currency    string `json:"currency"`
+		AccountID   int64  `json:"accountId"`
+		Balance     string `json:"balance"`
+		Available   string `json:"available"`
+		ChangeType  string `json:"changeType"`
+		AccountType string `json:"accountType"`
+		ChangeTime  int64  `json:"changeTime"`
+		SeqNum      int64  `json:"seqNum"`
+	}
+)
+
+const (
+	// AccountsUpdateModeBalance push balance only when balance changed
+	AccountsUpdateModeBalance AccountsUpdateMode = iota
+	// AccountsUpdateModeAvailable push balance or available when either changed
+	AccountsUpdateModeAvailable
+	// AccountsUpdateModeAll push both balance and available when either changed
+	AccountsUpdateModeAll
+
+	accountsUpdatePrefix = "accounts.update"
+)
+
+// NewAccountsUpdateChannel return accounts.update channel, only AccountsUpdateModeAll
+// push both balance and available
+func NewAccountsUpdateChannel(mode AccountsUpdateMode) *AccountsUpdateChannel {
+	return &AccountsUpdateChannel{
+		mode: mode,
+	}
+}
+
+func (ac *AccountsUpdateChannel) String() string {
+	return fmt.Sprintf("%s#%d", accountsUpdatePrefix, ac.mode)
+}
+
+// ParseBalanceUpdate parse accounts.update push into exchange.BalanceUpdate, Fields
+// is the balance or available pushed, frozen is only set if both are pushed
+func ParseBalanceUpdate(data json.RawMessage) (*exchange.BalanceUpdate, error) {
+	var au AccountUpdate
+	if err := json.Unmarshal(data, &au); err != nil {
+		return nil, err
+	}
+
+	var fields exchange.BalanceField
+	b := &exchange.Balance{
+		Currency: exchange.CurrencyFormat(au.Currency),
+	}
+
+	if au.Balance != "" {
+		total, err := decimal.NewFromString(au.Balance)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid balance")
+		}
+		b.Total = total
+		fields |= exchange.BalanceFieldTotal
+	}
+
+	if au.Available != "" {
+		free, err := decimal.NewFromString(au.Available)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid available")
+		}
+		b.Free = free
+		fields |= exchange.BalanceFieldFree
+	}
+
+	if au.Balance != "" && au.Available != "" {
+		b.Frozen = b.Total.Sub(b.Free)
+		fields |= exchange.BalanceFieldFrozen
+	}
+
+	return exchange.NewBalanceUpdate(b, fields, changeType2Reason(au.ChangeType), tconv.Milli2Time(au.ChangeTime), &au), nil
+}
+
+func changeType2Reason(changeType string) exchange.BalanceReason {
+	switch {
+	case strings.HasPrefix(changeType, "order-"):
+		return exchange.BalanceReasonTrade
+
+	case changeType == "deposit" || changeType == "withdraw" || strings.HasSuffix(changeType, "transfer"):
+		return exchange.BalanceReasonTransfer
+	}
+	return exchange.BalanceReasonOther
+}
@@ -2,6 +2,7 @@ package spot
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/szmcdull/ccexgo/internal/rpc"
@@ -63,7 +64,20 @@ func (pcc *PrivateCodeC) Decode(raw []byte) (rpc.Response, error) {
 	}
 
 	if resp.Action == ActionPush {
-		r, err := ParseOrder(resp.Data)
+		var (
+			r   interface{}
+			err error
+		)
+		switch strings.SplitN(resp.Ch, "#", 2)[0] {
+		case accountsUpdatePrefix:
+			r, err = ParseBalanceUpdate(resp.Data)
+
+		case tradeClearingPrefix:
+			r, err = ParseTradeClearing(resp.Data)
+
+		default:
+			r, err = ParseOrder(resp.Data)
+		}
 		if err != nil {
 			return nil, err
 		}
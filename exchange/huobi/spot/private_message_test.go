@@ -0,0 +1,81 @@
+package spot
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func TestDecodePrivatePush(t *testing.T) {
+	s := Symbol{
+		BaseCurrency: "btc",
+		QuoteCurreny: "usdt",
+		Symbol:       "btcusdt",
+	}
+	sym, _ := s.Parse()
+	symbolMap[sym.String()] = sym
+
+	cc := NewPrivateCodeC()
+	resp, err := cc.Decode([]byte(`{
+		"action": "push",
+		"ch": "trade.clearing#btcusdt#0",
+		"data": {
+			"eventType": "trade",
+			"symbol": "btcusdt",
+			"orderId": 99998888,
+			"tradePrice": "9999.99",
+			"tradeVolume": "0.96",
+			"orderSide": "buy",
+			"aggressor": true,
+			"tradeId": 919219323232,
+			"tradeTime": 998787897878,
+			"transactFee": "19.88",
+			"feeCurrency": "btc",
+			"feeDeduct": "0",
+			"feeDeductType": "",
+			"accountId": 1234,
+			"source": "spot-api",
+			"orderPrice": "10000",
+			"orderSize": "1",
+			"clientOrderId": "a001",
+			"orderCreateTime": 998787897878,
+			"orderStatus": "partial-filled"
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("decode trade.clearing fail %s", err.Error())
+	}
+
+	trade := resp.(*rpc.Notify).Params.(*exchange.Trade)
+	if trade.Side != exchange.OrderSideBuy || trade.IsMaker || trade.OrderID != "99998888" ||
+		!trade.Fee.Equal(decimal.RequireFromString("-19.88")) || trade.FeeCurrency != "btc" ||
+		!trade.Amount.Equal(decimal.RequireFromString("0.96")) {
+		t.Errorf("bad trade %+v", *trade)
+	}
+
+	resp, err = cc.Decode([]byte(`{
+		"action": "push",
+		"ch": "accounts.update#2",
+		"data": {
+			"currency": "btc",
+			"accountId": 123456,
+			"balance": "23.111",
+			"available": "2028.699426619837209087",
+			"changeType": "transfer",
+			"accountType": "trade",
+			"changeTime": 1568601800000,
+			"seqNum": 1
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("decode accounts.update fail %s", err.Error())
+	}
+
+	update := resp.(*rpc.Notify).Params.(*exchange.BalanceUpdate)
+	if update.Currency != "BTC" || !update.Total.Equal(decimal.RequireFromString("23.111")) ||
+		update.Reason != exchange.BalanceReasonTransfer || !update.Has(exchange.BalanceFieldFrozen) {
+		t.Errorf("bad balance update %+v", update)
+	}
+}
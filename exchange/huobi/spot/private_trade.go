@@ -0,0 +1,133 @@
+package spot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	TradeClearingChannel struct {
+		symbol string
+	}
+
+	TradeClearing struct {
+		EventType     string `json:"eventType"`
+		Symbol        string `json:"symbol"`
+		OrderID       int64  `json:"orderId"`
+		ClientOrderID string `json:"clientOrderId"`
+		TradePrice    string `json:"tradePrice"`
+		TradeVolume   string `json:"tradeVolume"`
+		OrderSide     string `json:"orderSide"`
+		OrderType     string `json:"orderType"`
+		Aggressor     bool   `json:"aggressor"`
+		TradeID       int64  `json:"tradeId"`
+		TradeTime     int64  `json:"tradeTime"`
+		TransactFee   string `json:"transactFee"`
+		FeeCurrency   string `json:"feeCurrency"`
+		FeeDeduct     string `json:"feeDeduct"`
+		FeeDeductType string `json:"feeDeductType"`
+		AccountID     int64  `json:"accountId"`
+		Source        string `json:"source"`
+		OrderPrice    string `json:"orderPrice"`
+		OrderSize     string `json:"orderSize"`
+		OrderValue    string `json:"orderValue"`
+		OrderStatus   string `json:"orderStatus"`
+	}
+)
+
+const (
+	tradeClearingPrefix = "trade.clearing"
+
+	TradeClearingEventTrade = "trade"
+)
+
+// NewTradeClearingChannel return trade.clearing channel which push trade event only,
+// * can be used for all symbols
+func NewTradeClearingChannel(sym string) *TradeClearingChannel {
+	return &TradeClearingChannel{
+		symbol: sym,
+	}
+}
+
+func (tc *TradeClearingChannel) String() string {
+	return fmt.Sprintf("%s#%s#0", tradeClearingPrefix, tc.symbol)
+}
+
+// ParseTradeClearing parse trade.clearing push into exchange.Trade, fee is negative
+// if it's paid and fee deduction is preferred
+func ParseTradeClearing(data json.RawMessage) (*exchange.Trade, error) {
+	var tc TradeClearing
+	if err := json.Unmarshal(data, &tc); err != nil {
+		return nil, err
+	}
+
+	if tc.EventType != TradeClearingEventTrade {
+		return nil, errors.Errorf("unsupport eventType '%s'", tc.EventType)
+	}
+	return tc.Parse()
+}
+
+func (tc *TradeClearing) Parse() (*exchange.Trade, error) {
+	symbol, err := ParseSymbol(tc.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var side exchange.OrderSide
+	if tc.OrderSide == "buy" {
+		side = exchange.OrderSideBuy
+	} else if tc.OrderSide == "sell" {
+		side = exchange.OrderSideSell
+	} else {
+		return nil, errors.Errorf("unkown orderSide '%s'", tc.OrderSide)
+	}
+
+	price, err := decimal.NewFromString(tc.TradePrice)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid tradePrice")
+	}
+
+	amount, err := decimal.NewFromString(tc.TradeVolume)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid tradeVolume")
+	}
+
+	fee, feeCurrency := decimal.Zero, tc.FeeCurrency
+	if tc.TransactFee != "" {
+		fee, err = decimal.NewFromString(tc.TransactFee)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid transactFee")
+		}
+	}
+
+	if tc.FeeDeduct != "" {
+		deduct, err := decimal.NewFromString(tc.FeeDeduct)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid feeDeduct")
+		}
+		if !deduct.IsZero() {
+			fee = deduct
+			feeCurrency = tc.FeeDeductType
+		}
+	}
+
+	return &exchange.Trade{
+		ID:          strconv.FormatInt(tc.TradeID, 10),
+		OrderID:     strconv.FormatInt(tc.OrderID, 10),
+		Symbol:      symbol,
+		Price:       price,
+		Amount:      amount,
+		Fee:         fee.Neg(),
+		FeeCurrency: feeCurrency,
+		Time:        huobi.ParseTS(tc.TradeTime),
+		Side:        side,
+		IsMaker:     !tc.Aggressor,
+		Raw:         tc,
+	}, nil
+}
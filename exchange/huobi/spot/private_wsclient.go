@@ -4,64 +4,157 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
+	"github.com/go-kit/log/level"
 	"github.com/pkg/errors"
 	"github.com/szmcdull/ccexgo/exchange"
 	"github.com/szmcdull/ccexgo/exchange/huobi"
 	"github.com/szmcdull/ccexgo/internal/rpc"
+	"github.com/szmcdull/ccexgo/misc/ctxlog"
 )
 
 type (
+	// PrivateWSClient huobi spot private websocket client, conn is replaced
+	// by Reconnect so conn, closed and channels are guarded by mu
 	PrivateWSClient struct {
 		key    string
 		secret string
-		*exchange.WSClient
-		data chan interface{}
+		data   chan interface{}
+
+		mu       sync.Mutex
+		conn     *exchange.WSClient
+		closed   bool
+		channels []exchange.Channel
 	}
 )
 
+var (
+	ErrClosed = errors.New("private websocket client closed")
+)
+
 const (
 	PrivateWSClientAddr = "wss://api.huobi.pro/ws/v2"
+
+	reconnectInterval = time.Second
 )
 
 func NewPrivateWSClient(key, secret string, data chan interface{}) *PrivateWSClient {
-	ret := &PrivateWSClient{
+	return &PrivateWSClient{
 		key:    key,
 		secret: secret,
 		data:   data,
 	}
-
-	ret.WSClient = exchange.NewWSClient(PrivateWSClientAddr, NewPrivateCodeC(), ret)
-	return ret
 }
 
+// Run connect and auth, the client will reconnect and restore subscribed
+// channels once the connection is closed until ctx is done or Close is called
 func (pws *PrivateWSClient) Run(ctx context.Context) error {
-	if err := pws.WSClient.Run(ctx); err != nil {
+	if err := pws.Reconnect(ctx); err != nil {
 		return err
 	}
 
-	return pws.Auth(ctx)
+	go pws.loop(ctx)
+	return nil
 }
 
+// Subscribe subscribe channels one by one, subscribed channels are restored after reconnect
 func (pws *PrivateWSClient) Subscribe(ctx context.Context, channels ...exchange.Channel) error {
-	if len(channels) != 1 {
-		return errors.Errorf("invalid channeld num=%d", len(channels))
+	pws.mu.Lock()
+	defer pws.mu.Unlock()
+
+	if pws.closed {
+		return ErrClosed
+	}
+	if pws.conn == nil {
+		return rpc.ErrNotRunning
 	}
 
-	params := PrivateWSReq{
-		Action: ActionSub,
-		Ch:     channels[0].String(),
+	for _, ch := range channels {
+		if err := pws.subscribe(ctx, pws.conn, ch); err != nil {
+			return err
+		}
+		pws.addChannel(ch)
+	}
+	return nil
+}
+
+// Reconnect connect, auth and subscribe all channels again with a new connection,
+// the previous connection is closed after being replaced
+func (pws *PrivateWSClient) Reconnect(ctx context.Context) error {
+	pws.mu.Lock()
+	closed := pws.closed
+	pws.mu.Unlock()
+	if closed {
+		return ErrClosed
 	}
 
-	var resp PrivateWSResp
-	if err := pws.Call(ctx, params.Ch, params.Action, &params, &resp); err != nil {
-		return errors.WithMessage(err, "subscribe fail")
+	conn, err := pws.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	pws.mu.Lock()
+	defer pws.mu.Unlock()
+	if pws.closed {
+		conn.Close()
+		return ErrClosed
+	}
+
+	prev := pws.conn
+	pws.conn = conn
+	if prev != nil {
+		prev.Close()
 	}
 
+	for _, ch := range pws.channels {
+		if err := pws.subscribe(ctx, conn, ch); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// Close close current connection and stop reconnecting, the client can't be used
+// after being closed
+func (pws *PrivateWSClient) Close() error {
+	pws.mu.Lock()
+	pws.closed = true
+	conn := pws.conn
+	pws.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return conn.Close()
+}
+
+func (pws *PrivateWSClient) Call(ctx context.Context, id string, method string, params interface{}, dest interface{}) error {
+	conn := pws.getConn()
+	if conn == nil {
+		return rpc.ErrNotRunning
+	}
+	return conn.Call(ctx, id, method, params, dest)
+}
+
+// Done return the done channel of current connection, it's nil if not connected
+func (pws *PrivateWSClient) Done() <-chan struct{} {
+	conn := pws.getConn()
+	if conn == nil {
+		return nil
+	}
+	return conn.Done()
+}
+
+func (pws *PrivateWSClient) Error() error {
+	conn := pws.getConn()
+	if conn == nil {
+		return rpc.ErrNotRunning
+	}
+	return conn.Error()
+}
+
 func (pws *PrivateWSClient) Handle(ctx context.Context, n *rpc.Notify) {
 	if n.Method == ActionPing {
 		go func() {
@@ -84,6 +177,78 @@ func (pws *PrivateWSClient) Handle(ctx context.Context, n *rpc.Notify) {
 	}
 }
 
+func (pws *PrivateWSClient) getConn() *exchange.WSClient {
+	pws.mu.Lock()
+	defer pws.mu.Unlock()
+	return pws.conn
+}
+
+// connect create a new authorized connection
+func (pws *PrivateWSClient) connect(ctx context.Context) (*exchange.WSClient, error) {
+	conn := exchange.NewWSClient(PrivateWSClientAddr, NewPrivateCodeC(), pws)
+	if err := conn.Run(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := pws.auth(ctx, conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (pws *PrivateWSClient) subscribe(ctx context.Context, conn *exchange.WSClient, ch exchange.Channel) error {
+	params := PrivateWSReq{
+		Action: ActionSub,
+		Ch:     ch.String(),
+	}
+
+	var resp PrivateWSResp
+	if err := conn.Call(ctx, params.Ch, params.Action, &params, &resp); err != nil {
+		return errors.WithMessagef(err, "subscribe %s fail", params.Ch)
+	}
+	return nil
+}
+
+func (pws *PrivateWSClient) addChannel(ch exchange.Channel) {
+	for _, c := range pws.channels {
+		if c.String() == ch.String() {
+			return
+		}
+	}
+	pws.channels = append(pws.channels, ch)
+}
+
+func (pws *PrivateWSClient) loop(ctx context.Context) {
+	logger := ctxlog.GetSafeLog(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-pws.Done():
+			level.Warn(logger).Log("message", "huobi private websocket closed, reconnecting")
+		}
+
+		for {
+			err := pws.Reconnect(ctx)
+			if err == nil {
+				break
+			}
+			if errors.Is(err, ErrClosed) {
+				return
+			}
+			level.Warn(logger).Log("message", "reconnect huobi private websocket fail", "error", err.Error())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectInterval):
+			}
+		}
+	}
+}
+
 func (pws *PrivateWSClient) genSignatureParmas() map[string]string {
 	ts := time.Now().UTC()
 	ret := map[string]string{
@@ -103,7 +268,16 @@ func (pws *PrivateWSClient) genSignatureParmas() map[string]string {
 	return ret
 }
 
+// Auth auth current connection, it's done by Run and Reconnect for every new connection
 func (pws *PrivateWSClient) Auth(ctx context.Context) error {
+	conn := pws.getConn()
+	if conn == nil {
+		return rpc.ErrNotRunning
+	}
+	return pws.auth(ctx, conn)
+}
+
+func (pws *PrivateWSClient) auth(ctx context.Context, conn *exchange.WSClient) error {
 	param := pws.genSignatureParmas()
 	req := PrivateWSReq{
 		Action: ActionReq,
@@ -112,7 +286,7 @@ func (pws *PrivateWSClient) Auth(ctx context.Context) error {
 	}
 
 	var resp PrivateWSResp
-	if err := pws.Call(ctx, req.Ch, req.Action, &req, &resp); err != nil {
+	if err := conn.Call(ctx, req.Ch, req.Action, &req, &resp); err != nil {
 		return errors.WithMessage(err, "auth failed")
 	}
 
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/szmcdull/ccexgo/exchange/huobi"
@@ -13,6 +14,9 @@ import (
 type (
 	RestClient struct {
 		*huobi.RestClient
+
+		mu     sync.Mutex
+		levers map[string]int
 	}
 
 	Serializer interface {
@@ -30,8 +34,11 @@ func NewRestClient(key string, secret string) *RestClient {
 }
 
 func NewRestClientWithHost(key, secret, host string) *RestClient {
+	rc := huobi.NewRestClient(key, secret, host)
+	rc.SetLimiter(huobi.NewContractLimiter())
 	return &RestClient{
-		RestClient: huobi.NewRestClient(key, secret, host),
+		RestClient: rc,
+		levers:     map[string]int{},
 	}
 }
 
@@ -0,0 +1,135 @@
+package swap
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/exchange/huobi"
+)
+
+type (
+	SwitchLeverRateReq struct {
+		ContractCode string `json:"contract_code"`
+		LeverRate    int    `json:"lever_rate"`
+	}
+
+	SwitchLeverRateResp struct {
+		ContractCode string `json:"contract_code"`
+		LeverRate    int    `json:"lever_rate"`
+	}
+)
+
+const (
+	SwitchLeverRateEndPoint = "/swap-api/v1/swap_switch_lever_rate"
+
+	// ErrCodePositionExists leverage can not be switched due to positions or open orders
+	ErrCodePositionExists = 1045
+)
+
+func NewSwitchLeverRateReq(contractCode string, lever int) *SwitchLeverRateReq {
+	return &SwitchLeverRateReq{
+		ContractCode: contractCode,
+		LeverRate:    lever,
+	}
+}
+
+func (rc *RestClient) SwitchLeverRate(ctx context.Context, req *SwitchLeverRateReq) (*SwitchLeverRateResp, error) {
+	var resp SwitchLeverRateResp
+	if err := rc.PrivatePostReq(ctx, SwitchLeverRateEndPoint, req, &resp); err != nil {
+		rc.clearLeverRate(req.ContractCode)
+		var ae *huobi.APIError
+		if errors.As(err, &ae) && ae.Code == ErrCodePositionExists {
+			err = exchange.NewPositionExists(err)
+		}
+		return nil, errors.WithMessage(err, "switch lever rate fail")
+	}
+
+	rc.mu.Lock()
+	rc.levers[resp.ContractCode] = resp.LeverRate
+	rc.mu.Unlock()
+	return &resp, nil
+}
+
+// NewOrderReq build order request with the lever_rate of the contract, which must
+// equal to the current leverage if there are positions
+func (rc *RestClient) NewOrderReq(ctx context.Context, contractCode string, volume int, direction string, offset string, orderPriceType string) (*OrderReq, error) {
+	lever, err := rc.leverRate(ctx, contractCode)
+	if err != nil {
+		return nil, err
+	}
+	return NewOrderReq(contractCode, volume, direction, offset, lever, orderPriceType), nil
+}
+
+// SetLeverage switch lever rate of the symbol, the leverage should be integer
+func (rc *RestClient) SetLeverage(ctx context.Context, sym exchange.Symbol, leverage decimal.Decimal) error {
+	if !leverage.Equal(leverage.Truncate(0)) {
+		return exchange.NewBadArg("leverage should be integer", leverage)
+	}
+	_, err := rc.SwitchLeverRate(ctx, NewSwitchLeverRateReq(sym.String(), int(leverage.IntPart())))
+	return err
+}
+
+func (rc *RestClient) GetLeverage(ctx context.Context, sym exchange.Symbol) (decimal.Decimal, error) {
+	lever, err := rc.leverRate(ctx, sym.String())
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromInt(int64(lever)), nil
+}
+
+// SetMarginMode coin margined swap only support isolated margin
+func (rc *RestClient) SetMarginMode(ctx context.Context, sym exchange.Symbol, mode exchange.PositionMode) error {
+	if mode != exchange.PositionModeFixed {
+		return exchange.NewBadArg("only isolated margin is supported", mode)
+	}
+	return nil
+}
+
+func (rc *RestClient) GetMarginMode(ctx context.Context, sym exchange.Symbol) (exchange.PositionMode, error) {
+	return exchange.PositionModeFixed, nil
+}
+
+// SetPositionMode coin margined swap always hold long and short position separately
+func (rc *RestClient) SetPositionMode(ctx context.Context, hedge bool) error {
+	if !hedge {
+		return exchange.NewBadArg("only hedge position mode is supported", hedge)
+	}
+	return nil
+}
+
+func (rc *RestClient) GetPositionMode(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (rc *RestClient) leverRate(ctx context.Context, contractCode string) (int, error) {
+	rc.mu.Lock()
+	lever, ok := rc.levers[contractCode]
+	rc.mu.Unlock()
+	if ok {
+		return lever, nil
+	}
+
+	infos, err := rc.SwapAccountInfo(ctx, NewSwapAccountInfoReq().ContractCode(contractCode))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, info := range infos {
+		if info.ContractCode == contractCode {
+			lever = int(info.LeverRate.IntPart())
+			rc.mu.Lock()
+			rc.levers[contractCode] = lever
+			rc.mu.Unlock()
+			return lever, nil
+		}
+	}
+	return 0, errors.Errorf("no account info for '%s'", contractCode)
+}
+
+func (rc *RestClient) clearLeverRate(contractCode string) {
+	rc.mu.Lock()
+	delete(rc.levers, contractCode)
+	rc.mu.Unlock()
+}
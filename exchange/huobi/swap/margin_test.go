@@ -0,0 +1,42 @@
+package swap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+var _ exchange.MarginManager = (*RestClient)(nil)
+
+func TestMarginMode(t *testing.T) {
+	rc := NewRestClient("", "")
+	ctx := context.Background()
+
+	if err := rc.SetMarginMode(ctx, nil, exchange.PositionModeCross); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("cross margin should not be supported %v", err)
+	}
+	if err := rc.SetPositionMode(ctx, false); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("one way position mode should not be supported %v", err)
+	}
+	if err := rc.SetLeverage(ctx, nil, decimal.RequireFromString("2.5")); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("fractional leverage should be rejected %v", err)
+	}
+
+	rc.levers["BTC-USD"] = 5
+	if req, err := rc.NewOrderReq(ctx, "BTC-USD", 1, OrderDirectionBuy, OrderOffsetOpen, OrderPriceLimit); err != nil ||
+		req.data["lever_rate"] != 5 {
+		t.Errorf("order should carry current lever rate %v", err)
+	}
+
+	req := &exchange.OrderRequest{
+		Side:   exchange.OrderSideBuy,
+		Type:   exchange.OrderTypeLimit,
+		Amount: decimal.RequireFromString("1.5"),
+	}
+	if _, err := rc.CreateOrder(ctx, req); !errors.Is(err, &exchange.ErrBadArg{}) {
+		t.Errorf("fractional contracts should be rejected %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/szmcdull/ccexgo/exchange"
 	"github.com/szmcdull/ccexgo/exchange/huobi"
+	"github.com/szmcdull/ccexgo/exchange/huobi/future"
 )
 
 type (
@@ -39,6 +40,10 @@ type (
 		Successes string            `json:"successes"` //id1,id2,id3 ...
 	}
 
+	SwapOrderInfoReq struct {
+		data map[string]interface{}
+	}
+
 	SwapOrderDetailReq struct {
 		data map[string]interface{}
 	}
@@ -66,7 +71,7 @@ type (
 		Fee             float64            `json:"fee"`
 		OrderID         int64              `json:"order_id"`
 		OrderIDStr      string             `json:"order_id_str"`
-		ClientOrderID   interface{}        `json:"client_order_id"`
+		ClientOrderID   json.Number        `json:"client_order_id"`
 		OrderType       string             `json:"order_type"`
 		Status          int                `json:"status"`
 		TradeAvgPrice   float64            `json:"trade_avg_price"`
@@ -81,15 +86,19 @@ const (
 	SwapOrderEndPoint       = "/swap-api/v1/swap_order"
 	SwapCancelEndPoint      = "/swap-api/v1/swap_cancel"
 	SwapOrderDetailEndPoint = "/swap-api/v1/swap_order_detail"
+	SwapOrderInfoEndPoint   = "/swap-api/v1/swap_order_info"
 
 	OrderDirectionBuy  = "buy"
 	OrderDirectionSell = "sell"
 	OrderOffsetOpen    = "open"
 	OrderOffsetClose   = "close"
 
-	OrderPriceLimit    = "limit"
-	OrderPriceMarket   = "opponent"
-	OrderPriceOptimal5 = "optimal_5"
+	OrderPriceLimit    = huobi.OrderPriceLimit
+	OrderPriceMarket   = huobi.OrderPriceMarket
+	OrderPricePostOnly = huobi.OrderPricePostOnly
+	OrderPriceOptimal5 = huobi.OrderPriceOptimal5
+	OrderPriceIOC      = huobi.OrderPriceIOC
+	OrderPriceFOK      = huobi.OrderPriceFOK
 )
 
 func NewOrderReq(contractCode string, volume int, direction string, offset string, lever int, orderPriceType string) *OrderReq {
@@ -111,6 +120,11 @@ func (or *OrderReq) Price(price float64) *OrderReq {
 	return or
 }
 
+func (or *OrderReq) ClientOrderID(id int64) *OrderReq {
+	or.data["client_order_id"] = id
+	return or
+}
+
 func (or *OrderReq) Serialize() ([]byte, error) {
 	return json.Marshal(or.data)
 }
@@ -151,6 +165,28 @@ func (scr *SwapCancelReq) Serialize() ([]byte, error) {
 	return json.Marshal(data)
 }
 
+func NewSwapOrderInfoReq(cc string) *SwapOrderInfoReq {
+	return &SwapOrderInfoReq{
+		data: map[string]interface{}{
+			"contract_code": cc,
+		},
+	}
+}
+
+func (sir *SwapOrderInfoReq) OrderIDs(ids ...string) *SwapOrderInfoReq {
+	sir.data["order_id"] = strings.Join(ids, ",")
+	return sir
+}
+
+func (sir *SwapOrderInfoReq) ClientOrderIDs(ids ...string) *SwapOrderInfoReq {
+	sir.data["client_order_id"] = strings.Join(ids, ",")
+	return sir
+}
+
+func (sir *SwapOrderInfoReq) Serialize() ([]byte, error) {
+	return json.Marshal(sir.data)
+}
+
 func NewSwapOrderDetailReq(cc string, id int64) *SwapOrderDetailReq {
 	return &SwapOrderDetailReq{
 		data: map[string]interface{}{
@@ -210,7 +246,33 @@ func (rc *RestClient) SwapOrderDetail(ctx context.Context, req *SwapOrderDetailR
 	return &ret, nil
 }
 
+func (rc *RestClient) SwapOrderInfo(ctx context.Context, req *SwapOrderInfoReq) ([]SwapOrderDetailResp, error) {
+	var ret []SwapOrderDetailResp
+	if err := rc.PrivatePostReq(ctx, SwapOrderInfoEndPoint, req, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// FetchOrder fetch order by ID, ClientID is used if ID is not set
 func (rc *RestClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	if order.ID == nil {
+		if order.ClientID == nil {
+			return nil, errors.Errorf("order id or client id is required")
+		}
+
+		req := NewSwapOrderInfoReq(order.Symbol.String()).ClientOrderIDs(order.ClientID.String())
+		resp, err := rc.SwapOrderInfo(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp) == 0 {
+			return nil, huobi.NewAPIError(huobi.ErrCodeOrderNotExist, "order not exist")
+		}
+		return resp[0].Transform()
+	}
+
 	id, err := strconv.ParseInt(order.ID.String(), 10, 64)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "invalid order id '%s'", order.ID.String())
@@ -242,24 +304,18 @@ func (r *SwapOrderDetailResp) Transform() (*exchange.Order, error) {
 		Created:     huobi.ParseTS(r.CreatedAt),
 	}
 
-	if r.Direction == OrderDirectionBuy {
-		if r.Offset == OrderOffsetOpen {
-			ret.Side = exchange.OrderSideBuy
-		} else if r.Offset == OrderOffsetClose {
-			ret.Side = exchange.OrderSideCloseShort
-		} else {
-			return nil, errors.Errorf("unkown order offset '%s'", r.Offset)
-		}
-	} else if r.Direction == OrderDirectionSell {
-		if r.Offset == OrderOffsetOpen {
-			ret.Side = exchange.OrderSideSell
-		} else if r.Offset == OrderOffsetClose {
-			ret.Side = exchange.OrderSideCloseLong
-		} else {
-			return nil, errors.Errorf("unkown order offset '%s'", r.Offset)
+	side, err := future.ParseSide(r.Direction, r.Offset)
+	if err != nil {
+		return nil, err
+	}
+	ret.Side = side
+
+	if r.ClientOrderID != "" {
+		cid, err := r.ClientOrderID.Int64()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parse client_order_id '%s' fail", r.ClientOrderID)
 		}
-	} else {
-		return nil, errors.Errorf("unkown order direction '%s'", r.Direction)
+		ret.ClientID = exchange.NewIntID(cid)
 	}
 
 	if r.CanceledAt != 0 {
@@ -289,3 +345,42 @@ func (r *SwapOrderDetailResp) Transform() (*exchange.Order, error) {
 
 	return ret, nil
 }
+
+// CreateOrder create order with exchange.OrderRequest, the amount is the number of contracts
+// and lever_rate is the current leverage of the contract. client id should be integer
+func (rc *RestClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	side, err := exchange.NewTradeSide(req.Side, true)
+	if err != nil {
+		return nil, err
+	}
+	direction, offset := future.DirectionOffset(side)
+
+	param, err := huobi.NewContractOrderParam(req, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	oReq, err := rc.NewOrderReq(ctx, req.Symbol.String(), param.Volume, direction, offset, param.PriceType)
+	if err != nil {
+		return nil, err
+	}
+	if req.Type == exchange.OrderTypeLimit {
+		oReq.Price(param.Price)
+	}
+	if param.HasClientID {
+		oReq.ClientOrderID(param.ClientOrderID)
+	}
+
+	resp, err := rc.SwapOrder(ctx, oReq)
+	if err != nil {
+		// lever_rate may be changed by others
+		rc.clearLeverRate(req.Symbol.String())
+		return nil, err
+	}
+	return huobi.NewCreatedOrder(req, resp.OrderID, resp), nil
+}
+
+// NewClientIDGenerator generate integer client_order_id
+func NewClientIDGenerator() *exchange.ClientIDGenerator {
+	return exchange.NewClientIDGenerator("", 0, true)
+}
@@ -0,0 +1,28 @@
+package swap
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderDetailClientID(t *testing.T) {
+	defer setTestSymbol("BTC-USD")()
+
+	for raw, cid := range map[string]string{
+		`{"contract_code":"BTC-USD","direction":"buy","offset":"open","status":3,"order_price_type":"limit","client_order_id":1700000000000123456}`: "1700000000000123456",
+		`{"contract_code":"BTC-USD","direction":"buy","offset":"open","status":3,"order_price_type":"limit","client_order_id":null}`:                "",
+	} {
+		var resp SwapOrderDetailResp
+		if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+			t.Fatalf("unmarshal fail %s", err.Error())
+		}
+
+		order, err := resp.Transform()
+		if err != nil {
+			t.Fatalf("transform fail %s", err.Error())
+		}
+		if (order.ClientID == nil && cid != "") || (order.ClientID != nil && order.ClientID.String() != cid) {
+			t.Errorf("bad client id %v expect %s", order.ClientID, cid)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package swap
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	accountsChannel struct {
+		topic  string
+		symbol string
+	}
+
+	AccountsNotify struct {
+		Op    string            `json:"op"`
+		Topic string            `json:"topic"`
+		TS    int64             `json:"ts"`
+		Event string            `json:"event"`
+		Data  []SwapAccountInfo `json:"data"`
+	}
+
+	PositionsNotify struct {
+		Op    string     `json:"op"`
+		Topic string     `json:"topic"`
+		TS    int64      `json:"ts"`
+		Event string     `json:"event"`
+		Data  []Position `json:"data"`
+	}
+)
+
+// NewAccountsChannel return accounts channel of the contract such as BTC-USD, * for all contracts
+func NewAccountsChannel(symbol string) exchange.Channel {
+	return &accountsChannel{
+		topic:  TopicAccounts,
+		symbol: symbol,
+	}
+}
+
+// NewPositionsChannel return positions channel of the contract such as BTC-USD, * for all contracts
+func NewPositionsChannel(symbol string) exchange.Channel {
+	return &accountsChannel{
+		topic:  TopicPositions,
+		symbol: symbol,
+	}
+}
+
+func (ac *accountsChannel) String() string {
+	return fmt.Sprintf("%s.%s", ac.topic, ac.symbol)
+}
+
+// ParseAccounts parse accounts notify into exchange.Balances, only changed accounts
+// are included
+func ParseAccounts(raw []byte) (*exchange.Balances, error) {
+	var an AccountsNotify
+	if err := json.Unmarshal(raw, &an); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal accounts notify fail")
+	}
+
+	ret := exchange.NewBalances()
+	for _, a := range an.Data {
+		ret.Add(&exchange.Balance{
+			Currency: a.Symbol,
+			Total:    a.MarginBalance,
+			Frozen:   a.MarginFrozen,
+			Free:     a.MarginAvailable,
+		})
+	}
+	ret.Raw = &an
+	return ret, nil
+}
+
+// ParsePositions parse positions notify into exchange.Position
+func ParsePositions(raw []byte) ([]*exchange.Position, error) {
+	var pn PositionsNotify
+	if err := json.Unmarshal(raw, &pn); err != nil {
+		return nil, errors.WithMessage(err, "unmarshal positions notify fail")
+	}
+
+	ret := make([]*exchange.Position, 0, len(pn.Data))
+	for i := range pn.Data {
+		p, err := pn.Data[i].Transfer()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
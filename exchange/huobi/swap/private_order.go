@@ -9,13 +9,19 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/szmcdull/ccexgo/exchange"
 	"github.com/szmcdull/ccexgo/exchange/huobi"
+	"github.com/szmcdull/ccexgo/exchange/huobi/future"
 )
 
 type (
 	ordersChannel struct {
+		topic  string
 		symbol string
 	}
 
+	// OrderUpdate is emitted by both orders and matchOrders topic. Fee of the trades
+	// is only set for the orders topic since matchOrders does not push trade_fee
+	OrderUpdate = huobi.OrderUpdate
+
 	OrderNotifyTrade struct {
 		TradeFee      float64 `json:"trade_fee"`
 		FeeAsset      string  `json:"fee_asset"`
@@ -75,17 +81,30 @@ var (
 		"limit":            exchange.OrderTypeLimit,
 		"opponent":         exchange.OrderTypeMarket,
 		OrderPriceOptimal5: exchange.OrderTypeMarket,
+		OrderPricePostOnly: exchange.OrderTypeLimit,
+		OrderPriceIOC:      exchange.OrderTypeLimit,
+		OrderPriceFOK:      exchange.OrderTypeLimit,
 	}
 )
 
 func NewOrdersChannel(symbol string) exchange.Channel {
 	return &ordersChannel{
+		topic:  TopicOrders,
+		symbol: symbol,
+	}
+}
+
+// NewMatchOrdersChannel return matchOrders channel which is pushed once the order
+// is matched, fee is not included in the trades, use orders channel for fee
+func NewMatchOrdersChannel(symbol string) exchange.Channel {
+	return &ordersChannel{
+		topic:  TopicMatchOrders,
 		symbol: symbol,
 	}
 }
 
 func (oc *ordersChannel) String() string {
-	return fmt.Sprintf("orders.%s", oc.symbol)
+	return fmt.Sprintf("%s.%s", oc.topic, oc.symbol)
 }
 
 func ParseOrder(raw []byte) (*exchange.Order, error) {
@@ -112,32 +131,16 @@ func ParseOrder(raw []byte) (*exchange.Order, error) {
 
 	st, ok := statusMap[resp.Status]
 	if !ok {
-		return nil, errors.Errorf("unkown orderstatus %s", resp.Status)
+		return nil, errors.Errorf("unkown orderstatus %d", resp.Status)
 	}
 	typ, ok := typeMap[resp.OrderPriceType]
 	if !ok {
 		return nil, errors.Errorf("unkown orderType %s", resp.OrderPriceType)
 	}
 
-	var side exchange.OrderSide
-	if resp.Direction == OrderDirectionBuy {
-		if resp.Offset == OrderOffsetOpen {
-			side = exchange.OrderSideBuy
-		} else if resp.Offset == OrderOffsetClose {
-			side = exchange.OrderSideCloseShort
-		} else {
-			return nil, errors.Errorf("unkown order offset '%s'", resp.Offset)
-		}
-	} else if resp.Direction == OrderDirectionSell {
-		if resp.Offset == OrderOffsetOpen {
-			side = exchange.OrderSideSell
-		} else if resp.Offset == OrderOffsetClose {
-			side = exchange.OrderSideCloseLong
-		} else {
-			return nil, errors.Errorf("unkown order offset '%s'", resp.Offset)
-		}
-	} else {
-		return nil, errors.Errorf("unkown order direction '%s'", resp.Direction)
+	side, err := future.ParseSide(resp.Direction, resp.Offset)
+	if err != nil {
+		return nil, err
 	}
 
 	return &exchange.Order{
@@ -158,3 +161,43 @@ func ParseOrder(raw []byte) (*exchange.Order, error) {
 		Raw:         &resp,
 	}, nil
 }
+
+// ParseOrderUpdate parse orders notify into order and its trades with fee
+func ParseOrderUpdate(raw []byte) (*OrderUpdate, error) {
+	return parseOrderUpdate(raw, true)
+}
+
+// ParseMatchOrderUpdate parse matchOrders notify into order and its trades,
+// Fee and FeeCurrency of the trades are left unset
+func ParseMatchOrderUpdate(raw []byte) (*OrderUpdate, error) {
+	return parseOrderUpdate(raw, false)
+}
+
+func parseOrderUpdate(raw []byte, withFee bool) (*OrderUpdate, error) {
+	order, err := ParseOrder(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	notify := order.Raw.(*OrderNotify)
+	trades := make([]*exchange.Trade, len(notify.Trades))
+	for i := range notify.Trades {
+		trade := notify.Trades[i].Transform(order)
+		if withFee {
+			trade.Fee = decimal.NewFromFloat(notify.Trades[i].TradeFee)
+			trade.FeeCurrency = notify.Trades[i].FeeAsset
+		}
+		trades[i] = trade
+	}
+
+	return &OrderUpdate{
+		Order:  order,
+		Trades: trades,
+	}, nil
+}
+
+// Transform trade of the order notify into exchange.Trade without fee, amount is in contracts
+func (ont *OrderNotifyTrade) Transform(order *exchange.Order) *exchange.Trade {
+	return huobi.NewOrderTrade(order, ont.ID, decimal.NewFromFloat(ont.TradePrice),
+		decimal.NewFromFloat(ont.TradeVolume), ont.CreatedAt, ont.Role == RoleMaker, ont)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -26,7 +27,8 @@ type (
 	}
 
 	Response struct {
-		Op string `json:"op"`
+		Op    string `json:"op"`
+		Topic string `json:"topic"`
 	}
 
 	subParam struct {
@@ -50,6 +52,13 @@ type (
 
 const (
 	SwapPrivateAddr = "wss://api.hbdm.com/swap-notification"
+
+	TopicOrders      = "orders"
+	TopicMatchOrders = "matchOrders"
+	TopicAccounts    = "accounts"
+	TopicPositions   = "positions"
+
+	RoleMaker = "maker"
 )
 
 func NewPrivateCodeC() *PrivateCodeC {
@@ -104,14 +113,32 @@ func (pcc *PrivateCodeC) Decode(raw []byte) (rpc.Response, error) {
 	}
 
 	if resp.Op == "notify" {
-		r, err := ParseOrder(msg)
+		var (
+			r   interface{}
+			err error
+		)
+		switch strings.SplitN(resp.Topic, ".", 2)[0] {
+		case TopicOrders:
+			r, err = ParseOrderUpdate(msg)
+
+		case TopicMatchOrders:
+			r, err = ParseMatchOrderUpdate(msg)
+
+		case TopicAccounts:
+			r, err = ParseAccounts(msg)
+
+		case TopicPositions:
+			r, err = ParsePositions(msg)
+
+		default:
+			return nil, errors.Errorf("unkown topic %s", resp.Topic)
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		op := r.Raw.(*OrderNotify)
 		return &rpc.Notify{
-			Method: op.Topic,
+			Method: resp.Topic,
 			Params: r,
 		}, nil
 	}
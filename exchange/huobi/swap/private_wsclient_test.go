@@ -0,0 +1,167 @@
+package swap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func decodePrivate(t *testing.T, raw string) *rpc.Notify {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(raw))
+	w.Close()
+
+	resp, err := NewPrivateCodeC().Decode(buf.Bytes())
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+	return resp.(*rpc.Notify)
+}
+
+// setTestSymbol add the symbol into contractMap, the returned func restore the map
+func setTestSymbol(code string) func() {
+	prev, ok := contractMap[code]
+	contractMap[code] = &Symbol{
+		exchange.NewBaseSwapSymbolWithCfg(code, decimal.NewFromInt(100), exchange.SymbolConfig{}, nil),
+	}
+	return func() {
+		if ok {
+			contractMap[code] = prev
+		} else {
+			delete(contractMap, code)
+		}
+	}
+}
+
+func TestDecodeOrderUpdate(t *testing.T) {
+	defer setTestSymbol("BTC-USD")()
+
+	orderNotify := `{
+		"op": "notify",
+		"topic": "%s.btc-usd",
+		"ts": 1600926986149,
+		"symbol": "BTC",
+		"contract_code": "BTC-USD",
+		"volume": 3,
+		"price": 10500,
+		"order_price_type": "limit",
+		"direction": "sell",
+		"offset": "open",
+		"status": 4,
+		"lever_rate": 10,
+		"order_id": 758688290195656704,
+		"order_id_str": "758688290195656704",
+		"client_order_id": null,
+		"order_type": 1,
+		"created_at": 1600926986104,
+		"trade_volume": 1,
+		"trade_turnover": 100,
+		"fee": -0.000000190476,
+		"trade_avg_price": 10500,
+		"margin_frozen": 0.002,
+		"profit": 0,
+		"trade": [{
+			"id": "9910-758688290195656704-1",
+			"trade_id": 9910,
+			"trade_volume": 1,
+			"trade_price": 10500,
+			"trade_fee": -0.000000190476,
+			"trade_turnover": 100,
+			"created_at": 1600926986130,
+			"role": "maker",
+			"fee_asset": "BTC"
+		}],
+		"canceled_at": 0,
+		"fee_asset": "BTC",
+		"real_profit": 0
+	}`
+
+	notify := decodePrivate(t, fmt.Sprintf(orderNotify, TopicOrders))
+	update := notify.Params.(*OrderUpdate)
+	order := update.Order
+	if notify.Method != "orders.btc-usd" || order.Side != exchange.OrderSideSell ||
+		order.Status != exchange.OrderStatusOpen || !order.Filled.Equal(decimal.NewFromInt(1)) ||
+		len(update.Trades) != 1 {
+		t.Fatalf("bad order %+v", *order)
+	}
+
+	trade := update.Trades[0]
+	if !trade.Fee.Equal(decimal.NewFromFloat(-0.000000190476)) || trade.FeeCurrency != "BTC" {
+		t.Errorf("bad orders trade fee %+v", *trade)
+	}
+
+	notify = decodePrivate(t, fmt.Sprintf(orderNotify, TopicMatchOrders))
+	update = notify.Params.(*OrderUpdate)
+	if notify.Method != "matchOrders.btc-usd" || update.Order.Side != exchange.OrderSideSell ||
+		update.Order.Status != exchange.OrderStatusOpen || len(update.Trades) != 1 {
+		t.Fatalf("bad order update %+v", *update)
+	}
+
+	trade = update.Trades[0]
+	if trade.OrderID != "758688290195656704" || !trade.IsMaker || trade.Side != exchange.OrderSideSell ||
+		!trade.Amount.Equal(decimal.NewFromInt(1)) || !trade.Price.Equal(decimal.NewFromInt(10500)) ||
+		!trade.Fee.IsZero() || trade.FeeCurrency != "" {
+		t.Errorf("bad trade %+v", *trade)
+	}
+
+	notify = decodePrivate(t, `{
+		"op": "notify",
+		"topic": "positions.btc-usd",
+		"ts": 1600926986149,
+		"event": "order.match",
+		"data": [{
+			"symbol": "BTC",
+			"contract_code": "BTC-USD",
+			"volume": 1,
+			"available": 1,
+			"frozen": 0,
+			"cost_open": 10500,
+			"cost_hold": 10500,
+			"profit_unreal": 0,
+			"profit_rate": 0,
+			"profit": 0,
+			"position_margin": 0.00095,
+			"lever_rate": 10,
+			"direction": "sell",
+			"last_price": 10500
+		}]
+	}`)
+	positions := notify.Params.([]*exchange.Position)
+	if len(positions) != 1 || positions[0].Side != exchange.PositionSideShort {
+		t.Errorf("bad positions %+v", positions)
+	}
+
+	notify = decodePrivate(t, `{
+		"op": "notify",
+		"topic": "accounts.btc-usd",
+		"ts": 1600926986149,
+		"event": "order.match",
+		"data": [{
+			"symbol": "BTC",
+			"contract_code": "BTC-USD",
+			"margin_balance": 0.01,
+			"margin_static": 0.01,
+			"margin_position": 0.00095,
+			"margin_frozen": 0.002,
+			"margin_available": 0.00705,
+			"profit_real": 0,
+			"profit_unreal": 0,
+			"withdraw_available": 0.00705,
+			"risk_rate": 8.4,
+			"liquidation_price": 95000,
+			"lever_rate": 10,
+			"adjust_factor": 0.075
+		}]
+	}`)
+	balances := notify.Params.(*exchange.Balances)
+	b, err := balances.Get("BTC")
+	if err != nil || !b.Free.Equal(decimal.RequireFromString("0.00705")) {
+		t.Errorf("bad balances %+v", balances)
+	}
+}
@@ -26,6 +26,13 @@ type (
 		passPhrase string
 		apiHost    string
 		test       bool
+		limiter    *request.Limiter
+	}
+
+	//StatusError response with non 200 status, the body may carry error code
+	StatusError struct {
+		StatusCode int
+		Body       []byte
 	}
 )
 
@@ -64,6 +71,15 @@ func (rc *RestClient) Request(ctx context.Context, method, endPoint string, para
 	return rc.request(ctx, method, endPoint, p, body, sign, dst)
 }
 
+// SetLimiter set the limiter which can be shared with other clients of the same account
+func (rc *RestClient) SetLimiter(l *request.Limiter) {
+	rc.limiter = l
+}
+
+func (rc *RestClient) Limiter() *request.Limiter {
+	return rc.limiter
+}
+
 func (rc *RestClient) Property() exchange.Property {
 	return exchange.Property{
 		Trades: &exchange.TradesProp{
@@ -82,7 +98,7 @@ func (rc *RestClient) request(ctx context.Context, method, endPoint string, para
 		return err
 	}
 
-	return request.DoReqWithCtx(req, func(resp *http.Response, ierr error) error {
+	return request.DoWithLimiter(rc.limiter, req, func(resp *http.Response, ierr error) error {
 		if ierr != nil {
 			return ierr
 		}
@@ -93,7 +109,7 @@ func (rc *RestClient) request(ctx context.Context, method, endPoint string, para
 		defer resp.Body.Close()
 
 		if resp.StatusCode != 200 {
-			return errors.Errorf("bad response %s", string(body))
+			return &StatusError{StatusCode: resp.StatusCode, Body: body}
 		}
 		if err := json.Unmarshal(body, dst); err != nil {
 			return errors.WithMessage(err, "unmarshal response fail")
@@ -102,6 +118,10 @@ func (rc *RestClient) request(ctx context.Context, method, endPoint string, para
 	})
 }
 
+func (se *StatusError) Error() string {
+	return fmt.Sprintf("bad response %s", string(se.Body))
+}
+
 func (rc *RestClient) buildRequest(ctx context.Context, method, endPoint string, param map[string]string, data io.Reader, sign bool) (*http.Request, error) {
 	var (
 		body string
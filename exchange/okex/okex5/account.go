@@ -55,6 +55,16 @@ type (
 		Limit   string
 	}
 
+	AccountConfig struct {
+		UID        string  `json:"uid"`
+		AcctLv     string  `json:"acctLv"`
+		PosMode    PosMode `json:"posMode"`
+		AutoLoan   bool    `json:"autoLoan"`
+		GreeksType string  `json:"greeksType"`
+		Level      string  `json:"level"`
+		LevelTmp   string  `json:"levelTmp"`
+	}
+
 	InterestAccrued struct {
 		InstID       string
 		Ccy          string
@@ -69,8 +79,36 @@ type (
 const (
 	BillsEndPoint           = "/api/v5/account/bills"
 	InterestAccruedEndPoint = "/api/v5/account/interest-accrued"
+	AccountConfigEndPoint   = "/api/v5/account/config"
 )
 
+// AccountConfig return account configuration, the result is cached since
+// posMode is required to create orders for derivatives. the cached config is
+// never modified, a copy is returned to the caller
+func (rc *RestClient) AccountConfig(ctx context.Context) (*AccountConfig, error) {
+	rc.mu.Lock()
+	config := rc.config
+	rc.mu.Unlock()
+	if config != nil {
+		ret := *config
+		return &ret, nil
+	}
+
+	var ret []AccountConfig
+	if err := rc.Request(ctx, http.MethodGet, AccountConfigEndPoint, nil, nil, true, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.Errorf("empty account config")
+	}
+	cached := ret[0]
+	rc.mu.Lock()
+	rc.config = &cached
+	rc.mu.Unlock()
+	return &ret[0], nil
+}
+
 func (rc *RestClient) InterestAccrued(ctx context.Context, iar *InterestAccruedReq) ([]InterestAccrued, error) {
 	values := url.Values{}
 	if iar.InstID != "" {
@@ -152,21 +190,7 @@ func (rc *RestClient) Bills(ctx context.Context, req *BillReq) ([]Bill, error) {
 }
 
 func (rc *RestClient) Finance(ctx context.Context, req *exchange.FinanceReqParam) ([]exchange.Finance, error) {
-	var it InstType
-	switch req.Symbol.(type) {
-	case exchange.MarginSymbol:
-		it = InstTypeMargin
-
-	case exchange.OptionSymbol:
-		it = InstTypeOption
-
-	case exchange.SwapSymbol:
-		it = InstTypeSwap
-
-	case exchange.SpotSymbol:
-		it = InstTypeSpot
-
-	}
+	it := instType(req.Symbol)
 
 	param := BillReq{
 		InstType: it,
@@ -213,28 +237,9 @@ func (b *Bill) Parse() (*exchange.Finance, error) {
 		return nil, err
 	}
 
-	var symbol exchange.Symbol
-	switch b.InstType {
-	case InstTypeSpot:
-		symbol, err = ParseSpotSymbol(b.InstID)
-		if err != nil {
-			return nil, err
-		}
-
-	case InstTypeMargin:
-		symbol, err = ParseMarginSymbol(b.InstID)
-		if err != nil {
-			return nil, err
-		}
-
-	case InstTypeSwap:
-		symbol, err = ParseSwapSymbol(b.InstID)
-		if err != nil {
-			return nil, err
-		}
-
-	default:
-		return nil, errors.Errorf("not support instType %s", b.InstType)
+	symbol, err := ParseInstSymbol(b.InstType, b.InstID)
+	if err != nil {
+		return nil, err
 	}
 
 	ret := &exchange.Finance{
@@ -5,6 +5,9 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
 )
 
 type (
@@ -64,3 +67,53 @@ func (r *RestClient) AccountBalance(ctx context.Context, currency ...string) (*A
 
 	return &ret[0], nil
 }
+
+// FetchBalance fetch trading account balances
+func (r *RestClient) FetchBalance(ctx context.Context, currencies ...string) (*exchange.Balances, error) {
+	ab, err := r.AccountBalance(ctx, currencies...)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := exchange.NewBalances()
+	for i := range ab.Details {
+		b, err := ab.Details[i].Transform()
+		if err != nil {
+			return nil, err
+		}
+		ret.Add(b)
+	}
+	ret.Raw = ab
+	return ret, nil
+}
+
+// Transform okex5 balance detail into exchange.Balance
+func (ad *AccountDetial) Transform() (*exchange.Balance, error) {
+	eq, err := parseDecimal(ad.Eq)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid eq")
+	}
+
+	cashBal, err := parseDecimal(ad.CashBal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid cashBal")
+	}
+
+	availBal, err := parseDecimal(ad.AvailBal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid availBal")
+	}
+
+	frozenBal, err := parseDecimal(ad.FrozenBal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid frozenBal")
+	}
+
+	return &exchange.Balance{
+		Currency: ad.Ccy,
+		Equitity: eq,
+		Total:    cashBal,
+		Free:     availBal,
+		Frozen:   frozenBal,
+	}, nil
+}
@@ -2,8 +2,11 @@ package okex5
 
 import (
 	"context"
+	"encoding/json"
 	"io"
+	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,6 +17,10 @@ import (
 type (
 	RestClient struct {
 		client *okex.RestClient
+		config *AccountConfig //cached account config, guarded by mu
+
+		mu       sync.Mutex
+		mgnModes map[string]MgnMode
 	}
 
 	GetRequest struct {
@@ -45,14 +52,18 @@ func (gr *GetRequest) Values() url.Values {
 }
 
 func NewRestClient(key, secret, pass string) *RestClient {
+	client := okex.NewRestClient(key, secret, pass)
+	client.SetLimiter(NewLimiter())
 	return &RestClient{
-		client: okex.NewRestClient(key, secret, pass),
+		client: client,
 	}
 }
 
 func NewTestRestClient(key, secret, pass string) *RestClient {
+	client := okex.NewTESTRestClient(key, secret, pass)
+	client.SetLimiter(NewLimiter())
 	return &RestClient{
-		client: okex.NewTESTRestClient(key, secret, pass),
+		client: client,
 	}
 }
 
@@ -63,16 +74,50 @@ func (rc *RestClient) Request(ctx context.Context, method string, endPoint strin
 	}
 
 	if err := rc.client.Request(ctx, method, endPoint, params, body, sign, &resp); err != nil {
-		return errors.WithMessagef(err, "request %s fail", endPoint)
+		return errors.WithMessagef(statusError(err), "request %s fail", endPoint)
 	}
 
 	if resp.Code != "0" {
-		return errors.Errorf("request: %s fail code: %s msg: %s, data: %+v", endPoint, resp.Code, resp.Msg, resp.Data)
+		return errors.WithMessagef(resp.error(), "request: %s fail, data: %+v", endPoint, resp.Data)
 	}
 
 	return nil
 }
 
+// error take the first failed sCode of trade operations, which is more specific
+// than the code of the response
+func (r *RestResponse) error() error {
+	raw, err := json.Marshal(r.Data)
+	if err == nil {
+		var results []wsOpResult
+		if json.Unmarshal(raw, &results) == nil {
+			for _, res := range results {
+				if res.SCode != "" && res.SCode != CodeOK {
+					return NewError(res.SCode, res.SMsg)
+				}
+			}
+		}
+	}
+	return NewError(r.Code, r.Msg)
+}
+
+// statusError parse error code carried by response with non 200 status
+func statusError(err error) error {
+	var se *okex.StatusError
+	if !errors.As(err, &se) {
+		return err
+	}
+
+	var resp RestResponse
+	if json.Unmarshal(se.Body, &resp) == nil && resp.Code != "" {
+		return errors.WithMessagef(resp.error(), "http status %d", se.StatusCode)
+	}
+	if se.StatusCode == http.StatusTooManyRequests {
+		return errors.WithMessage(exchange.ErrRateLimited, err.Error())
+	}
+	return err
+}
+
 func (rc *RestClient) Property() exchange.Property {
 	return exchange.Property{
 		Trades: &exchange.TradesProp{
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 )
 
 //ParseTimestamp parse okex5 api timestamp
@@ -17,3 +18,11 @@ func ParseTimestamp(ts string) (time.Time, error) {
 
 	return time.Unix(ret/1e3, ret%1e3*1e6), nil
 }
+
+// parseDecimal parse okex5 number field, empty string is treated as zero
+func parseDecimal(val string) (decimal.Decimal, error) {
+	if val == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(val)
+}
@@ -8,7 +8,8 @@ import (
 )
 
 const (
-	CodeOK = "0"
+	CodeOK            = "0"
+	CodeOrderNotFound = "51603"
 )
 
 type (
@@ -22,9 +23,13 @@ type (
 	ExecType      string
 	MgnMode       string
 	CtType        string
+	PosMode       string
 )
 
 const (
+	PosModeLongShort PosMode = "long_short_mode"
+	PosModeNet       PosMode = "net_mode"
+
 	CtTypeNone    CtType = ""
 	CtTypeLinear  CtType = "linear"
 	CtTypeInverse CtType = "Inverse"
@@ -90,6 +95,7 @@ var (
 	execTypeMap      map[string]ExecType      = make(map[string]ExecType)
 	mgnModeMap       map[string]MgnMode       = make(map[string]MgnMode)
 	ctTypeMap        map[string]CtType        = make(map[string]CtType)
+	posModeMap       map[string]PosMode       = make(map[string]PosMode)
 )
 
 func (it *InstType) UnmarshalJSON(raw []byte) error {
@@ -132,6 +138,10 @@ func (ct *CtType) UnmarshalJSON(raw []byte) error {
 	return assignMapPtr(ctTypeMap, "ctType", raw, ct)
 }
 
+func (pm *PosMode) UnmarshalJSON(raw []byte) error {
+	return assignMapPtr(posModeMap, "posMode", raw, pm)
+}
+
 func init() {
 	its := []InstType{
 		InstTypeSpot,
@@ -236,6 +246,14 @@ func init() {
 	for _, m := range mms {
 		mgnModeMap[string(m)] = m
 	}
+
+	pms := []PosMode{
+		PosModeLongShort,
+		PosModeNet,
+	}
+	for _, p := range pms {
+		posModeMap[string(p)] = p
+	}
 }
 
 func assignMapPtr(dict interface{}, typName string, rawKey []byte, dst interface{}) error {
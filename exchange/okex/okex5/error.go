@@ -0,0 +1,66 @@
+package okex5
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	//Error okex5 error carry code and msg return from api.
+	//for trade operations the code and msg are taken from sCode and sMsg
+	Error struct {
+		Code string
+		Msg  string
+	}
+)
+
+var (
+	codeErrors = map[string]error{
+		"50001":           exchange.ErrMaintenance,
+		"50011":           exchange.ErrRateLimited,
+		"50061":           exchange.ErrRateLimited,
+		"50102":           exchange.ErrTimestampOutOfWindow,
+		"50112":           exchange.ErrTimestampOutOfWindow,
+		"50113":           exchange.ErrInvalidSignature,
+		"51001":           exchange.ErrSymbolNotFound,
+		"51008":           exchange.ErrInsufficientBalance,
+		"51169":           exchange.ErrReduceOnlyRejected,
+		CodeOrderNotFound: exchange.ErrOrderNotFound,
+	}
+)
+
+func NewError(code string, msg string) error {
+	return &Error{
+		Code: code,
+		Msg:  msg,
+	}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("okex5 error code: %s msg: %s", e.Code, e.Msg)
+}
+
+// Is match *Error and the exchange error mapped from the code
+func (e *Error) Is(target error) bool {
+	if _, ok := target.(*Error); ok {
+		return true
+	}
+	err, ok := codeErrors[e.Code]
+	return ok && target == err
+}
+
+// IsOrderNotFound whether the error means the order does not exist
+func IsOrderNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Code == CodeOrderNotFound
+}
+
+// ClientIDMaxLen max length of clOrdId
+const ClientIDMaxLen = 32
+
+// NewClientIDGenerator generate alphanumeric clOrdId with the prefix
+func NewClientIDGenerator(prefix string) *exchange.ClientIDGenerator {
+	return exchange.NewClientIDGenerator(prefix, ClientIDMaxLen, false)
+}
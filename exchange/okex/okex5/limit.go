@@ -0,0 +1,49 @@
+package okex5
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/szmcdull/ccexgo/misc/request"
+)
+
+// NewLimiter limiter with per endpoint limits. trade and account endpoints are limited
+// by uid, so share the limiter among clients of the same account. public endpoints
+// are limited by ip
+func NewLimiter() *request.Limiter {
+	second2 := 2 * time.Second
+	return request.NewLimiter().
+		EndpointLimit(http.MethodPost, CreateOrderEndPoint, 60, second2).
+		EndpointLimit(http.MethodGet, FetchOrderEndPoint, 60, second2).
+		EndpointLimit(http.MethodPost, CancelOrderEndPoint, 60, second2).
+		EndpointLimit(http.MethodPost, AmendOrderEndPoint, 60, second2).
+		EndpointLimit(http.MethodGet, OrdersPendingEndPoint, 60, second2).
+		EndpointLimit(http.MethodGet, OrdersHistoryEndPoint, 40, second2).
+		EndpointLimit(http.MethodGet, FillsEndPoint, 60, second2).
+		EndpointLimit(http.MethodGet, AccountBalanceEndPoint, 10, second2).
+		EndpointLimit(http.MethodGet, PositionsEndPoint, 10, second2).
+		EndpointLimit(http.MethodGet, AccountConfigEndPoint, 5, second2).
+		EndpointLimit(http.MethodPost, SetLeverageEndPoint, 20, second2).
+		EndpointLimit(http.MethodGet, LeverageInfoEndPoint, 20, second2).
+		EndpointLimit(http.MethodPost, SetPositionModeEndPoint, 5, second2).
+		EndpointLimit(http.MethodGet, BillsEndPoint, 5, time.Second).
+		EndpointLimit(http.MethodGet, InterestAccruedEndPoint, 5, second2).
+		EndpointLimit(http.MethodGet, SubAccountBalancesEndPoint, 6, second2).
+		EndpointLimit(http.MethodGet, SubAccountBillsEndPoint, 6, time.Second).
+		EndpointLimit(http.MethodGet, AssetBillsEndPoint, 6, time.Second).
+		EndpointLimit(http.MethodGet, CurrenciesEndPoint, 6, time.Second).
+		EndpointLimit(http.MethodPost, TransferEndPoint, 1, time.Second).
+		EndpointLimit(http.MethodGet, WithdrawlHistoryEndPoint, 6, time.Second).
+		EndpointLimit(http.MethodGet, BooksEndPoint, 40, second2).
+		EndpointLimit(http.MethodGet, InstrumentEndPoint, 20, second2).
+		EndpointLimit(http.MethodGet, FundingEndPoint, 20, second2)
+}
+
+// SetLimiter replace the limiter, nil disables rate limiting
+func (rc *RestClient) SetLimiter(l *request.Limiter) {
+	rc.client.SetLimiter(l)
+}
+
+func (rc *RestClient) Limiter() *request.Limiter {
+	return rc.client.Limiter()
+}
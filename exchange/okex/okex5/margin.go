@@ -0,0 +1,187 @@
+package okex5
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	SetLeverageReq struct {
+		InstID  string  `json:"instId,omitempty"`
+		Ccy     string  `json:"ccy,omitempty"`
+		Lever   string  `json:"lever"`
+		MgnMode MgnMode `json:"mgnMode"`
+		PosSide PosSide `json:"posSide,omitempty"`
+	}
+
+	LeverageInfo struct {
+		InstID  string  `json:"instId"`
+		MgnMode MgnMode `json:"mgnMode"`
+		PosSide PosSide `json:"posSide"`
+		Lever   string  `json:"lever"`
+	}
+
+	SetPositionModeReq struct {
+		PosMode PosMode `json:"posMode"`
+	}
+)
+
+const (
+	SetLeverageEndPoint     = "/api/v5/account/set-leverage"
+	LeverageInfoEndPoint    = "/api/v5/account/leverage-info"
+	SetPositionModeEndPoint = "/api/v5/account/set-position-mode"
+
+	// CodePositionExists settings can not be changed due to open orders or positions
+	CodePositionExists = "59000"
+)
+
+func (rc *RestClient) SetLeverageRaw(ctx context.Context, req *SetLeverageReq) ([]SetLeverageReq, error) {
+	var ret []SetLeverageReq
+	if err := rc.doPostJSON(ctx, SetLeverageEndPoint, req, &ret); err != nil {
+		return nil, checkPositionExists(err)
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) LeverageInfo(ctx context.Context, instID string, mgnMode MgnMode) ([]LeverageInfo, error) {
+	req := NewGetRequest()
+	req.Add("instId", instID)
+	req.Add("mgnMode", string(mgnMode))
+
+	var ret []LeverageInfo
+	if err := rc.Request(ctx, http.MethodGet, LeverageInfoEndPoint, req.Values(), nil, true, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (rc *RestClient) SetPositionModeRaw(ctx context.Context, req *SetPositionModeReq) error {
+	var ret []SetPositionModeReq
+	if err := rc.doPostJSON(ctx, SetPositionModeEndPoint, req, &ret); err != nil {
+		return checkPositionExists(err)
+	}
+
+	rc.mu.Lock()
+	if rc.config != nil {
+		config := *rc.config
+		config.PosMode = req.PosMode
+		rc.config = &config
+	}
+	rc.mu.Unlock()
+	return nil
+}
+
+// SetLeverage set leverage of the symbol under the margin mode set by SetMarginMode.
+// isolated leverage of long and short position are set together in long_short_mode
+func (rc *RestClient) SetLeverage(ctx context.Context, sym exchange.Symbol, leverage decimal.Decimal) error {
+	req := &SetLeverageReq{
+		InstID:  sym.String(),
+		Lever:   leverage.String(),
+		MgnMode: rc.mgnMode(sym),
+	}
+
+	if req.MgnMode != MgnModeIsolated {
+		_, err := rc.SetLeverageRaw(ctx, req)
+		return err
+	}
+
+	cfg, err := rc.AccountConfig(ctx)
+	if err != nil {
+		return errors.WithMessage(err, "fetch account config fail")
+	}
+	if cfg.PosMode != PosModeLongShort {
+		_, err := rc.SetLeverageRaw(ctx, req)
+		return err
+	}
+
+	for _, side := range []PosSide{PosSideLong, PosSideShort} {
+		req.PosSide = side
+		if _, err := rc.SetLeverageRaw(ctx, req); err != nil {
+			return errors.WithMessagef(err, "set %s leverage fail", side)
+		}
+	}
+	return nil
+}
+
+func (rc *RestClient) GetLeverage(ctx context.Context, sym exchange.Symbol) (decimal.Decimal, error) {
+	infos, err := rc.LeverageInfo(ctx, sym.String(), rc.mgnMode(sym))
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	if len(infos) == 0 {
+		return decimal.Zero, errors.Errorf("no leverage info for '%s'", sym.String())
+	}
+	return parseDecimal(infos[0].Lever)
+}
+
+// SetMarginMode okex5 specific margin mode via tdMode of each order, the mode is
+// kept by the client and used by CreateOrder and leverage settings of the symbol
+func (rc *RestClient) SetMarginMode(ctx context.Context, sym exchange.Symbol, mode exchange.PositionMode) error {
+	var mgnMode MgnMode
+	switch mode {
+	case exchange.PositionModeFixed:
+		mgnMode = MgnModeIsolated
+
+	case exchange.PositionModeCross:
+		mgnMode = MgnModeCross
+
+	default:
+		return exchange.NewBadArg("unknown margin mode", mode)
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if rc.mgnModes == nil {
+		rc.mgnModes = map[string]MgnMode{}
+	}
+	rc.mgnModes[sym.String()] = mgnMode
+	return nil
+}
+
+func (rc *RestClient) GetMarginMode(ctx context.Context, sym exchange.Symbol) (exchange.PositionMode, error) {
+	if rc.mgnMode(sym) == MgnModeIsolated {
+		return exchange.PositionModeFixed, nil
+	}
+	return exchange.PositionModeCross, nil
+}
+
+func (rc *RestClient) SetPositionMode(ctx context.Context, hedge bool) error {
+	req := &SetPositionModeReq{
+		PosMode: PosModeNet,
+	}
+	if hedge {
+		req.PosMode = PosModeLongShort
+	}
+	return rc.SetPositionModeRaw(ctx, req)
+}
+
+func (rc *RestClient) GetPositionMode(ctx context.Context) (bool, error) {
+	cfg, err := rc.AccountConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	return cfg.PosMode == PosModeLongShort, nil
+}
+
+// mgnMode return the margin mode set by SetMarginMode, default is cross
+func (rc *RestClient) mgnMode(sym exchange.Symbol) MgnMode {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if mode, ok := rc.mgnModes[sym.String()]; ok {
+		return mode
+	}
+	return MgnModeCross
+}
+
+func checkPositionExists(err error) error {
+	var oe *Error
+	if errors.As(err, &oe) && oe.Code == CodePositionExists {
+		return exchange.NewPositionExists(err)
+	}
+	return err
+}
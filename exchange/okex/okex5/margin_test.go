@@ -0,0 +1,34 @@
+package okex5
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+var _ exchange.MarginManager = (*RestClient)(nil)
+
+func TestMarginMode(t *testing.T) {
+	sym := initTestSwapSymbol(t)
+	rc := NewRestClient("", "", "")
+
+	if mode, _ := rc.GetMarginMode(context.Background(), sym); mode != exchange.PositionModeCross {
+		t.Errorf("default margin mode should be cross")
+	}
+	if err := rc.SetMarginMode(context.Background(), sym, exchange.PositionModeFixed); err != nil {
+		t.Fatalf("set margin mode fail %s", err.Error())
+	}
+	if mode, _ := rc.GetMarginMode(context.Background(), sym); mode != exchange.PositionModeFixed || rc.mgnMode(sym) != MgnModeIsolated {
+		t.Errorf("margin mode should be isolated")
+	}
+
+	err := checkPositionExists(errors.WithMessage(NewError(CodePositionExists, "cancel any open orders"), "request fail"))
+	if !errors.Is(err, &exchange.ErrPositionExists{}) {
+		t.Errorf("bad position exists error %v", err)
+	}
+	if err := checkPositionExists(NewError("51000", "parameter error")); errors.Is(err, &exchange.ErrPositionExists{}) {
+		t.Errorf("bad error %v", err)
+	}
+}
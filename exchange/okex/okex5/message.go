@@ -13,6 +13,7 @@ type (
 	}
 
 	wsReq struct {
+		ID   string      `json:"id,omitempty"`
 		Op   string      `json:"op"`
 		Args interface{} `json:"args"`
 	}
@@ -24,6 +25,8 @@ type (
 	}
 
 	wsResp struct {
+		ID     string          `json:"id"`
+		Op     string          `json:"op"`
 		Event  string          `json:"event"`
 		Code   string          `json:"code"`
 		Msg    string          `json:"msg"`
@@ -32,13 +35,21 @@ type (
 		Data   json.RawMessage `json:"data"`
 	}
 
+	//wsOpResult common fields of trade operation result
+	wsOpResult struct {
+		SCode string `json:"sCode"`
+		SMsg  string `json:"sMsg"`
+	}
+
 	parseCB func(*wsResp) (*rpc.Notify, error)
 )
 
 const (
 	eventError = "error"
-	pingMethod = "ping"
-	pongMethod = "pong"
+	//codePartial batch operation partially succeeded
+	codePartial = "2"
+	pingMethod  = "ping"
+	pongMethod  = "pong"
 )
 
 var (
@@ -61,7 +72,13 @@ func (cc *CodeC) Encode(req rpc.Request) ([]byte, error) {
 		Op:   method,
 		Args: data,
 	}
-	cc.LastEvent = method
+
+	//trade operations are correlated by request id, others by event
+	if isTradeOp(method) {
+		r.ID = req.ID()
+	} else {
+		cc.LastEvent = method
+	}
 
 	return json.Marshal(&r)
 }
@@ -78,6 +95,10 @@ func (cc *CodeC) Decode(raw []byte) (rpc.Response, error) {
 		return nil, err
 	}
 
+	if resp.ID != "" && resp.Op != "" {
+		return resp.opResult()
+	}
+
 	if resp.Event != "" {
 		if resp.Event == eventError {
 			return &rpc.Result{
@@ -103,3 +124,32 @@ func (r *wsResp) transfer() (*rpc.Notify, error) {
 	}
 	return cb(r)
 }
+
+// opResult build rpc result for trade operations. code "2" means batch
+// operation partially succeeded and each result should be checked by sCode
+func (r *wsResp) opResult() (*rpc.Result, error) {
+	ret := &rpc.Result{
+		ID:     r.ID,
+		Result: r.Data,
+	}
+
+	if r.Code == CodeOK || r.Code == codePartial {
+		return ret, nil
+	}
+
+	var results []wsOpResult
+	if len(r.Data) != 0 {
+		if err := json.Unmarshal(r.Data, &results); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, res := range results {
+		if res.SCode != "" && res.SCode != CodeOK {
+			ret.Error = NewError(res.SCode, res.SMsg)
+			return ret, nil
+		}
+	}
+	ret.Error = NewError(r.Code, r.Msg)
+	return ret, nil
+}
@@ -0,0 +1,102 @@
+package okex5
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+func TestDecodeOpResult(t *testing.T) {
+	cc := NewCodec()
+	message := `{
+		"id": "1512",
+		"op": "order",
+		"data": [{
+			"clOrdId": "",
+			"ordId": "",
+			"tag": "",
+			"sCode": "51008",
+			"sMsg": "Order placement failed due to insufficient balance"
+		}],
+		"code": "1",
+		"msg": ""
+	}`
+	resp, err := cc.Decode([]byte(message))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+	result := resp.(*rpc.Result)
+
+	var e *Error
+	if !errors.As(result.Error, &e) {
+		t.Fatalf("bad result error %v", result.Error)
+	}
+	if result.ID != "1512" || e.Code != "51008" {
+		t.Errorf("bad result %v", *result)
+	}
+
+	message = `{
+		"id": "1513",
+		"op": "batch-orders",
+		"data": [{
+			"clOrdId": "",
+			"ordId": "12345689",
+			"tag": "",
+			"sCode": "0",
+			"sMsg": ""
+		}, {
+			"clOrdId": "",
+			"ordId": "",
+			"tag": "",
+			"sCode": "51008",
+			"sMsg": "Order placement failed due to insufficient balance"
+		}],
+		"code": "2",
+		"msg": ""
+	}`
+	resp, err = cc.Decode([]byte(message))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+	result = resp.(*rpc.Result)
+	if result.ID != "1513" || result.Error != nil {
+		t.Errorf("bad result %v", *result)
+	}
+}
+
+func TestDecodeAccount(t *testing.T) {
+	cc := NewCodec()
+	message := `{
+		"arg": {"channel": "account", "ccy": "BTC"},
+		"data": [{
+			"uTime": "1597026383085",
+			"totalEq": "41624.32",
+			"details": [{
+				"ccy": "BTC",
+				"eq": "1.2",
+				"cashBal": "1",
+				"uTime": "1597026383085",
+				"availBal": "0.8",
+				"frozenBal": "0.2"
+			}]
+		}]
+	}`
+	resp, err := cc.Decode([]byte(message))
+	if err != nil {
+		t.Fatalf("decode fail %s", err.Error())
+	}
+
+	updates := resp.(*rpc.Notify).Params.([]*exchange.BalanceUpdate)
+	if len(updates) != 1 {
+		t.Fatalf("bad updates %+v", updates)
+	}
+
+	u := updates[0]
+	if u.Currency != "BTC" || !u.Equitity.Equal(decimal.RequireFromString("1.2")) ||
+		!u.Free.Equal(decimal.RequireFromString("0.8")) || u.Time.UnixMilli() != 1597026383085 {
+		t.Errorf("bad update %+v", *u)
+	}
+}
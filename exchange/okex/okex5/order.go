@@ -0,0 +1,364 @@
+package okex5
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	//TDModeOption specific trade mode of the order, default is cash for spot
+	//and cross for margin and derivatives
+	TDModeOption struct {
+		Mode TDMode
+	}
+
+	//ExchangeClient implement order methods with exchange.OrderRequest and exchange.Order,
+	//the methods of RestClient with the same names take okex5 requests
+	ExchangeClient struct {
+		*RestClient
+	}
+)
+
+const (
+	OrdersPendingEndPoint = "/api/v5/trade/orders-pending"
+)
+
+var (
+	orderStatusMap = map[OrderState]exchange.OrderStatus{
+		OrderStateLive:            exchange.OrderStatusOpen,
+		OrderStatePartiallyFilled: exchange.OrderStatusOpen,
+		OrderStateFilled:          exchange.OrderStatusDone,
+		OrderStateCanceled:        exchange.OrderStatusCancel,
+	}
+)
+
+func NewTDModeOption(mode TDMode) exchange.OrderReqOption {
+	return &TDModeOption{
+		Mode: mode,
+	}
+}
+
+func NewExchangeClient(rc *RestClient) *ExchangeClient {
+	return &ExchangeClient{
+		RestClient: rc,
+	}
+}
+
+// NewCreateOrderReq build okex5 order request from exchange.OrderRequest. posMode
+// is only used for swap and futures, the request can be sent via RestClient or WSClient
+func NewCreateOrderReq(req *exchange.OrderRequest, posMode PosMode, options ...exchange.OrderReqOption) (*CreateOrderReq, error) {
+	it := instType(req.Symbol)
+	ret := &CreateOrderReq{
+		InstID: req.Symbol.String(),
+		Sz:     req.Amount.String(),
+	}
+
+	if req.ClientID != nil {
+		ret.ClOrderID = req.ClientID.String()
+	}
+
+	switch it {
+	case InstTypeSpot:
+		ret.TDMode = TDModeCash
+
+	case InstTypeMargin, InstTypeSwap, InstTypeFutures:
+		ret.TDMode = TDModeCross
+
+	default:
+		return nil, errors.Errorf("unsupport symbol '%s'", req.Symbol.String())
+	}
+
+	switch req.Type {
+	case exchange.OrderTypeLimit:
+		ret.OrdType = OrdTypeLimit
+		ret.Px = req.Price.String()
+
+	case exchange.OrderTypeMarket:
+		ret.OrdType = OrdTypeMaket
+
+	default:
+		return nil, errors.Errorf("unsupport order type '%s'", req.Type)
+	}
+
+	hedge := posMode == PosModeLongShort && (it == InstTypeSwap || it == InstTypeFutures)
+	ts, err := exchange.NewTradeSide(req.Side, hedge)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.Side = OrderSideSell
+	if ts.IsBuy() {
+		ret.Side = OrderSideBuy
+	}
+	if hedge {
+		ret.PosSide = PosSideShort
+		if ts.PositionSide == exchange.PositionSideLong {
+			ret.PosSide = PosSideLong
+		}
+	}
+	ret.ReduecOnly = ts.ReduceOnly()
+
+	if it == InstTypeSpot && ret.ReduecOnly {
+		return nil, errors.Errorf("spot order can not close position")
+	}
+
+	for _, opt := range options {
+		switch t := opt.(type) {
+		case *exchange.PostOnlyOption:
+			if t.PostOnly {
+				if ret.OrdType != OrdTypeLimit {
+					return nil, errors.Errorf("post only is only support for limit order")
+				}
+				ret.OrdType = OrdTypePostOnly
+			}
+
+		case *exchange.TimeInForceOption:
+			if ret.OrdType != OrdTypeLimit {
+				return nil, errors.Errorf("time in force is only support for limit order")
+			}
+			switch t.Flag {
+			case exchange.TimeInForceFOK:
+				ret.OrdType = OrdTypeFOK
+
+			case exchange.TimeInForceIOC:
+				ret.OrdType = OrdTypeIOC
+			}
+
+		case *TDModeOption:
+			ret.TDMode = t.Mode
+
+		default:
+			return nil, errors.Errorf("unsupport option %+v", opt)
+		}
+	}
+
+	return ret, nil
+}
+
+// CreateOrder create order with exchange.OrderRequest, amount is the number of
+// contracts for swap and futures symbols
+func (ec *ExchangeClient) CreateOrder(ctx context.Context, req *exchange.OrderRequest, options ...exchange.OrderReqOption) (*exchange.Order, error) {
+	var posMode PosMode
+	switch instType(req.Symbol) {
+	case InstTypeSwap, InstTypeFutures:
+		cfg, err := ec.AccountConfig(ctx)
+		if err != nil {
+			return nil, errors.WithMessage(err, "fetch account config fail")
+		}
+		posMode = cfg.PosMode
+	}
+
+	ec.mu.Lock()
+	mgnMode, ok := ec.mgnModes[req.Symbol.String()]
+	ec.mu.Unlock()
+	if ok {
+		// options given by caller take precedence over SetMarginMode
+		options = append([]exchange.OrderReqOption{NewTDModeOption(TDMode(mgnMode))}, options...)
+	}
+
+	oReq, err := NewCreateOrderReq(req, posMode, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ec.RestClient.CreateOrder(ctx, oReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.SCode != CodeOK {
+		return nil, NewError(resp.SCode, resp.SMsg)
+	}
+
+	ts := time.Now()
+	return &exchange.Order{
+		ID:       exchange.NewStrID(resp.OrderID),
+		ClientID: exchange.NewStrID(resp.ClOrderID),
+		Symbol:   req.Symbol,
+		Amount:   req.Amount,
+		Price:    req.Price,
+		Side:     req.Side,
+		Type:     req.Type,
+		Status:   exchange.OrderStatusOpen,
+		Created:  ts,
+		Updated:  ts,
+		Raw:      resp,
+	}, nil
+}
+
+// CancelOrder cancel order by ID, ClientID is used if ID is not set
+func (ec *ExchangeClient) CancelOrder(ctx context.Context, order *exchange.Order) error {
+	req := &CancelOrderReq{
+		InstID: order.Symbol.String(),
+	}
+
+	if order.ID != nil {
+		req.OrdId = order.ID.String()
+	} else if order.ClientID != nil {
+		req.ClOrdID = order.ClientID.String()
+	} else {
+		return errors.Errorf("order id or client id is required")
+	}
+
+	resp, err := ec.RestClient.CancelOrder(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if resp.SCode != CodeOK {
+		return NewError(resp.SCode, resp.SMsg)
+	}
+	return nil
+}
+
+// FetchOrder fetch order by ID, ClientID is used if ID is not set
+func (ec *ExchangeClient) FetchOrder(ctx context.Context, order *exchange.Order) (*exchange.Order, error) {
+	req := &FetchOrderReq{
+		InstID: order.Symbol.String(),
+	}
+
+	if order.ID != nil {
+		req.OrdID = order.ID.String()
+	} else if order.ClientID != nil {
+		req.ClOrdID = order.ClientID.String()
+	} else {
+		return nil, errors.Errorf("order id or client id is required")
+	}
+
+	resp, err := ec.RestClient.FetchOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transform()
+}
+
+// FetchOpenOrders fetch all open orders of the symbol
+func (rc *RestClient) FetchOpenOrders(ctx context.Context, sym exchange.Symbol) ([]*exchange.Order, error) {
+	values := url.Values{}
+	values.Add("instType", string(instType(sym)))
+	values.Add("instId", sym.String())
+
+	var orders []Order
+	if err := rc.Request(ctx, http.MethodGet, OrdersPendingEndPoint, values, nil, true, &orders); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Order, 0, len(orders))
+	for i := range orders {
+		o, err := orders[i].Transform()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, o)
+	}
+	return ret, nil
+}
+
+// Transform okex5 order into exchange.Order, amount is the number of contracts
+// for swap and futures symbols
+func (o *Order) Transform() (*exchange.Order, error) {
+	sym, err := ParseInstSymbol(o.InstType, o.InstId)
+	if err != nil {
+		return nil, err
+	}
+	return o.transform(sym)
+}
+
+func (o *Order) transform(sym exchange.Symbol) (*exchange.Order, error) {
+	status, ok := orderStatusMap[o.State]
+	if !ok {
+		return nil, errors.Errorf("unknown order state '%s'", o.State)
+	}
+
+	side, err := parseOrderSide(o.Side, o.PosSide, o.ReduceOnly == "true")
+	if err != nil {
+		return nil, err
+	}
+
+	typ := exchange.OrderTypeLimit
+	if o.OrderType == OrdTypeMaket {
+		typ = exchange.OrderTypeMarket
+	}
+
+	sz, err := parseDecimal(o.Sz)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid sz")
+	}
+
+	filled, err := parseDecimal(o.AccFillSZ)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid accFillSz")
+	}
+
+	px, err := parseDecimal(o.Px)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid px")
+	}
+
+	avgPx, err := parseDecimal(o.AvgPx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid avgPx")
+	}
+
+	fee, err := parseDecimal(o.Fee)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid fee")
+	}
+
+	created, err := ParseTimestamp(o.CTime)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid cTime")
+	}
+
+	updated, err := ParseTimestamp(o.UTime)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid uTime")
+	}
+
+	return &exchange.Order{
+		ID:          exchange.NewStrID(o.OrderID),
+		ClientID:    exchange.NewStrID(o.ClOrdID),
+		Symbol:      sym,
+		Amount:      sz,
+		Filled:      filled,
+		Price:       px,
+		AvgPrice:    avgPx,
+		Fee:         fee,
+		FeeCurrency: o.FeeCcy,
+		Created:     created,
+		Updated:     updated,
+		Side:        side,
+		Status:      status,
+		Type:        typ,
+		Raw:         o,
+	}, nil
+}
+
+// parseOrderSide translate side, posSide and reduceOnly into exchange.OrderSide, reduce
+// only order in net mode is closing order
+func parseOrderSide(side OrderSide, posSide PosSide, reduceOnly bool) (exchange.OrderSide, error) {
+	if side != OrderSideBuy && side != OrderSideSell {
+		return exchange.OrderSideBuy, errors.Errorf("unknown side '%s'", side)
+	}
+
+	buy := side == OrderSideBuy
+	switch posSide {
+	case PosSideLong:
+		return exchange.ParseTradeSide(buy, true, exchange.PositionSideLong, false).OrderSide(), nil
+
+	case PosSideShort:
+		return exchange.ParseTradeSide(buy, true, exchange.PositionSideShort, false).OrderSide(), nil
+
+	case PosSideNet, PosSideNone:
+		return exchange.ParseTradeSide(buy, false, exchange.PositionSideLong, reduceOnly).OrderSide(), nil
+
+	default:
+		return exchange.OrderSideBuy, errors.Errorf("unknown posSide '%s'", posSide)
+	}
+}
@@ -0,0 +1,112 @@
+package okex5
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+func initTestSwapSymbol(t *testing.T) exchange.Symbol {
+	it := &Instrument{
+		InstType: InstTypeSwap,
+		InstID:   "BTC-USDT-SWAP",
+		Uly:      "BTC-USDT",
+		CtVal:    "0.01",
+		TickSz:   "0.1",
+		LotSz:    "1",
+		MinSz:    "1",
+	}
+	sym, err := it.Parse()
+	if err != nil {
+		t.Fatalf("parse instrument fail %s", err.Error())
+	}
+	return sym
+}
+
+func TestOrderTransform(t *testing.T) {
+	sym := initTestSwapSymbol(t)
+	if !sym.AmountPrecision().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("bad amount precision %s", sym.AmountPrecision())
+	}
+
+	raw := `{
+		"instType": "SWAP",
+		"instId": "BTC-USDT-SWAP",
+		"ordId": "312269865356374016",
+		"clOrdId": "b1",
+		"px": "999",
+		"sz": "3",
+		"ordType": "limit",
+		"side": "sell",
+		"posSide": "long",
+		"tdMode": "cross",
+		"accFillSz": "1",
+		"avgPx": "",
+		"state": "partially_filled",
+		"category": "normal",
+		"fee": "-0.01",
+		"feeCcy": "USDT",
+		"uTime": "1597026383085",
+		"cTime": "1597026383085"
+	}`
+	var o Order
+	if err := json.Unmarshal([]byte(raw), &o); err != nil {
+		t.Fatalf("unmarshal fail %s", err.Error())
+	}
+
+	order, err := o.transform(sym)
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+
+	if order.Side != exchange.OrderSideCloseLong || order.Status != exchange.OrderStatusOpen ||
+		!order.Amount.Equal(decimal.NewFromInt(3)) ||
+		!order.Filled.Equal(decimal.NewFromInt(1)) || !order.AvgPrice.IsZero() {
+		t.Errorf("bad order %+v", *order)
+	}
+}
+
+func TestPositionTransform(t *testing.T) {
+	sym := initTestSwapSymbol(t)
+	p := Positions{
+		InstType: "SWAP",
+		InstID:   "BTC-USDT-SWAP",
+		MgnMode:  "cross",
+		PosSide:  "net",
+		Pos:      "-5",
+		AvailPos: "",
+		AvgPx:    "20000",
+		Lever:    "10",
+		IMR:      "10",
+		CTime:    "1597026383085",
+	}
+
+	pos, err := p.transform(sym)
+	if err != nil {
+		t.Fatalf("transform fail %s", err.Error())
+	}
+
+	if pos.Side != exchange.PositionSideShort || pos.Mode != exchange.PositionModeCross ||
+		!pos.Position.Equal(decimal.NewFromInt(5)) || !pos.Margin.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("bad position %+v", *pos)
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	resp := &RestResponse{
+		Code: "1",
+		Msg:  "Operation failed.",
+		Data: []wsOpResult{{SCode: "51008", SMsg: "Order placement failed due to insufficient balance"}},
+	}
+	err := errors.WithMessage(resp.error(), "request fail")
+	if !errors.Is(err, exchange.ErrInsufficientBalance) || errors.Is(err, exchange.ErrOrderNotFound) {
+		t.Errorf("bad error %v", err)
+	}
+
+	if !errors.Is(NewError(CodeOrderNotFound, "Order does not exist"), exchange.ErrOrderNotFound) {
+		t.Errorf("order not found expected")
+	}
+}
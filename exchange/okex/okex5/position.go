@@ -3,8 +3,10 @@ package okex5
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
 )
 
 type (
@@ -40,6 +42,7 @@ type (
 		PosCcy      string `json:"posCcy"`
 		PosID       string `json:"posId"`
 		PosSide     string `json:"posSide"`
+		RealizedPnl string `json:"realizedPnl"`
 		ThetaBS     string `json:"thetaBS"`
 		ThetaPA     string `json:"thetaPA"`
 		TradeID     string `json:"tradeId"`
@@ -59,6 +62,13 @@ const (
 	PositionsEndPoint = "/api/v5/account/positions"
 )
 
+var (
+	positionModeMap = map[string]exchange.PositionMode{
+		string(MgnModeCross):    exchange.PositionModeCross,
+		string(MgnModeIsolated): exchange.PositionModeFixed,
+	}
+)
+
 func NewPositionsReq() *PositionsReq {
 	return &PositionsReq{
 		NewGetRequest(),
@@ -88,3 +98,139 @@ func (rc *RestClient) Positions(ctx context.Context, req *PositionsReq) ([]Posit
 
 	return ret, nil
 }
+
+// FetchPosition fetch positions of given symbols, all positions are returned if no symbol specific
+func (rc *RestClient) FetchPosition(ctx context.Context, sym ...exchange.Symbol) ([]*exchange.Position, error) {
+	req := NewPositionsReq()
+	if len(sym) != 0 {
+		ids := make([]string, len(sym))
+		for i, s := range sym {
+			ids[i] = s.String()
+		}
+		req.InstID(strings.Join(ids, ","))
+	}
+
+	positions, err := rc.Positions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*exchange.Position, 0, len(positions))
+	for i := range positions {
+		p, err := positions[i].Transform()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+// Transform okex5 position into exchange.Position. side of net mode position
+// is decided by the sign of pos
+func (p *Positions) Transform() (*exchange.Position, error) {
+	sym, err := ParseInstSymbol(InstType(p.InstType), p.InstID)
+	if err != nil {
+		return nil, err
+	}
+	return p.transform(sym)
+}
+
+func (p *Positions) transform(sym exchange.Symbol) (*exchange.Position, error) {
+	mode, ok := positionModeMap[p.MgnMode]
+	if !ok {
+		return nil, errors.Errorf("unknown mgnMode '%s'", p.MgnMode)
+	}
+
+	pos, err := parseDecimal(p.Pos)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid pos")
+	}
+
+	availPos, err := parseDecimal(p.AvailPos)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid availPos")
+	}
+
+	var side exchange.PositionSide
+	switch PosSide(p.PosSide) {
+	case PosSideLong:
+		side = exchange.PositionSideLong
+
+	case PosSideShort:
+		side = exchange.PositionSideShort
+
+	case PosSideNet:
+		side = exchange.PositionSideLong
+		if pos.IsNegative() {
+			side = exchange.PositionSideShort
+			pos = pos.Neg()
+			availPos = availPos.Abs()
+		}
+
+	default:
+		return nil, errors.Errorf("unknown posSide '%s'", p.PosSide)
+	}
+
+	avgPx, err := parseDecimal(p.AvgPx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid avgPx")
+	}
+
+	liqPx, err := parseDecimal(p.LiqPx)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid liqPx")
+	}
+
+	//margin is only available for isolated position
+	marginVal := p.Margin
+	if mode == exchange.PositionModeCross {
+		marginVal = p.IMR
+	}
+	margin, err := parseDecimal(marginVal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid margin")
+	}
+
+	mgnRatio, err := parseDecimal(p.MgnRatio)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid mgnRatio")
+	}
+
+	upl, err := parseDecimal(p.Upl)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid upl")
+	}
+
+	realizedPnl, err := parseDecimal(p.RealizedPnl)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid realizedPnl")
+	}
+
+	lever, err := parseDecimal(p.Lever)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid lever")
+	}
+
+	ct, err := ParseTimestamp(p.CTime)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid cTime")
+	}
+
+	return &exchange.Position{
+		Symbol:           sym,
+		Mode:             mode,
+		Side:             side,
+		LiquidationPrice: liqPx,
+		AvgOpenPrice:     avgPx,
+		CreateTime:       ct,
+		Margin:           margin,
+		MarginMaintRatio: mgnRatio,
+		Position:         pos,
+		AvailPosition:    availPos,
+		RealizedPNL:      realizedPnl,
+		UNRealizedPNL:    upl,
+		Leverage:         lever,
+		Raw:              p,
+	}, nil
+}
@@ -47,6 +47,10 @@ type (
 	SwapSymbol struct {
 		*exchange.BaseSwapSymbol
 	}
+
+	FuturesSymbol struct {
+		*exchange.BaseFutureSymbol
+	}
 )
 
 const (
@@ -54,9 +58,17 @@ const (
 )
 
 var (
-	spotSymbolMap   = map[string]exchange.SpotSymbol{}
-	swapSymbolMap   = map[string]exchange.SwapSymbol{}
-	marginSymbolMap = map[string]exchange.MarginSymbol{}
+	spotSymbolMap    = map[string]exchange.SpotSymbol{}
+	swapSymbolMap    = map[string]exchange.SwapSymbol{}
+	marginSymbolMap  = map[string]exchange.MarginSymbol{}
+	futuresSymbolMap = map[string]exchange.FuturesSymbol{}
+
+	aliasMap = map[string]exchange.FutureType{
+		"this_week":    exchange.FutureTypeCW,
+		"next_week":    exchange.FutureTypeNW,
+		"quarter":      exchange.FutureTypeCQ,
+		"next_quarter": exchange.FutureTypeNQ,
+	}
 )
 
 func (rc *RestClient) Instruments(ctx context.Context, typ InstType) ([]Instrument, error) {
@@ -70,7 +82,7 @@ func (rc *RestClient) Instruments(ctx context.Context, typ InstType) ([]Instrume
 	return ret, nil
 }
 
-// Symbols return all spot + margin + swap symbols
+// Symbols return all spot + margin + swap + futures symbols
 func (rc *RestClient) Symbols(ctx context.Context) ([]exchange.Symbol, error) {
 	var ret []exchange.Symbol
 	spots, err := rc.SpotSymbols(ctx)
@@ -97,6 +109,14 @@ func (rc *RestClient) Symbols(ctx context.Context) ([]exchange.Symbol, error) {
 	for _, s := range swaps {
 		ret = append(ret, s)
 	}
+
+	futures, err := rc.FuturesSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range futures {
+		ret = append(ret, f)
+	}
 	return ret, nil
 }
 
@@ -125,6 +145,14 @@ func (rc *RestClient) SwapSymbols(ctx context.Context) ([]exchange.SwapSymbol, e
 	return symbols.([]exchange.SwapSymbol), nil
 }
 
+func (rc *RestClient) FuturesSymbols(ctx context.Context) ([]exchange.FuturesSymbol, error) {
+	symbols, err := rc.symbols(ctx, InstTypeFutures)
+	if err != nil {
+		return nil, err
+	}
+	return symbols.([]exchange.FuturesSymbol), nil
+}
+
 func (rc *RestClient) symbols(ctx context.Context, it InstType) (interface{}, error) {
 	its, err := rc.Instruments(ctx, it)
 	if err != nil {
@@ -142,6 +170,9 @@ func (rc *RestClient) symbols(ctx context.Context, it InstType) (interface{}, er
 
 	case InstTypeSwap:
 		arr = make([]exchange.SwapSymbol, 0, len(its))
+
+	case InstTypeFutures:
+		arr = make([]exchange.FuturesSymbol, 0, len(its))
 	}
 
 	arrValue := reflect.ValueOf(arr)
@@ -201,6 +232,15 @@ func initSymbols(ctx context.Context, isTest bool) error {
 		marginSymbolMap[m.String()] = m
 	}
 
+	futures, err := rc.FuturesSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range futures {
+		futuresSymbolMap[f.String()] = f
+	}
+
 	return nil
 }
 
@@ -219,9 +259,34 @@ func ParseSymbol(sym string) (ret exchange.Symbol, err error) {
 	if err == nil {
 		return ret, nil
 	}
+
+	ret, err = ParseFuturesSymbol(sym)
+	if err == nil {
+		return ret, nil
+	}
 	return nil, err
 }
 
+// ParseInstSymbol parse symbol with instType, spot and margin symbol share the same instId
+func ParseInstSymbol(it InstType, sym string) (exchange.Symbol, error) {
+	switch it {
+	case InstTypeSpot:
+		return ParseSpotSymbol(sym)
+
+	case InstTypeMargin:
+		return ParseMarginSymbol(sym)
+
+	case InstTypeSwap:
+		return ParseSwapSymbol(sym)
+
+	case InstTypeFutures:
+		return ParseFuturesSymbol(sym)
+
+	default:
+		return nil, errors.Errorf("unsupport instType '%s'", it)
+	}
+}
+
 func ParseSpotSymbol(sym string) (exchange.SpotSymbol, error) {
 	s, ok := spotSymbolMap[sym]
 	if !ok {
@@ -247,6 +312,16 @@ func ParseSwapSymbol(sym string) (exchange.SwapSymbol, error) {
 	return s, nil
 }
 
+func ParseFuturesSymbol(sym string) (exchange.FuturesSymbol, error) {
+	s, ok := futuresSymbolMap[sym]
+	if !ok {
+		return nil, errors.Errorf("unsupport symbol '%s'", sym)
+	}
+	return s, nil
+}
+
+// Parse the instrument into symbol. amount of swap and futures symbol is
+// the number of contracts
 func (it *Instrument) Parse() (exchange.Symbol, error) {
 	pp, err := decimal.NewFromString(it.TickSz)
 	if err != nil {
@@ -293,6 +368,23 @@ func (it *Instrument) Parse() (exchange.Symbol, error) {
 			exchange.NewBaseSwapSymbolWithCfg(it.Uly, ctVal, *cfg, it),
 		}, nil
 
+	case InstTypeFutures:
+		ctVal, err := decimal.NewFromString(it.CtVal)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "parse ctrVal fail %+v", it)
+		}
+		typ, ok := aliasMap[it.Alias]
+		if !ok {
+			return nil, errors.Errorf("unsupport alias '%s'", it.Alias)
+		}
+		st, err := ParseTimestamp(it.ExpTime)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid expTime")
+		}
+		return &FuturesSymbol{
+			exchange.NewBaseFuturesSymbolWithCfgCV(it.Uly, st, typ, *cfg, ctVal, it),
+		}, nil
+
 	default:
 		return nil, errors.Errorf("unsupport instType '%s'", it.InstType)
 	}
@@ -312,3 +404,32 @@ func (ss *SwapSymbol) String() string {
 	it := ss.Raw().(*Instrument)
 	return it.InstID
 }
+
+func (fs *FuturesSymbol) String() string {
+	it := fs.Raw().(*Instrument)
+	return it.InstID
+}
+
+// instType return the instType of symbol, futures symbol should be checked
+// before swap symbol since it implement exchange.SwapSymbol as well
+func instType(sym exchange.Symbol) InstType {
+	switch sym.(type) {
+	case exchange.MarginSymbol:
+		return InstTypeMargin
+
+	case exchange.OptionSymbol:
+		return InstTypeOption
+
+	case exchange.FuturesSymbol:
+		return InstTypeFutures
+
+	case exchange.SwapSymbol:
+		return InstTypeSwap
+
+	case exchange.SpotSymbol:
+		return InstTypeSpot
+
+	default:
+		return InstTypeNone
+	}
+}
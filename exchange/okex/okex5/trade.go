@@ -19,7 +19,7 @@ type (
 		InstID     string    `json:"instId"`
 		TDMode     TDMode    `json:"tdMode"`
 		Ccy        string    `json:"ccy,omitempty"`
-		ClOrderID  string    `json:"clOrderId,omitempty"`
+		ClOrderID  string    `json:"clOrdId,omitempty"`
 		Tag        string    `json:"tag,omitempty"`
 		Side       OrderSide `json:"side"`
 		PosSide    PosSide   `json:"posSide,omitempty"`
@@ -39,8 +39,8 @@ type (
 
 	CancelOrderReq struct {
 		InstID  string `json:"instId"`
-		OrdId   string `json:"ordId"`
-		ClOrdID string `json:"clOrdId"`
+		OrdId   string `json:"ordId,omitempty"`
+		ClOrdID string `json:"clOrdId,omitempty"`
 	}
 
 	CancelOrderResp struct {
@@ -50,6 +50,24 @@ type (
 		SMsg    string `json:"sMsg"`
 	}
 
+	AmendOrderReq struct {
+		InstID    string `json:"instId"`
+		CxlOnFail bool   `json:"cxlOnFail,omitempty"`
+		OrdID     string `json:"ordId,omitempty"`
+		ClOrdID   string `json:"clOrdId,omitempty"`
+		ReqID     string `json:"reqId,omitempty"`
+		NewSz     string `json:"newSz,omitempty"`
+		NewPx     string `json:"newPx,omitempty"`
+	}
+
+	AmendOrderResp struct {
+		OrdID   string `json:"ordId"`
+		ClOrdID string `json:"clOrdId"`
+		ReqID   string `json:"reqId"`
+		SCode   string `json:"sCode"`
+		SMsg    string `json:"sMsg"`
+	}
+
 	FetchOrderReq struct {
 		InstID  string `json:"instId"`
 		OrdID   string `json:"ordId"`
@@ -127,16 +145,19 @@ type (
 		RebateCcy   string        `json:"rebatCcy"`
 		Rebat       string        `json:"rebat"`
 		Category    OrderCategory `json:"category"`
+		ReduceOnly  string        `json:"reduceOnly"`
 		UTime       string        `json:"uTime"`
 		CTime       string        `json:"cTime"`
 	}
 )
 
 const (
-	CreateOrderEndPoint = "/api/v5/trade/order"
-	FetchOrderEndPoint  = CreateOrderEndPoint
-	CancelOrderEndPoint = "/api/v5/trade/cancel-order"
-	FillsEndPoint       = "/api/v5/trade/fills"
+	CreateOrderEndPoint   = "/api/v5/trade/order"
+	FetchOrderEndPoint    = CreateOrderEndPoint
+	CancelOrderEndPoint   = "/api/v5/trade/cancel-order"
+	AmendOrderEndPoint    = "/api/v5/trade/amend-order"
+	FillsEndPoint         = "/api/v5/trade/fills"
+	OrdersHistoryEndPoint = "/api/v5/trade/orders-history"
 )
 
 const ()
@@ -161,6 +182,15 @@ func (rc *RestClient) CancelOrder(ctx context.Context, req *CancelOrderReq) (*Ca
 	return &ret[0], nil
 }
 
+func (rc *RestClient) AmendOrder(ctx context.Context, req *AmendOrderReq) (*AmendOrderResp, error) {
+	ret := []AmendOrderResp{}
+	if err := rc.doPostJSON(ctx, AmendOrderEndPoint, req, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret[0], nil
+}
+
 func (rc *RestClient) FetchOrder(ctx context.Context, req *FetchOrderReq) (*Order, error) {
 	ret := []Order{}
 	values := url.Values{}
@@ -176,6 +206,9 @@ func (rc *RestClient) FetchOrder(ctx context.Context, req *FetchOrderReq) (*Orde
 		return nil, err
 	}
 
+	if len(ret) == 0 {
+		return nil, NewError(CodeOrderNotFound, "order does not exist")
+	}
 	return &ret[0], nil
 }
 
@@ -216,7 +249,7 @@ func (rc *RestClient) OrdersHistory(ctx context.Context, param *OrdersHistoryReq
 	}
 
 	var ret []Order
-	if err := rc.Request(ctx, http.MethodGet, "/api/v5/trade/orders-history", values, nil, true, &ret); err != nil {
+	if err := rc.Request(ctx, http.MethodGet, OrdersHistoryEndPoint, values, nil, true, &ret); err != nil {
 		return nil, err
 	}
 
@@ -261,21 +294,7 @@ func (rc *RestClient) Fills(ctx context.Context, param *FillsReq) ([]Fill, error
 }
 
 func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) ([]exchange.Trade, error) {
-	var it InstType
-	switch req.Symbol.(type) {
-	case exchange.MarginSymbol:
-		it = InstTypeMargin
-
-	case exchange.OptionSymbol:
-		it = InstTypeOption
-
-	case exchange.SwapSymbol:
-		it = InstTypeSwap
-
-	case exchange.SpotSymbol:
-		it = InstTypeSpot
-
-	}
+	it := instType(req.Symbol)
 
 	var limit string
 	if req.Limit != 0 {
@@ -306,30 +325,9 @@ func (rc *RestClient) Trades(ctx context.Context, req *exchange.TradeReqParam) (
 }
 
 func (f *Fill) Parse() (*exchange.Trade, error) {
-	var (
-		symbol exchange.Symbol
-		err    error
-	)
-
-	switch f.InstType {
-	case InstTypeSwap:
-		symbol, err = ParseSwapSymbol(f.InstID)
-		if err != nil {
-			return nil, err
-		}
-
-	case InstTypeMargin:
-		symbol, err = ParseMarginSymbol(f.InstID)
-		if err != nil {
-			return nil, err
-		}
-
-	case InstTypeSpot:
-		symbol, err = ParseSpotSymbol(f.InstID)
-		if err != nil {
-			return nil, err
-		}
-
+	symbol, err := ParseInstSymbol(f.InstType, f.InstID)
+	if err != nil {
+		return nil, err
 	}
 
 	var (
@@ -0,0 +1,55 @@
+package okex5
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+	"github.com/szmcdull/ccexgo/internal/rpc"
+)
+
+const (
+	AccountChannel = "account"
+)
+
+func init() {
+	parseCBMap[AccountChannel] = parseAccount
+}
+
+// NewAccountChannel private account channel, all currencies are pushed if ccy is empty
+func NewAccountChannel(ccy string) *Okex5Channel {
+	return &Okex5Channel{
+		Channel: AccountChannel,
+		Ccy:     ccy,
+	}
+}
+
+// parseAccount parse account push into []*exchange.BalanceUpdate, the reason is not pushed
+func parseAccount(data *wsResp) (*rpc.Notify, error) {
+	var ab []AccountBalance
+	if err := json.Unmarshal(data.Data, &ab); err != nil {
+		return nil, err
+	}
+
+	updates := []*exchange.BalanceUpdate{}
+	for i := range ab {
+		for j := range ab[i].Details {
+			detail := &ab[i].Details[j]
+			b, err := detail.Transform()
+			if err != nil {
+				return nil, errors.WithMessagef(err, "parse %s balance fail", detail.Ccy)
+			}
+
+			ts, err := ParseTimestamp(detail.UTime)
+			if err != nil {
+				return nil, err
+			}
+			updates = append(updates, exchange.NewBalanceUpdate(b, exchange.BalanceFieldAll, exchange.BalanceReasonOther, ts, detail))
+		}
+	}
+
+	return &rpc.Notify{
+		Method: data.Arg.Channel,
+		Params: updates,
+	}, nil
+}
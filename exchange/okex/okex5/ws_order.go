@@ -0,0 +1,77 @@
+package okex5
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	OpOrder       = "order"
+	OpBatchOrders = "batch-orders"
+	OpCancelOrder = "cancel-order"
+	OpAmendOrder  = "amend-order"
+)
+
+var (
+	tradeOps = map[string]struct{}{
+		OpOrder:       {},
+		OpBatchOrders: {},
+		OpCancelOrder: {},
+		OpAmendOrder:  {},
+	}
+)
+
+// CreateOrder place order via websocket, the client should login first
+func (ws *WSClient) CreateOrder(ctx context.Context, req *CreateOrderReq) (*CreateOrderResp, error) {
+	var ret []CreateOrderResp
+	if err := ws.Call(ctx, ws.nextID(), OpOrder, []*CreateOrderReq{req}, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.Errorf("empty order response")
+	}
+	return &ret[0], nil
+}
+
+// CreateOrders place orders via websocket. orders may be partially placed,
+// so the SCode of each response should be checked
+func (ws *WSClient) CreateOrders(ctx context.Context, reqs []*CreateOrderReq) ([]CreateOrderResp, error) {
+	var ret []CreateOrderResp
+	if err := ws.Call(ctx, ws.nextID(), OpBatchOrders, reqs, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CancelOrder cancel order via websocket
+func (ws *WSClient) CancelOrder(ctx context.Context, req *CancelOrderReq) (*CancelOrderResp, error) {
+	var ret []CancelOrderResp
+	if err := ws.Call(ctx, ws.nextID(), OpCancelOrder, []*CancelOrderReq{req}, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.Errorf("empty cancel order response")
+	}
+	return &ret[0], nil
+}
+
+// AmendOrder amend order price or size via websocket
+func (ws *WSClient) AmendOrder(ctx context.Context, req *AmendOrderReq) (*AmendOrderResp, error) {
+	var ret []AmendOrderResp
+	if err := ws.Call(ctx, ws.nextID(), OpAmendOrder, []*AmendOrderReq{req}, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.Errorf("empty amend order response")
+	}
+	return &ret[0], nil
+}
+
+func isTradeOp(op string) bool {
+	_, ok := tradeOps[op]
+	return ok
+}
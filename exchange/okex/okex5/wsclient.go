@@ -2,6 +2,11 @@ package okex5
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -11,6 +16,7 @@ import (
 
 type (
 	WSClient struct {
+		reqID int64
 		*exchange.WSClient
 		data   chan interface{}
 		key    string
@@ -23,6 +29,14 @@ type (
 		InstType InstType `json:"instType,omitempty"`
 		Uly      string   `json:"uly,omitempty"`
 		InstID   string   `json:"instId,omitempty"`
+		Ccy      string   `json:"ccy,omitempty"`
+	}
+
+	loginArg struct {
+		APIKey     string `json:"apiKey"`
+		Passphrase string `json:"passphrase"`
+		Timestamp  string `json:"timestamp"`
+		Sign       string `json:"sign"`
 	}
 )
 
@@ -34,6 +48,7 @@ const (
 
 	MethodSubscribe   = "subscribe"
 	MethodUnSubscribe = "unsubscribe"
+	MethodLogin       = "login"
 )
 
 func NewWSPublicClient(data chan interface{}) *WSClient {
@@ -44,6 +59,23 @@ func NewTestWSPublicClient(data chan interface{}) *WSClient {
 	return newWSClient(WebSocketSimPublicAddr, data)
 }
 
+// NewWSPrivateClient return a client for private channels and trade operations
+func NewWSPrivateClient(key, secret, passwd string, data chan interface{}) *WSClient {
+	ret := newWSClient(WebSocketPrivateAddr, data)
+	ret.key = key
+	ret.secret = secret
+	ret.passwd = passwd
+	return ret
+}
+
+func NewTestWSPrivateClient(key, secret, passwd string, data chan interface{}) *WSClient {
+	ret := newWSClient(WebSocketSimPrivateAdrr, data)
+	ret.key = key
+	ret.secret = secret
+	ret.passwd = passwd
+	return ret
+}
+
 func newWSClient(addr string, data chan interface{}) *WSClient {
 	ret := &WSClient{
 		data: data,
@@ -52,11 +84,19 @@ func newWSClient(addr string, data chan interface{}) *WSClient {
 	return ret
 }
 
+// Run start the websocket loop, private client will login after connected
 func (ws *WSClient) Run(ctx context.Context) error {
 	if err := ws.WSClient.Run(ctx); err != nil {
 		return err
 	}
 
+	if ws.key != "" {
+		if err := ws.Login(ctx); err != nil {
+			ws.WSClient.Close()
+			return err
+		}
+	}
+
 	go func() {
 		ticker := time.NewTicker(time.Second * 25)
 		for {
@@ -74,6 +114,27 @@ func (ws *WSClient) Run(ctx context.Context) error {
 	return nil
 }
 
+// Login authenticate the connection with api key
+func (ws *WSClient) Login(ctx context.Context) error {
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	h := hmac.New(sha256.New, []byte(ws.secret))
+	h.Write([]byte(ts + "GET/users/self/verify"))
+	sign := base64.StdEncoding.EncodeToString(h.Sum(nil))
+
+	arg := loginArg{
+		APIKey:     ws.key,
+		Passphrase: ws.passwd,
+		Timestamp:  ts,
+		Sign:       sign,
+	}
+
+	var resp wsResp
+	if err := ws.Call(ctx, MethodLogin, MethodLogin, []loginArg{arg}, &resp); err != nil {
+		return errors.WithMessage(err, "okex5 login error")
+	}
+	return nil
+}
+
 func (ws *WSClient) Handle(ctx context.Context, notify *rpc.Notify) {
 	data := &exchange.WSNotify{
 		Exchange: "okex",
@@ -116,6 +177,11 @@ func (ws *WSClient) UnSubscribe(ctx context.Context, channels ...exchange.Channe
 
 }
 
+func (ws *WSClient) nextID() string {
+	id := atomic.AddInt64(&ws.reqID, 1)
+	return strconv.FormatInt(id, 10)
+}
+
 func (oc *Okex5Channel) String() string {
 	return ""
 }
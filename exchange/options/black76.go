@@ -0,0 +1,168 @@
+// Package options implement option analytics on exchange.OptionSymbol: Black-76 pricing,
+// greeks and implied volatility for linear and inverse(coin settled) options.
+//
+// volatility is expressed in decimal(0.8 for 80%), time in years and prices of inverse
+// options in underlying coin as quoted by deribit.
+package options
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	// Settlement is the currency the option premium is paid and settled in
+	Settlement int
+
+	// Option contract terms needed by pricing
+	Option struct {
+		Strike     float64
+		Expiry     time.Time
+		Type       exchange.OptionType
+		Settlement Settlement
+	}
+
+	// Greeks of the option. for inverse options delta is the underlying coin exposure
+	// and all others are in coin, vega is per 1% volatility change and theta per day
+	Greeks struct {
+		Delta float64
+		Gamma float64
+		Vega  float64
+		Theta float64
+	}
+)
+
+const (
+	// SettlementLinear premium quoted in quote currency, such as binance options
+	SettlementLinear Settlement = iota
+	// SettlementInverse premium quoted in underlying coin, such as deribit options
+	SettlementInverse
+
+	yearDuration = time.Hour * 24 * 365
+	daysPerYear  = 365
+)
+
+// NewOption build option from the symbol
+func NewOption(sym exchange.OptionSymbol, settlement Settlement) *Option {
+	strike, _ := sym.Strike().Float64()
+	return &Option{
+		Strike:     strike,
+		Expiry:     sym.SettleTime(),
+		Type:       sym.Type(),
+		Settlement: settlement,
+	}
+}
+
+// TimeToExpiry return years to expiry, 0 if expired
+func (o *Option) TimeToExpiry(now time.Time) float64 {
+	d := o.Expiry.Sub(now)
+	if d <= 0 {
+		return 0
+	}
+	return float64(d) / float64(yearDuration)
+}
+
+// Price return Black-76 price of the option with forward price, volatility, years to
+// expiry and discount rate. inverse option price is in coin
+func (o *Option) Price(forward, vol, t, rate float64) float64 {
+	p := o.linearPrice(forward, vol, t, rate)
+	if o.Settlement == SettlementInverse {
+		return p / forward
+	}
+	return p
+}
+
+// Greeks return greeks of the option, see Greeks for the units
+func (o *Option) Greeks(forward, vol, t, rate float64) Greeks {
+	df := math.Exp(-rate * t)
+	if t <= 0 || vol <= 0 {
+		var delta float64
+		if o.intrinsic(forward) > 0 {
+			delta = 1
+			if o.Type == exchange.OptionTypePut {
+				delta = -1
+			}
+		}
+		g := Greeks{Delta: delta}
+		if o.Settlement == SettlementInverse {
+			g.Delta -= o.intrinsic(forward) / forward
+		}
+		return g
+	}
+
+	sqrtT := math.Sqrt(t)
+	d1, _ := d12(forward, o.Strike, vol, t)
+	price := o.linearPrice(forward, vol, t, rate)
+
+	delta := df * normCDF(d1)
+	if o.Type == exchange.OptionTypePut {
+		delta = -df * normCDF(-d1)
+	}
+	gamma := df * normPDF(d1) / (forward * vol * sqrtT)
+	vega := df * forward * normPDF(d1) * sqrtT
+	theta := rate*price - df*forward*normPDF(d1)*vol/(2*sqrtT)
+
+	if o.Settlement == SettlementInverse {
+		// value in coin is V/F, delta is F*d(V/F)/dF which is coin exposure
+		// and gamma is derivative of the coin delta
+		invDelta := delta - price/forward
+		gamma = gamma - delta/forward + price/(forward*forward)
+		delta = invDelta
+		vega /= forward
+		theta /= forward
+	}
+
+	return Greeks{
+		Delta: delta,
+		Gamma: gamma,
+		Vega:  vega / 100,
+		Theta: theta / daysPerYear,
+	}
+}
+
+func (o *Option) linearPrice(forward, vol, t, rate float64) float64 {
+	if t <= 0 || vol <= 0 {
+		return o.intrinsic(forward) * math.Exp(-rate*t)
+	}
+
+	df := math.Exp(-rate * t)
+	d1, d2 := d12(forward, o.Strike, vol, t)
+	if o.Type == exchange.OptionTypeCall {
+		return df * (forward*normCDF(d1) - o.Strike*normCDF(d2))
+	}
+	return df * (o.Strike*normCDF(-d2) - forward*normCDF(-d1))
+}
+
+func (o *Option) intrinsic(forward float64) float64 {
+	if o.Type == exchange.OptionTypeCall {
+		return math.Max(forward-o.Strike, 0)
+	}
+	return math.Max(o.Strike-forward, 0)
+}
+
+func d12(forward, strike, vol, t float64) (float64, float64) {
+	vt := vol * math.Sqrt(t)
+	d1 := (math.Log(forward/strike) + vol*vol*t/2) / vt
+	return d1, d1 - vt
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func checkInput(forward, t float64) error {
+	if forward <= 0 || math.IsNaN(forward) {
+		return errors.Errorf("invalid forward %f", forward)
+	}
+	if t <= 0 {
+		return errors.Errorf("option expired")
+	}
+	return nil
+}
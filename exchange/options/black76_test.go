@@ -0,0 +1,130 @@
+package options
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type testOptionSymbol struct {
+	*exchange.BaseOptionSymbol
+}
+
+func (s *testOptionSymbol) String() string {
+	return s.Index()
+}
+
+func almostEqual(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestPrice(t *testing.T) {
+	call := &Option{Strike: 100, Type: exchange.OptionTypeCall}
+	put := &Option{Strike: 100, Type: exchange.OptionTypePut}
+
+	if p := call.Price(100, 0.2, 1, 0); !almostEqual(p, 7.965567, 1e-6) {
+		t.Errorf("bad call price %f", p)
+	}
+
+	// put call parity C - P = df * (F - K)
+	c, p := call.Price(110, 0.5, 0.5, 0.03), put.Price(110, 0.5, 0.5, 0.03)
+	if !almostEqual(c-p, math.Exp(-0.03*0.5)*10, 1e-9) {
+		t.Errorf("put call parity fail call=%f put=%f", c, p)
+	}
+
+	inv := &Option{Strike: 100, Type: exchange.OptionTypeCall, Settlement: SettlementInverse}
+	if p := inv.Price(100, 0.2, 1, 0); !almostEqual(p, 0.07965567, 1e-8) {
+		t.Errorf("bad inverse price %f", p)
+	}
+}
+
+func TestImpliedVol(t *testing.T) {
+	for _, settlement := range []Settlement{SettlementLinear, SettlementInverse} {
+		for _, typ := range []exchange.OptionType{exchange.OptionTypeCall, exchange.OptionTypePut} {
+			for _, strike := range []float64{20000, 45000, 50000, 60000, 90000} {
+				for _, vol := range []float64{0.05, 0.6, 2.5} {
+					o := &Option{Strike: strike, Type: typ, Settlement: settlement}
+					price := o.Price(50000, vol, 0.1, 0.01)
+					iv, err := o.ImpliedVol(price, 50000, 0.1, 0.01)
+					if err != nil {
+						// deep in/out of money options with low vol has no time value
+						if price-o.intrinsic(50000)*math.Exp(-0.001) < 1e-9 {
+							continue
+						}
+						t.Errorf("implied vol fail %+v vol=%f %s", *o, vol, err.Error())
+						continue
+					}
+					if p := o.Price(50000, iv, 0.1, 0.01); !almostEqual(p, price, 1e-8*math.Max(price, 1)) {
+						t.Errorf("bad implied vol %+v vol=%f iv=%f", *o, vol, iv)
+					}
+				}
+			}
+		}
+	}
+
+	o := &Option{Strike: 100, Type: exchange.OptionTypeCall}
+	if _, err := o.ImpliedVol(5, 110, 0.5, 0); !errors.Is(err, ErrPriceOutOfBounds) {
+		t.Errorf("price below intrinsic should fail %v", err)
+	}
+}
+
+func TestGreeks(t *testing.T) {
+	const (
+		f, vol, tt, rate, h = 50000.0, 0.7, 0.25, 0.02, 1.0
+	)
+	for _, settlement := range []Settlement{SettlementLinear, SettlementInverse} {
+		for _, typ := range []exchange.OptionType{exchange.OptionTypeCall, exchange.OptionTypePut} {
+			o := &Option{Strike: 55000, Type: typ, Settlement: settlement}
+			g := o.Greeks(f, vol, tt, rate)
+
+			scale := 1.0
+			if settlement == SettlementInverse {
+				scale = f
+			}
+			delta := scale * (o.Price(f+h, vol, tt, rate) - o.Price(f-h, vol, tt, rate)) / (2 * h)
+			gamma := (o.Greeks(f+h, vol, tt, rate).Delta - o.Greeks(f-h, vol, tt, rate).Delta) / (2 * h)
+			vega := (o.Price(f, vol+1e-4, tt, rate) - o.Price(f, vol-1e-4, tt, rate)) / 2e-4 / 100
+			theta := o.Price(f, vol, tt-1.0/365, rate) - o.Price(f, vol, tt, rate)
+
+			if !almostEqual(g.Delta, delta, 1e-6) || !almostEqual(g.Gamma, gamma, 1e-8) ||
+				!almostEqual(g.Vega, vega, 1e-6*math.Abs(vega)) || !almostEqual(g.Theta, theta, 1e-2*math.Abs(theta)) {
+				t.Errorf("bad greeks %+v type=%d settlement=%d expect delta=%g gamma=%g vega=%g theta=%g",
+					g, typ, settlement, delta, gamma, vega, theta)
+			}
+		}
+	}
+}
+
+func TestTickerIV(t *testing.T) {
+	st := time.Date(2021, 1, 15, 8, 0, 0, 0, time.UTC)
+	sym := &testOptionSymbol{
+		exchange.NewBaseOptionSymbol("BTC", st, decimal.NewFromInt(28000), exchange.OptionTypeCall, exchange.SymbolConfig{}, nil),
+	}
+	o := NewOption(sym, SettlementInverse)
+
+	now := st.Add(-time.Hour * 24 * 15)
+	tt := o.TimeToExpiry(now)
+	ticker := &exchange.Ticker{
+		BestBid:   decimal.NewFromFloat(o.Price(29000, 0.8, tt, 0)),
+		BestAsk:   decimal.NewFromFloat(o.Price(29000, 0.9, tt, 0)),
+		MarkPrice: decimal.NewFromFloat(o.Price(29000, 0.85, tt, 0)),
+		Time:      now,
+	}
+
+	q, err := o.TickerIV(ticker, 29000, now, 0)
+	if err != nil {
+		t.Fatalf("ticker iv fail %s", err.Error())
+	}
+	if !almostEqual(q.Bid, 0.8, 1e-6) || !almostEqual(q.Ask, 0.9, 1e-6) || !almostEqual(q.Mark, 0.85, 1e-6) {
+		t.Errorf("bad quote iv %+v", *q)
+	}
+
+	if q.IsStale(0.85, 0.01, now, time.Minute) || !q.IsStale(0.7, 0.01, now, time.Minute) ||
+		!q.IsStale(0.85, 0.01, now.Add(time.Hour), time.Minute) {
+		t.Errorf("bad stale check")
+	}
+}
@@ -0,0 +1,502 @@
+package options
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	// Quote live market of one option
+	Quote struct {
+		Symbol exchange.OptionSymbol
+		Option *Option
+		Ticker *exchange.Ticker
+		Book   *exchange.OrderBook
+	}
+
+	// StrikePair call and put of the same expiry and strike
+	StrikePair struct {
+		Strike float64
+		Call   *Quote
+		Put    *Quote
+	}
+
+	// Expiry options of the same expiry with the fitted smile
+	Expiry struct {
+		Expiry    time.Time
+		Strikes   []*StrikePair
+		Forward   float64
+		ATMStrike float64
+		T         float64
+		Smile     *SVI
+		dirty     bool
+	}
+
+	// OptionChain group options of one index by expiry and strike, attach the live market and
+	// fit a SVI smile for each expiry which build the volatility surface
+	OptionChain struct {
+		mu         sync.Mutex
+		index      string
+		settlement Settlement
+		rate       float64
+		expiries   []*Expiry
+		quotes     map[string]*Quote
+		quote2Exp  map[string]*Expiry
+	}
+)
+
+const (
+	// parityStrikes is the count of strikes nearest to the money used to compute forward
+	parityStrikes = 3
+)
+
+var (
+	ErrNoForward = errors.New("no call put pair to compute forward")
+	ErrNoSmile   = errors.New("smile not fitted")
+)
+
+// NewOptionChain build chain from symbols, symbols of other index are ignored
+func NewOptionChain(index string, settlement Settlement, syms []exchange.OptionSymbol) *OptionChain {
+	oc := &OptionChain{
+		index:      index,
+		settlement: settlement,
+		quotes:     map[string]*Quote{},
+		quote2Exp:  map[string]*Expiry{},
+	}
+
+	expMap := map[int64]*Expiry{}
+	pairMap := map[int64]map[float64]*StrikePair{}
+	for _, sym := range syms {
+		if sym.Index() != index {
+			continue
+		}
+
+		q := &Quote{
+			Symbol: sym,
+			Option: NewOption(sym, settlement),
+		}
+		key := sym.SettleTime().Unix()
+		exp, ok := expMap[key]
+		if !ok {
+			exp = &Expiry{
+				Expiry: sym.SettleTime(),
+			}
+			expMap[key] = exp
+			pairMap[key] = map[float64]*StrikePair{}
+			oc.expiries = append(oc.expiries, exp)
+		}
+
+		pair, ok := pairMap[key][q.Option.Strike]
+		if !ok {
+			pair = &StrikePair{
+				Strike: q.Option.Strike,
+			}
+			pairMap[key][q.Option.Strike] = pair
+			exp.Strikes = append(exp.Strikes, pair)
+		}
+		if sym.Type() == exchange.OptionTypeCall {
+			pair.Call = q
+		} else {
+			pair.Put = q
+		}
+
+		oc.quotes[sym.String()] = q
+		oc.quote2Exp[sym.String()] = exp
+	}
+
+	sort.Slice(oc.expiries, func(i, j int) bool {
+		return oc.expiries[i].Expiry.Before(oc.expiries[j].Expiry)
+	})
+	for _, exp := range oc.expiries {
+		sort.Slice(exp.Strikes, func(i, j int) bool {
+			return exp.Strikes[i].Strike < exp.Strikes[j].Strike
+		})
+	}
+	return oc
+}
+
+// SetRate set the discount rate used in pricing
+func (oc *OptionChain) SetRate(rate float64) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.rate = rate
+	for _, exp := range oc.expiries {
+		exp.dirty = true
+	}
+}
+
+// Expiries return expiry times in ascending order
+func (oc *OptionChain) Expiries() []time.Time {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	ret := make([]time.Time, len(oc.expiries))
+	for i, exp := range oc.expiries {
+		ret[i] = exp.Expiry
+	}
+	return ret
+}
+
+// Expiry return a deep copy of the expiry which is not changed by later updates, nil if not found
+func (oc *OptionChain) Expiry(expiry time.Time) *Expiry {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	exp := oc.findExpiry(expiry)
+	if exp == nil {
+		return nil
+	}
+	return exp.clone()
+}
+
+// clone copy the strikes and quotes, Option of the quote is never changed so it's shared
+func (exp *Expiry) clone() *Expiry {
+	ret := *exp
+	ret.Strikes = make([]*StrikePair, len(exp.Strikes))
+	for i, pair := range exp.Strikes {
+		p := &StrikePair{
+			Strike: pair.Strike,
+		}
+		if pair.Call != nil {
+			q := *pair.Call
+			p.Call = &q
+		}
+		if pair.Put != nil {
+			q := *pair.Put
+			p.Put = &q
+		}
+		ret.Strikes[i] = p
+	}
+
+	if exp.Smile != nil {
+		smile := *exp.Smile
+		ret.Smile = &smile
+	}
+	return &ret
+}
+
+// UpdateTicker attach ticker to the option, false is returned if the symbol is not in the chain
+func (oc *OptionChain) UpdateTicker(ticker *exchange.Ticker) bool {
+	if ticker.Symbol == nil {
+		return false
+	}
+
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	q, ok := oc.quotes[ticker.Symbol.String()]
+	if !ok {
+		return false
+	}
+	q.Ticker = ticker
+	oc.quote2Exp[ticker.Symbol.String()].dirty = true
+	return true
+}
+
+// UpdateOrderBook attach order book to the option, false is returned if the symbol is not in the chain
+func (oc *OptionChain) UpdateOrderBook(book *exchange.OrderBook) bool {
+	if book.Symbol == nil {
+		return false
+	}
+
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	q, ok := oc.quotes[book.Symbol.String()]
+	if !ok {
+		return false
+	}
+	q.Book = book
+	oc.quote2Exp[book.Symbol.String()].dirty = true
+	return true
+}
+
+// Refresh recompute forward, atm strike and smile of the expiries updated since last
+// refresh, expired ones are dropped. errors of single expiry are collected and the
+// expiry keep the previous result
+func (oc *OptionChain) Refresh(now time.Time) error {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	var (
+		ret      error
+		expiries []*Expiry
+	)
+	for _, exp := range oc.expiries {
+		if !exp.Expiry.After(now) {
+			oc.removeExpiry(exp)
+			continue
+		}
+		expiries = append(expiries, exp)
+
+		t := float64(exp.Expiry.Sub(now)) / float64(yearDuration)
+		if !exp.dirty && exp.Smile != nil {
+			exp.T = t
+			continue
+		}
+		if err := oc.refreshExpiry(exp, t); err != nil {
+			if ret == nil {
+				ret = errors.WithMessagef(err, "refresh expiry %s", exp.Expiry)
+			}
+			continue
+		}
+		exp.dirty = false
+	}
+	oc.expiries = expiries
+	return ret
+}
+
+// Vol return implied volatility of the strike at expiry. between fitted expiries total variance
+// is interpolated linearly in time at the same log moneyness
+func (oc *OptionChain) Vol(expiry time.Time, strike float64) (float64, error) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	w, t, err := oc.totalVariance(expiry, func(exp *Expiry) float64 {
+		return math.Log(strike / exp.Forward)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(w / t), nil
+}
+
+// VolByDelta return the strike and implied volatility whose forward delta equal to delta,
+// delta of put is negative
+func (oc *OptionChain) VolByDelta(expiry time.Time, delta float64, typ exchange.OptionType) (float64, float64, error) {
+	callDelta := delta
+	if typ == exchange.OptionTypePut {
+		callDelta = delta + 1
+	}
+	if callDelta <= 0 || callDelta >= 1 {
+		return 0, 0, errors.Errorf("invalid delta %f", delta)
+	}
+
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	forward, err := oc.forward(expiry)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// call delta N(d1) decrease with k, solve it by bisection
+	lo, hi := -5.0, 5.0
+	var (
+		k, w, t float64
+	)
+	for i := 0; i < 100; i++ {
+		k = (lo + hi) / 2
+		w, t, err = oc.totalVariance(expiry, func(exp *Expiry) float64 {
+			return k + math.Log(forward/exp.Forward)
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+
+		d1 := (-k + w/2) / math.Sqrt(w)
+		if normCDF(d1) > callDelta {
+			lo = k
+		} else {
+			hi = k
+		}
+		if hi-lo < 1e-10 {
+			break
+		}
+	}
+	return forward * math.Exp(k), math.Sqrt(w / t), nil
+}
+
+// Forward return the forward of the expiry, interpolated linearly in time between expiries
+func (oc *OptionChain) Forward(expiry time.Time) (float64, error) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	return oc.forward(expiry)
+}
+
+func (oc *OptionChain) forward(expiry time.Time) (float64, error) {
+	lo, hi, err := oc.neighbours(expiry)
+	if err != nil {
+		return 0, err
+	}
+	if lo == hi {
+		return lo.Forward, nil
+	}
+	r := float64(expiry.Sub(lo.Expiry)) / float64(hi.Expiry.Sub(lo.Expiry))
+	return lo.Forward + (hi.Forward-lo.Forward)*r, nil
+}
+
+// totalVariance return total variance and years to expiry, kf return log moneyness on the
+// fitted expiry
+func (oc *OptionChain) totalVariance(expiry time.Time, kf func(*Expiry) float64) (float64, float64, error) {
+	lo, hi, err := oc.neighbours(expiry)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	wLo := lo.Smile.TotalVariance(kf(lo))
+	if lo == hi {
+		if wLo <= 0 {
+			return 0, 0, errors.Errorf("non positive total variance")
+		}
+		return wLo, lo.T, nil
+	}
+
+	r := float64(expiry.Sub(lo.Expiry)) / float64(hi.Expiry.Sub(lo.Expiry))
+	t := lo.T + (hi.T-lo.T)*r
+	w := wLo + (hi.Smile.TotalVariance(kf(hi))-wLo)*r
+	if w <= 0 || t <= 0 {
+		return 0, 0, errors.Errorf("non positive total variance")
+	}
+	return w, t, nil
+}
+
+// neighbours return fitted expiries around the expiry, both are the same if it match exactly
+func (oc *OptionChain) neighbours(expiry time.Time) (*Expiry, *Expiry, error) {
+	var lo, hi *Expiry
+	for _, exp := range oc.expiries {
+		if exp.Smile == nil || exp.Forward <= 0 {
+			continue
+		}
+		if exp.Expiry.Equal(expiry) {
+			return exp, exp, nil
+		}
+		if exp.Expiry.Before(expiry) {
+			lo = exp
+		} else if hi == nil {
+			hi = exp
+		}
+	}
+	if lo == nil || hi == nil {
+		return nil, nil, errors.WithMessagef(ErrNoSmile, "expiry %s out of surface", expiry)
+	}
+	return lo, hi, nil
+}
+
+func (oc *OptionChain) findExpiry(expiry time.Time) *Expiry {
+	for _, exp := range oc.expiries {
+		if exp.Expiry.Equal(expiry) {
+			return exp
+		}
+	}
+	return nil
+}
+
+func (oc *OptionChain) removeExpiry(exp *Expiry) {
+	for _, pair := range exp.Strikes {
+		for _, q := range []*Quote{pair.Call, pair.Put} {
+			if q != nil {
+				delete(oc.quotes, q.Symbol.String())
+				delete(oc.quote2Exp, q.Symbol.String())
+			}
+		}
+	}
+}
+
+func (oc *OptionChain) refreshExpiry(exp *Expiry, t float64) error {
+	df := math.Exp(-oc.rate * t)
+
+	type parity struct {
+		diff    float64
+		forward float64
+	}
+	var parities []parity
+	for _, pair := range exp.Strikes {
+		if pair.Call == nil || pair.Put == nil {
+			continue
+		}
+		c, p := pair.Call.mid(), pair.Put.mid()
+		if c <= 0 || p <= 0 {
+			continue
+		}
+
+		// C - P = df * (F - K), inverse prices are divided by F
+		var f float64
+		if oc.settlement == SettlementInverse {
+			f = pair.Strike / (1 - (c-p)/df)
+		} else {
+			f = pair.Strike + (c-p)/df
+		}
+		if f > 0 && !math.IsInf(f, 0) {
+			parities = append(parities, parity{diff: math.Abs(c - p), forward: f})
+		}
+	}
+	if len(parities) == 0 {
+		return ErrNoForward
+	}
+
+	sort.Slice(parities, func(i, j int) bool {
+		return parities[i].diff < parities[j].diff
+	})
+	if len(parities) > parityStrikes {
+		parities = parities[:parityStrikes]
+	}
+	forwards := make([]float64, len(parities))
+	for i := range parities {
+		forwards[i] = parities[i].forward
+	}
+	sort.Float64s(forwards)
+	forward := forwards[len(forwards)/2]
+
+	atm := exp.Strikes[0].Strike
+	for _, pair := range exp.Strikes {
+		if math.Abs(pair.Strike-forward) < math.Abs(atm-forward) {
+			atm = pair.Strike
+		}
+	}
+
+	// fit smile with out of the money options
+	var ks, ws []float64
+	for _, pair := range exp.Strikes {
+		q := pair.Call
+		if pair.Strike < forward {
+			q = pair.Put
+		}
+		if q == nil {
+			continue
+		}
+
+		price := q.mid()
+		if price <= 0 {
+			continue
+		}
+		vol, err := q.Option.ImpliedVol(price, forward, t, oc.rate)
+		if err != nil || vol <= ivMin {
+			continue
+		}
+		ks = append(ks, math.Log(pair.Strike/forward))
+		ws = append(ws, vol*vol*t)
+	}
+
+	smile, err := FitSVI(ks, ws)
+	if err != nil {
+		return err
+	}
+
+	exp.Forward = forward
+	exp.ATMStrike = atm
+	exp.T = t
+	exp.Smile = smile
+	return nil
+}
+
+// mid return mid price of the order book, then the ticker and fallback to the mark price
+func (q *Quote) mid() float64 {
+	if q.Book != nil && len(q.Book.Bids) != 0 && len(q.Book.Asks) != 0 {
+		return (q.Book.Bids[0].Price + q.Book.Asks[0].Price) / 2
+	}
+
+	if q.Ticker != nil {
+		bid, _ := q.Ticker.BestBid.Float64()
+		ask, _ := q.Ticker.BestAsk.Float64()
+		if bid > 0 && ask > 0 {
+			return (bid + ask) / 2
+		}
+		mark, _ := q.Ticker.MarkPrice.Float64()
+		return mark
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type testChainSymbol struct {
+	*exchange.BaseOptionSymbol
+}
+
+func (s *testChainSymbol) String() string {
+	return fmt.Sprintf("%s-%d-%s-%s", s.Index(), s.SettleTime().Unix(), s.Strike().String(), s.Type())
+}
+
+func TestFitSVI(t *testing.T) {
+	expect := &SVI{A: 0.02, B: 0.1, Rho: -0.3, M: 0.05, Sigma: 0.2}
+	var ks, ws []float64
+	for k := -0.6; k <= 0.6; k += 0.1 {
+		ks = append(ks, k)
+		ws = append(ws, expect.TotalVariance(k))
+	}
+
+	s, err := FitSVI(ks, ws)
+	if err != nil {
+		t.Fatalf("fit svi fail %s", err.Error())
+	}
+	for _, k := range ks {
+		if !almostEqual(s.TotalVariance(k), expect.TotalVariance(k), 1e-6) {
+			t.Errorf("bad fit %+v at k=%f", *s, k)
+		}
+	}
+
+	if _, err := FitSVI(ks[:3], ws[:3]); err == nil {
+		t.Errorf("fit with too few points should fail")
+	}
+}
+
+func TestOptionChain(t *testing.T) {
+	now := time.Date(2021, 1, 1, 8, 0, 0, 0, time.UTC)
+	expiries := []time.Time{now.Add(time.Hour * 24 * 30), now.Add(time.Hour * 24 * 90)}
+	forwards := []float64{30000, 31000}
+	smile := &SVI{A: 0.01, B: 0.08, Rho: -0.2, M: 0, Sigma: 0.3}
+
+	var syms []exchange.OptionSymbol
+	for _, st := range expiries {
+		for strike := 20000; strike <= 42000; strike += 2000 {
+			for _, typ := range []exchange.OptionType{exchange.OptionTypeCall, exchange.OptionTypePut} {
+				syms = append(syms, &testChainSymbol{
+					exchange.NewBaseOptionSymbol("BTC", st, decimal.NewFromInt(int64(strike)), typ, exchange.SymbolConfig{}, nil),
+				})
+			}
+		}
+	}
+	syms = append(syms, &testChainSymbol{
+		exchange.NewBaseOptionSymbol("ETH", expiries[0], decimal.NewFromInt(1000), exchange.OptionTypeCall, exchange.SymbolConfig{}, nil),
+	})
+
+	oc := NewOptionChain("BTC", SettlementInverse, syms)
+	if exps := oc.Expiries(); len(exps) != 2 || !exps[0].Equal(expiries[0]) {
+		t.Fatalf("bad expiries %v", exps)
+	}
+	if exp := oc.Expiry(expiries[0]); len(exp.Strikes) != 12 || exp.Strikes[0].Call == nil || exp.Strikes[0].Put == nil {
+		t.Fatalf("bad strikes %+v", exp)
+	}
+
+	for _, sym := range syms {
+		o := NewOption(sym.(exchange.OptionSymbol), SettlementInverse)
+		i := 0
+		if sym.SettleTime().Equal(expiries[1]) {
+			i = 1
+		}
+		tt := o.TimeToExpiry(now)
+		vol := smile.Vol(math.Log(o.Strike/forwards[i]), tt)
+		ok := oc.UpdateTicker(&exchange.Ticker{
+			Symbol:    sym,
+			MarkPrice: decimal.NewFromFloat(o.Price(forwards[i], vol, tt, 0)),
+			Time:      now,
+		})
+		if ok != (sym.Index() == "BTC") {
+			t.Errorf("bad update result %v for %s", ok, sym.String())
+		}
+	}
+
+	if err := oc.Refresh(now); err != nil {
+		t.Fatalf("refresh fail %s", err.Error())
+	}
+
+	exp := oc.Expiry(expiries[0])
+	if !almostEqual(exp.Forward, forwards[0], 1e-3) || exp.ATMStrike != 30000 {
+		t.Errorf("bad forward %f atm %f", exp.Forward, exp.ATMStrike)
+	}
+
+	// the copy is not changed by later updates
+	ticker := exp.Strikes[0].Call.Ticker
+	exp.Strikes[0].Call.Ticker = nil
+	exp.Smile.A = 1
+	if cp := oc.Expiry(expiries[0]); cp.Strikes[0].Call.Ticker != ticker || cp.Smile.A == 1 {
+		t.Errorf("expiry should be a deep copy")
+	}
+
+	for i, st := range expiries {
+		tt := float64(st.Sub(now)) / float64(yearDuration)
+		for _, strike := range []float64{24000, 31000, 38000} {
+			vol, err := oc.Vol(st, strike)
+			if err != nil || !almostEqual(vol, smile.Vol(math.Log(strike/forwards[i]), tt), 1e-4) {
+				t.Errorf("bad vol %f at %s %f %v", vol, st, strike, err)
+			}
+		}
+	}
+
+	// the middle expiry is interpolated in total variance at the same moneyness
+	mid := now.Add(time.Hour * 24 * 60)
+	f, err := oc.Forward(mid)
+	if err != nil || !almostEqual(f, 30500, 1e-2) {
+		t.Errorf("bad interpolated forward %f %v", f, err)
+	}
+	vol, err := oc.Vol(mid, f)
+	tt := float64(mid.Sub(now)) / float64(yearDuration)
+	w := (smile.TotalVariance(math.Log(f/forwards[0])) + smile.TotalVariance(math.Log(f/forwards[1]))) / 2
+	if err != nil || !almostEqual(vol, math.Sqrt(w/tt), 1e-4) {
+		t.Errorf("bad interpolated vol %f %v", vol, err)
+	}
+
+	strike, vol, err := oc.VolByDelta(expiries[0], -0.25, exchange.OptionTypePut)
+	if err != nil {
+		t.Fatalf("vol by delta fail %s", err.Error())
+	}
+	o := &Option{Strike: strike, Type: exchange.OptionTypePut}
+	tt = float64(expiries[0].Sub(now)) / float64(yearDuration)
+	if g := o.Greeks(forwards[0], vol, tt, 0); !almostEqual(g.Delta, -0.25, 1e-3) || strike >= forwards[0] {
+		t.Errorf("bad 25 delta put strike %f vol %f delta %f", strike, vol, g.Delta)
+	}
+
+	if _, err := oc.Vol(now.Add(time.Hour*24*365), 30000); err == nil {
+		t.Errorf("expiry out of surface should fail")
+	}
+
+	// expired options are dropped
+	if err := oc.Refresh(expiries[0]); err != nil {
+		t.Fatalf("refresh fail %s", err.Error())
+	}
+	if exps := oc.Expiries(); len(exps) != 1 {
+		t.Errorf("expired expiry not dropped %v", exps)
+	}
+}
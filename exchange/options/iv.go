@@ -0,0 +1,90 @@
+package options
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+const (
+	ivMin       = 1e-4
+	ivMax       = 10.0
+	ivTolerance = 1e-10
+	ivMaxIter   = 100
+)
+
+var (
+	// ErrPriceOutOfBounds the price is below intrinsic value or above the upper bound, no
+	// volatility can produce it
+	ErrPriceOutOfBounds = errors.New("price out of no arbitrage bounds")
+)
+
+// ImpliedVol inverse the Black-76 price into volatility, price of inverse option is in coin.
+// newton iteration is used and fallback to bisection if it leave the bracket
+func (o *Option) ImpliedVol(price, forward, t, rate float64) (float64, error) {
+	if err := checkInput(forward, t); err != nil {
+		return 0, err
+	}
+
+	target := price
+	if o.Settlement == SettlementInverse {
+		target = price * forward
+	}
+
+	df := math.Exp(-rate * t)
+	lower := o.intrinsic(forward) * df
+	upper := forward * df
+	if o.Type == exchange.OptionTypePut {
+		upper = o.Strike * df
+	}
+	if target < lower-ivTolerance || target >= upper {
+		return 0, errors.WithMessagef(ErrPriceOutOfBounds, "price=%f lower=%f upper=%f", target, lower, upper)
+	}
+
+	lo, hi := ivMin, ivMax
+	if o.linearPrice(forward, lo, t, rate) >= target {
+		return lo, nil
+	}
+	if o.linearPrice(forward, hi, t, rate) <= target {
+		return 0, errors.WithMessagef(ErrPriceOutOfBounds, "price=%f above max volatility", target)
+	}
+
+	vol := initialVol(forward, o.Strike, target/df, t)
+	for i := 0; i < ivMaxIter; i++ {
+		if vol <= lo || vol >= hi || math.IsNaN(vol) {
+			vol = (lo + hi) / 2
+		}
+
+		diff := o.linearPrice(forward, vol, t, rate) - target
+		if math.Abs(diff) < ivTolerance {
+			return vol, nil
+		}
+		if diff > 0 {
+			hi = vol
+		} else {
+			lo = vol
+		}
+		if hi-lo < ivTolerance {
+			return vol, nil
+		}
+
+		d1, _ := d12(forward, o.Strike, vol, t)
+		vega := df * forward * normPDF(d1) * math.Sqrt(t)
+		if vega < 1e-12 {
+			vol = (lo + hi) / 2
+			continue
+		}
+		vol -= diff / vega
+	}
+	return 0, errors.Errorf("implied volatility not converge")
+}
+
+// initialVol Brenner-Subrahmanyam approximation as the start point of newton iteration
+func initialVol(forward, strike, undiscounted, t float64) float64 {
+	atm := math.Sqrt(2*math.Pi/t) * undiscounted / forward
+	if strike != forward {
+		atm += math.Abs(math.Log(forward/strike)) / math.Sqrt(t)
+	}
+	return atm
+}
@@ -0,0 +1,102 @@
+package options
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/szmcdull/ccexgo/exchange"
+)
+
+type (
+	// QuoteIV implied volatility of the quotes, 0 if the side has no quote
+	QuoteIV struct {
+		Bid  float64
+		Ask  float64
+		Mark float64
+		Time time.Time
+	}
+)
+
+// TickerIV compute bid, ask and mark implied volatility of the ticker
+func (o *Option) TickerIV(ticker *exchange.Ticker, forward float64, now time.Time, rate float64) (*QuoteIV, error) {
+	t := o.TimeToExpiry(now)
+	if err := checkInput(forward, t); err != nil {
+		return nil, err
+	}
+
+	bid, _ := ticker.BestBid.Float64()
+	ask, _ := ticker.BestAsk.Float64()
+	mark, _ := ticker.MarkPrice.Float64()
+
+	ret := &QuoteIV{
+		Time: ticker.Time,
+	}
+	var err error
+	if ret.Bid, err = o.quoteIV(bid, forward, t, rate); err != nil {
+		return nil, errors.WithMessage(err, "bid implied volatility")
+	}
+	if ret.Ask, err = o.quoteIV(ask, forward, t, rate); err != nil {
+		return nil, errors.WithMessage(err, "ask implied volatility")
+	}
+	if ret.Mark, err = o.quoteIV(mark, forward, t, rate); err != nil {
+		return nil, errors.WithMessage(err, "mark implied volatility")
+	}
+	return ret, nil
+}
+
+// OrderBookIV compute implied volatility of the best bid and ask, mark is the mid volatility
+func (o *Option) OrderBookIV(book *exchange.OrderBook, forward float64, now time.Time, rate float64) (*QuoteIV, error) {
+	t := o.TimeToExpiry(now)
+	if err := checkInput(forward, t); err != nil {
+		return nil, err
+	}
+
+	ret := &QuoteIV{
+		Time: book.Created,
+	}
+	var err error
+	if len(book.Bids) != 0 {
+		if ret.Bid, err = o.quoteIV(book.Bids[0].Price, forward, t, rate); err != nil {
+			return nil, errors.WithMessage(err, "bid implied volatility")
+		}
+	}
+	if len(book.Asks) != 0 {
+		if ret.Ask, err = o.quoteIV(book.Asks[0].Price, forward, t, rate); err != nil {
+			return nil, errors.WithMessage(err, "ask implied volatility")
+		}
+	}
+	ret.Mark = ret.Mid()
+	return ret, nil
+}
+
+// Mid return mid of bid and ask volatility, or the only side if the other is missing
+func (q *QuoteIV) Mid() float64 {
+	if q.Bid == 0 {
+		return q.Ask
+	}
+	if q.Ask == 0 {
+		return q.Bid
+	}
+	return (q.Bid + q.Ask) / 2
+}
+
+// IsStale flag the quote if it's older than maxAge, or the mark volatility deviate from
+// the reference, such as deribit mark_iv/100, by more than tolerance
+func (q *QuoteIV) IsStale(ref, tolerance float64, now time.Time, maxAge time.Duration) bool {
+	if maxAge > 0 && now.Sub(q.Time) > maxAge {
+		return true
+	}
+	if q.Mark == 0 {
+		return true
+	}
+	return math.Abs(q.Mark-ref) > tolerance
+}
+
+// quoteIV return 0 for empty quote
+func (o *Option) quoteIV(price, forward, t, rate float64) (float64, error) {
+	if price <= 0 {
+		return 0, nil
+	}
+	return o.ImpliedVol(price, forward, t, rate)
+}
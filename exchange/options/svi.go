@@ -0,0 +1,174 @@
+package options
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// SVI raw parameterization of total implied variance in log moneyness k=ln(K/F)
+	//   w(k) = A + B*(Rho*(k-M) + sqrt((k-M)^2 + Sigma^2))
+	SVI struct {
+		A     float64
+		B     float64
+		Rho   float64
+		M     float64
+		Sigma float64
+	}
+)
+
+const (
+	sviMinPoints   = 5
+	sviGridSize    = 20
+	sviRefineTimes = 6
+	sviSigmaMin    = 1e-3
+	sviSigmaMax    = 2.0
+)
+
+// TotalVariance return w(k)
+func (s *SVI) TotalVariance(k float64) float64 {
+	d := k - s.M
+	return s.A + s.B*(s.Rho*d+math.Sqrt(d*d+s.Sigma*s.Sigma))
+}
+
+// Vol return implied volatility at log moneyness k for t years to expiry
+func (s *SVI) Vol(k, t float64) float64 {
+	w := s.TotalVariance(k)
+	if w <= 0 || t <= 0 {
+		return 0
+	}
+	return math.Sqrt(w / t)
+}
+
+// FitSVI fit raw SVI to total variances w at log moneyness k. for fixed M and Sigma
+// the rest parameters are solved by linear least squares, and M, Sigma are searched
+// on a grid which is refined around the best point
+func FitSVI(k, w []float64) (*SVI, error) {
+	if len(k) != len(w) {
+		return nil, errors.Errorf("length mismatch k=%d w=%d", len(k), len(w))
+	}
+	if len(k) < sviMinPoints {
+		return nil, errors.Errorf("at least %d points required, got %d", sviMinPoints, len(k))
+	}
+
+	kMin, kMax := k[0], k[0]
+	for _, v := range k {
+		kMin = math.Min(kMin, v)
+		kMax = math.Max(kMax, v)
+	}
+
+	mLo, mHi := kMin, kMax
+	sLo, sHi := math.Log(sviSigmaMin), math.Log(sviSigmaMax)
+	var (
+		best    *SVI
+		bestErr = math.Inf(1)
+		bestM   float64
+		bestS   float64
+	)
+	for r := 0; r < sviRefineTimes; r++ {
+		for i := 0; i <= sviGridSize; i++ {
+			m := mLo + (mHi-mLo)*float64(i)/sviGridSize
+			for j := 0; j <= sviGridSize; j++ {
+				ls := sLo + (sHi-sLo)*float64(j)/sviGridSize
+				s, sse := fitSVILinear(k, w, m, math.Exp(ls))
+				if s != nil && sse < bestErr {
+					best, bestErr, bestM, bestS = s, sse, m, ls
+				}
+			}
+		}
+		if best == nil {
+			break
+		}
+
+		mStep := (mHi - mLo) / sviGridSize * 2
+		sStep := (sHi - sLo) / sviGridSize * 2
+		mLo, mHi = bestM-mStep, bestM+mStep
+		sLo, sHi = bestS-sStep, bestS+sStep
+	}
+
+	if best == nil {
+		return nil, errors.Errorf("no valid svi parameters")
+	}
+	return best, nil
+}
+
+// fitSVILinear solve w = a + d*y + c*z with y=(k-m)/sigma and z=sqrt(y^2+1), where
+// c = b*sigma and d = rho*b*sigma. nil is returned if the result violate b>=0 and |rho|<=1
+func fitSVILinear(k, w []float64, m, sigma float64) (*SVI, float64) {
+	var ata [3][3]float64
+	var atb [3]float64
+	for i := range k {
+		y := (k[i] - m) / sigma
+		row := [3]float64{1, y, math.Sqrt(y*y + 1)}
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				ata[r][c] += row[r] * row[c]
+			}
+			atb[r] += row[r] * w[i]
+		}
+	}
+
+	x, ok := solve3(ata, atb)
+	if !ok {
+		return nil, 0
+	}
+	a, d, c := x[0], x[1], x[2]
+	if c < 0 || math.Abs(d) > c || c == 0 {
+		return nil, 0
+	}
+
+	s := &SVI{
+		A:     a,
+		B:     c / sigma,
+		Rho:   d / c,
+		M:     m,
+		Sigma: sigma,
+	}
+	// total variance must be positive at the minimum
+	if s.A+s.B*s.Sigma*math.Sqrt(1-s.Rho*s.Rho) < 0 {
+		return nil, 0
+	}
+
+	var sse float64
+	for i := range k {
+		diff := s.TotalVariance(k[i]) - w[i]
+		sse += diff * diff
+	}
+	return s, sse
+}
+
+// solve3 solve 3x3 linear equations by gaussian elimination with partial pivoting
+func solve3(a [3][3]float64, b [3]float64) ([3]float64, bool) {
+	var x [3]float64
+	for col := 0; col < 3; col++ {
+		pivot := col
+		for r := col + 1; r < 3; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-14 {
+			return x, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for r := col + 1; r < 3; r++ {
+			f := a[r][col] / a[col][col]
+			for c := col; c < 3; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+			b[r] -= f * b[col]
+		}
+	}
+
+	for r := 2; r >= 0; r-- {
+		sum := b[r]
+		for c := r + 1; c < 3; c++ {
+			sum -= a[r][c] * x[c]
+		}
+		x[r] = sum / a[r][r]
+	}
+	return x, true
+}
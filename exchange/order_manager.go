@@ -0,0 +1,422 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	OrderEventType int
+
+	//OrderEvent emitted on order status transition. Filled is the cumulative filled
+	//amount and LastFilled is the amount filled since previous event
+	OrderEvent struct {
+		Type       OrderEventType
+		Order      *Order
+		Filled     decimal.Decimal
+		LastFilled decimal.Decimal
+		Time       time.Time
+	}
+
+	//OrderFetcher fetch order state with rest api
+	OrderFetcher interface {
+		FetchOrder(ctx context.Context, order *Order) (*Order, error)
+	}
+
+	//OrderManager track orders by client id, apply updates of private stream and rest
+	//api with legal status transitions. orders in OrderStatusUnknown, not updated
+	//within timeout or open during disconnection are reconciled with FetchOrder
+	OrderManager struct {
+		emitMu  sync.Mutex //serialize transitions with their events, held before mu
+		mu      sync.Mutex
+		fetcher OrderFetcher
+		timeout time.Duration
+		events  chan *OrderEvent
+		orders  map[string]*managedOrder
+		ids     map[string]string
+	}
+
+	managedOrder struct {
+		order   *Order
+		updated time.Time
+		stale   bool
+	}
+)
+
+const (
+	OrderEventAccepted OrderEventType = iota
+	OrderEventPartiallyFilled
+	OrderEventFilled
+	OrderEventCancelled
+	OrderEventRejected
+)
+
+var (
+	oetMap = map[OrderEventType]string{
+		OrderEventAccepted:        "accepted",
+		OrderEventPartiallyFilled: "partiallyFilled",
+		OrderEventFilled:          "filled",
+		OrderEventCancelled:       "cancelled",
+		OrderEventRejected:        "rejected",
+	}
+
+	ostMap = map[OrderStatus]string{
+		OrderStatusUnknown: "unknown",
+		OrderStatusOpen:    "open",
+		OrderStatusDone:    "done",
+		OrderStatusCancel:  "cancel",
+		OrderStatusFailed:  "failed",
+	}
+)
+
+func (t OrderEventType) String() string {
+	return oetMap[t]
+}
+
+func (s OrderStatus) String() string {
+	return ostMap[s]
+}
+
+//Final whether the order will not change any more
+func (s OrderStatus) Final() bool {
+	return s == OrderStatusDone || s == OrderStatusCancel || s == OrderStatusFailed
+}
+
+//NewOrderManager create order manager, events are sent to the channel which
+//should be consumed, otherwise updates are blocked
+func NewOrderManager(fetcher OrderFetcher, timeout time.Duration, events chan *OrderEvent) *OrderManager {
+	return &OrderManager{
+		fetcher: fetcher,
+		timeout: timeout,
+		events:  events,
+		orders:  make(map[string]*managedOrder),
+		ids:     make(map[string]string),
+	}
+}
+
+//Track register the request before it's sent, the order is in OrderStatusUnknown
+//until accepted or rejected
+func (om *OrderManager) Track(req *OrderRequest) (*Order, error) {
+	if req.ClientID == nil || req.ClientID.String() == "" {
+		return nil, NewBadArg("client id is required", req)
+	}
+
+	cid := req.ClientID.String()
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	if _, ok := om.orders[cid]; ok {
+		return nil, errors.Errorf("duplicate client id '%s'", cid)
+	}
+
+	now := time.Now()
+	order := &Order{
+		ClientID: req.ClientID,
+		Symbol:   req.Symbol,
+		Amount:   req.Amount,
+		Price:    req.Price,
+		Side:     req.Side,
+		Type:     req.Type,
+		Status:   OrderStatusUnknown,
+		Created:  now,
+		Updated:  now,
+	}
+	om.orders[cid] = &managedOrder{order: order, updated: now}
+	ret := *order
+	return &ret, nil
+}
+
+//Reject mark the tracked order as rejected, which is used when the request is
+//refused by the exchange definitely
+func (om *OrderManager) Reject(clientID string) {
+	om.emitMu.Lock()
+	defer om.emitMu.Unlock()
+
+	om.mu.Lock()
+	mo, ok := om.orders[clientID]
+	if !ok {
+		om.mu.Unlock()
+		return
+	}
+
+	update := *mo.order
+	update.Status = OrderStatusFailed
+	update.Updated = time.Now()
+	if !legalTransition(mo.order, &update) {
+		om.mu.Unlock()
+		return
+	}
+	events := om.transit(mo, &update)
+	om.mu.Unlock()
+	om.emit(events)
+}
+
+//Handle apply order updates carried by notify data, which can be *WSNotify, *Order,
+//[]*Order or OrderUpdater. orders not tracked are ignored
+func (om *OrderManager) Handle(data interface{}) {
+	if notify, ok := data.(*WSNotify); ok {
+		data = notify.Data
+	}
+
+	for _, o := range orderUpdates(data) {
+		om.Update(o)
+	}
+}
+
+//Update apply order update, return false if the order is not tracked or the status
+//transition is illegal
+func (om *OrderManager) Update(order *Order) bool {
+	om.emitMu.Lock()
+	defer om.emitMu.Unlock()
+
+	om.mu.Lock()
+	mo := om.lookup(order)
+	if mo == nil {
+		om.mu.Unlock()
+		return false
+	}
+
+	if !legalTransition(mo.order, order) {
+		om.mu.Unlock()
+		return false
+	}
+
+	events := om.transit(mo, order)
+	om.mu.Unlock()
+	om.emit(events)
+	return true
+}
+
+//Disconnected mark unfinished orders to be reconciled, updates may be lost during
+//disconnection
+func (om *OrderManager) Disconnected() {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+	for _, mo := range om.orders {
+		if !mo.order.Status.Final() {
+			mo.stale = true
+		}
+	}
+}
+
+//Reconcile fetch orders in OrderStatusUnknown, stale after disconnection or not
+//updated within timeout, the first error is returned after all orders checked.
+//order in OrderStatusUnknown which is not found after timeout is rejected since
+//the request never reached the exchange
+func (om *OrderManager) Reconcile(ctx context.Context) error {
+	om.mu.Lock()
+	var orders []*Order
+	now := time.Now()
+	for _, mo := range om.orders {
+		if mo.order.Status.Final() {
+			continue
+		}
+
+		if mo.order.Status == OrderStatusUnknown || mo.stale || now.Sub(mo.updated) > om.timeout {
+			o := *mo.order
+			orders = append(orders, &o)
+		}
+	}
+	om.mu.Unlock()
+
+	var ret error
+	for _, o := range orders {
+		update, err := om.fetcher.FetchOrder(ctx, o)
+		if err != nil {
+			if o.Status == OrderStatusUnknown && errors.Is(err, ErrOrderNotFound) {
+				if time.Since(o.Created) > om.timeout {
+					om.Reject(o.ClientID.String())
+				}
+				//the request may be still in flight
+				continue
+			}
+			if ret == nil {
+				ret = errors.WithMessagef(err, "fetch order '%s' fail", o.ClientID.String())
+			}
+			continue
+		}
+
+		//rest api may not carry client id
+		if update.ClientID == nil {
+			update.ClientID = o.ClientID
+		}
+		om.Update(update)
+
+		om.mu.Lock()
+		if mo, ok := om.orders[o.ClientID.String()]; ok {
+			mo.stale = false
+			mo.updated = time.Now()
+		}
+		om.mu.Unlock()
+	}
+	return ret
+}
+
+//Run reconcile orders periodically until ctx done
+func (om *OrderManager) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-ticker.C:
+			om.Reconcile(ctx)
+		}
+	}
+}
+
+//Order return copy of the order tracked
+func (om *OrderManager) Order(clientID string) (*Order, bool) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	mo, ok := om.orders[clientID]
+	if !ok {
+		return nil, false
+	}
+	ret := *mo.order
+	return &ret, true
+}
+
+//OpenOrders return copy of orders not finished
+func (om *OrderManager) OpenOrders() []*Order {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	var ret []*Order
+	for _, mo := range om.orders {
+		if !mo.order.Status.Final() {
+			o := *mo.order
+			ret = append(ret, &o)
+		}
+	}
+	return ret
+}
+
+//Remove stop tracking the finished order
+func (om *OrderManager) Remove(clientID string) {
+	om.mu.Lock()
+	defer om.mu.Unlock()
+
+	mo, ok := om.orders[clientID]
+	if !ok || !mo.order.Status.Final() {
+		return
+	}
+	if mo.order.ID != nil {
+		delete(om.ids, mo.order.ID.String())
+	}
+	delete(om.orders, clientID)
+}
+
+func (om *OrderManager) lookup(order *Order) *managedOrder {
+	if order.ClientID != nil {
+		if mo, ok := om.orders[order.ClientID.String()]; ok {
+			return mo
+		}
+	}
+
+	if order.ID != nil {
+		if cid, ok := om.ids[order.ID.String()]; ok {
+			return om.orders[cid]
+		}
+	}
+	return nil
+}
+
+//transit apply the update and return events of the transition
+func (om *OrderManager) transit(mo *managedOrder, update *Order) []*OrderEvent {
+	old := mo.order
+	order := *update
+	order.ClientID = old.ClientID
+	if order.ID == nil {
+		order.ID = old.ID
+	}
+	if order.Symbol == nil {
+		order.Symbol = old.Symbol
+	}
+	if order.ID != nil {
+		om.ids[order.ID.String()] = old.ClientID.String()
+	}
+
+	mo.order = &order
+	mo.updated = time.Now()
+
+	var events []*OrderEvent
+	newEvent := func(typ OrderEventType) {
+		o := order
+		events = append(events, &OrderEvent{
+			Type:       typ,
+			Order:      &o,
+			Filled:     order.Filled,
+			LastFilled: order.Filled.Sub(old.Filled),
+			Time:       mo.updated,
+		})
+	}
+
+	if old.Status == OrderStatusUnknown && order.Status != OrderStatusUnknown && order.Status != OrderStatusFailed {
+		newEvent(OrderEventAccepted)
+	}
+
+	switch order.Status {
+	case OrderStatusOpen:
+		if order.Filled.GreaterThan(old.Filled) {
+			newEvent(OrderEventPartiallyFilled)
+		}
+
+	case OrderStatusDone:
+		newEvent(OrderEventFilled)
+
+	case OrderStatusCancel:
+		if order.Filled.GreaterThan(old.Filled) {
+			newEvent(OrderEventPartiallyFilled)
+		}
+		newEvent(OrderEventCancelled)
+
+	case OrderStatusFailed:
+		newEvent(OrderEventRejected)
+	}
+	return events
+}
+
+//emit send events with emitMu held, so events of concurrent updates keep the order
+//of the transitions. mu is released to not block readers by the consumer
+func (om *OrderManager) emit(events []*OrderEvent) {
+	if om.events == nil {
+		return
+	}
+	for _, e := range events {
+		om.events <- e
+	}
+}
+
+//legalTransition check status transition and filled amount is not decreased.
+//final status can not be changed and only unknown order can be rejected
+func legalTransition(old *Order, update *Order) bool {
+	if old.Status.Final() {
+		return false
+	}
+
+	if update.Filled.LessThan(old.Filled) {
+		return false
+	}
+
+	switch update.Status {
+	case OrderStatusUnknown:
+		return false
+
+	case OrderStatusFailed:
+		return old.Status == OrderStatusUnknown
+
+	case OrderStatusOpen:
+		//duplicated update without progress
+		return old.Status == OrderStatusUnknown || update.Filled.GreaterThan(old.Filled) ||
+			!update.Updated.Before(old.Updated)
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+)
+
+type testOrderFetcher struct {
+	order *Order
+	err   error
+}
+
+func (tf *testOrderFetcher) FetchOrder(ctx context.Context, order *Order) (*Order, error) {
+	if tf.err != nil {
+		return nil, tf.err
+	}
+	ret := *tf.order
+	return &ret, nil
+}
+
+func TestOrderManager(t *testing.T) {
+	events := make(chan *OrderEvent, 16)
+	fetcher := &testOrderFetcher{}
+	om := NewOrderManager(fetcher, time.Minute, events)
+
+	req := &OrderRequest{ClientID: NewStrID("c1"), Amount: decimal.NewFromInt(10)}
+	if _, err := om.Track(req); err != nil {
+		t.Fatalf("track fail %s", err.Error())
+	}
+	if _, err := om.Track(req); err == nil {
+		t.Errorf("duplicate client id should fail")
+	}
+
+	//create order timeout, reconcile with rest api
+	now := time.Now()
+	fetcher.order = &Order{ID: NewStrID("1"), Status: OrderStatusOpen, Filled: decimal.NewFromInt(2), Updated: now}
+	if err := om.Reconcile(context.Background()); err != nil {
+		t.Fatalf("reconcile fail %s", err.Error())
+	}
+
+	//updates by order id only
+	if !om.Update(&Order{ID: NewStrID("1"), Status: OrderStatusOpen, Filled: decimal.NewFromInt(6), Updated: now}) {
+		t.Errorf("update fail")
+	}
+	if om.Update(&Order{ID: NewStrID("1"), Status: OrderStatusOpen, Filled: decimal.NewFromInt(4), Updated: now}) {
+		t.Errorf("stale update should be ignored")
+	}
+	om.Handle(&WSNotify{Data: &Order{ID: NewStrID("1"), Status: OrderStatusDone, Filled: decimal.NewFromInt(10), Updated: now}})
+	if om.Update(&Order{ID: NewStrID("1"), Status: OrderStatusCancel, Filled: decimal.NewFromInt(10), Updated: now}) {
+		t.Errorf("final status should not be changed")
+	}
+
+	expects := []struct {
+		typ    OrderEventType
+		filled int64
+		last   int64
+	}{
+		{OrderEventAccepted, 2, 2},
+		{OrderEventPartiallyFilled, 2, 2},
+		{OrderEventPartiallyFilled, 6, 4},
+		{OrderEventFilled, 10, 4},
+	}
+	if len(events) != len(expects) {
+		t.Fatalf("bad events count %d", len(events))
+	}
+	for _, e := range expects {
+		ev := <-events
+		if ev.Type != e.typ || !ev.Filled.Equal(decimal.NewFromInt(e.filled)) ||
+			!ev.LastFilled.Equal(decimal.NewFromInt(e.last)) || ev.Order.ClientID.String() != "c1" {
+			t.Errorf("bad event %s %+v", ev.Type, *ev)
+		}
+	}
+
+	if orders := om.OpenOrders(); len(orders) != 0 {
+		t.Errorf("bad open orders %+v", orders)
+	}
+}
+
+func TestOrderManagerNotFound(t *testing.T) {
+	events := make(chan *OrderEvent, 16)
+	fetcher := &testOrderFetcher{err: errors.WithMessage(ErrOrderNotFound, "fetch order")}
+	om := NewOrderManager(fetcher, 10*time.Millisecond, events)
+
+	if _, err := om.Track(&OrderRequest{ClientID: NewStrID("c1"), Amount: decimal.NewFromInt(1)}); err != nil {
+		t.Fatalf("track fail %s", err.Error())
+	}
+
+	//the request may be still in flight
+	if err := om.Reconcile(context.Background()); err != nil || len(events) != 0 {
+		t.Fatalf("order should be kept before timeout %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if err := om.Reconcile(context.Background()); err != nil {
+		t.Fatalf("reconcile fail %s", err.Error())
+	}
+	if len(events) != 1 {
+		t.Fatalf("bad events count %d", len(events))
+	}
+	if ev := <-events; ev.Type != OrderEventRejected {
+		t.Errorf("order not found after timeout should be rejected %s", ev.Type)
+	}
+	if o, _ := om.Order("c1"); o.Status != OrderStatusFailed {
+		t.Errorf("bad status %s", o.Status)
+	}
+}
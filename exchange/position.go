@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"context"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -26,6 +27,18 @@ type (
 		Leverage         decimal.Decimal
 		Raw              interface{}
 	}
+
+	//MarginManager manage leverage, margin mode and position mode of derivatives.
+	//hedge position mode hold long and short position of a symbol separately.
+	//ErrPositionExists is returned if the change is blocked by positions or orders
+	MarginManager interface {
+		SetLeverage(ctx context.Context, sym Symbol, leverage decimal.Decimal) error
+		GetLeverage(ctx context.Context, sym Symbol) (decimal.Decimal, error)
+		SetMarginMode(ctx context.Context, sym Symbol, mode PositionMode) error
+		GetMarginMode(ctx context.Context, sym Symbol) (PositionMode, error)
+		SetPositionMode(ctx context.Context, hedge bool) error
+		GetPositionMode(ctx context.Context) (bool, error)
+	}
 )
 
 const (
@@ -0,0 +1,115 @@
+package exchange
+
+import (
+	"fmt"
+)
+
+type (
+	//PositionOffset whether the order open or close position
+	PositionOffset int
+
+	//TradeSide explicit model of OrderSide, the order open or close the position of
+	//PositionSide. Hedge means the account hold long and short position separately,
+	//in one way mode closing order is sent as reduce only
+	TradeSide struct {
+		Offset       PositionOffset
+		PositionSide PositionSide
+		Hedge        bool
+	}
+)
+
+const (
+	PositionOffsetOpen PositionOffset = iota
+	PositionOffsetClose
+)
+
+//NewTradeSide translate OrderSide under the position mode. buy and sell open long
+//and short position, close long and close short reduce the position
+func NewTradeSide(side OrderSide, hedge bool) (*TradeSide, error) {
+	ret := &TradeSide{
+		Hedge: hedge,
+	}
+
+	switch side {
+	case OrderSideBuy:
+		ret.PositionSide = PositionSideLong
+
+	case OrderSideSell:
+		ret.PositionSide = PositionSideShort
+
+	case OrderSideCloseLong:
+		ret.Offset = PositionOffsetClose
+		ret.PositionSide = PositionSideLong
+
+	case OrderSideCloseShort:
+		ret.Offset = PositionOffsetClose
+		ret.PositionSide = PositionSideShort
+
+	default:
+		return nil, NewBadArg("unknown order side", side)
+	}
+	return ret, nil
+}
+
+//ParseTradeSide translate order direction of exchange back. in hedge mode posSide
+//decide which position the order work on, in one way mode reduce only order close
+//the position of the opposite side
+func ParseTradeSide(buy bool, hedge bool, posSide PositionSide, reduceOnly bool) *TradeSide {
+	ret := &TradeSide{
+		Hedge: hedge,
+	}
+
+	if hedge {
+		ret.PositionSide = posSide
+		if buy != (posSide == PositionSideLong) {
+			ret.Offset = PositionOffsetClose
+		}
+		return ret
+	}
+
+	if buy != reduceOnly {
+		ret.PositionSide = PositionSideLong
+	} else {
+		ret.PositionSide = PositionSideShort
+	}
+	if reduceOnly {
+		ret.Offset = PositionOffsetClose
+	}
+	return ret
+}
+
+//IsBuy return the order direction, which is buy for opening long or closing short position
+func (ts *TradeSide) IsBuy() bool {
+	return (ts.Offset == PositionOffsetOpen) == (ts.PositionSide == PositionSideLong)
+}
+
+//ReduceOnly whether the order should be sent as reduce only
+func (ts *TradeSide) ReduceOnly() bool {
+	return !ts.Hedge && ts.Offset == PositionOffsetClose
+}
+
+//OrderSide translate back to OrderSide
+func (ts *TradeSide) OrderSide() OrderSide {
+	if ts.Offset == PositionOffsetOpen {
+		if ts.PositionSide == PositionSideLong {
+			return OrderSideBuy
+		}
+		return OrderSideSell
+	}
+
+	if ts.PositionSide == PositionSideLong {
+		return OrderSideCloseLong
+	}
+	return OrderSideCloseShort
+}
+
+func (ts *TradeSide) String() string {
+	return fmt.Sprintf("%s %s", ts.Offset.String(), ts.PositionSide.String())
+}
+
+func (po PositionOffset) String() string {
+	if po == PositionOffsetClose {
+		return "close"
+	}
+	return "open"
+}
@@ -0,0 +1,38 @@
+package exchange
+
+import "testing"
+
+func TestTradeSide(t *testing.T) {
+	sides := []OrderSide{OrderSideBuy, OrderSideSell, OrderSideCloseLong, OrderSideCloseShort}
+	buys := []bool{true, false, false, true}
+	reduceOnly := map[bool][]bool{
+		true:  {false, false, false, false},
+		false: {false, false, true, true},
+	}
+
+	for _, hedge := range []bool{true, false} {
+		for i, side := range sides {
+			ts, err := NewTradeSide(side, hedge)
+			if err != nil {
+				t.Fatalf("new trade side fail %s", err.Error())
+			}
+
+			if ts.IsBuy() != buys[i] {
+				t.Errorf("bad direction side=%s hedge=%v", side, hedge)
+			}
+
+			if ts.ReduceOnly() != reduceOnly[hedge][i] {
+				t.Errorf("bad reduce only side=%s hedge=%v", side, hedge)
+			}
+
+			back := ParseTradeSide(ts.IsBuy(), hedge, ts.PositionSide, ts.ReduceOnly())
+			if back.OrderSide() != side {
+				t.Errorf("round trip fail side=%s hedge=%v got=%s", side, hedge, back.OrderSide())
+			}
+		}
+	}
+
+	if _, err := NewTradeSide(OrderSide(-1), false); err == nil {
+		t.Errorf("expect error for unknown side")
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"net/http"
 )
 
+type (
+	acquiredKey struct{}
+)
+
 var (
 	client *http.Client
 )
@@ -39,3 +43,27 @@ func DoReqWithCtx(req *http.Request, f func(*http.Response, error) error) error
 		return err
 	}
 }
+
+//WithAcquired mark the request of ctx is already charged by Limiter.TryAcquire, so
+//DoWithLimiter does not wait and charge it again
+func WithAcquired(ctx context.Context) context.Context {
+	return context.WithValue(ctx, acquiredKey{}, true)
+}
+
+//DoWithLimiter like DoReqWithCtx except waiting for the limiter before the request
+//and applying the response feedback to the limiter, the url path is the endpoint.
+//waiting is skipped if the request context is marked by WithAcquired
+func DoWithLimiter(l *Limiter, req *http.Request, f func(*http.Response, error) error) error {
+	if acquired, _ := req.Context().Value(acquiredKey{}).(bool); !acquired {
+		if err := l.Wait(req.Context(), req.Method, req.URL.Path); err != nil {
+			return err
+		}
+	}
+
+	return DoReqWithCtx(req, func(resp *http.Response, err error) error {
+		if err == nil {
+			l.Update(resp)
+		}
+		return f(resp, err)
+	})
+}
@@ -0,0 +1,246 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	//Rule at most Limit weight can be consumed within each Interval
+	Rule struct {
+		Name     string
+		Limit    int
+		Interval time.Duration
+	}
+
+	//Cost weight consumed from each rule by a request, keyed by rule name
+	Cost map[string]int
+
+	//Limiter throttle requests with limit tables of a venue. endpoints not configured
+	//consume the default cost. usage reported by response headers and Retry-After are
+	//applied, and 429/418 status without Retry-After backoff exponentially. a nil
+	//Limiter does not throttle, share one Limiter among clients of the same ip or uid
+	Limiter struct {
+		mu      sync.Mutex
+		windows map[string]*window
+		costs   map[string]Cost
+		def     Cost
+		headers map[string]string
+
+		minBackoff time.Duration
+		maxBackoff time.Duration
+		backoff    time.Duration
+		until      time.Time
+	}
+
+	window struct {
+		rule  Rule
+		used  int
+		start time.Time
+	}
+)
+
+const (
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+//NewLimiter create limiter with the rules, default cost is 1 of each rule
+func NewLimiter(rules ...Rule) *Limiter {
+	ret := &Limiter{
+		windows:    make(map[string]*window),
+		costs:      make(map[string]Cost),
+		def:        Cost{},
+		headers:    make(map[string]string),
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+	for _, r := range rules {
+		ret.windows[r.Name] = &window{rule: r}
+		ret.def[r.Name] = 1
+	}
+	return ret
+}
+
+//Default set cost of endpoints not configured
+func (l *Limiter) Default(cost Cost) *Limiter {
+	l.def = cost
+	return l
+}
+
+//Endpoint set cost of the endpoint, empty method matches all methods
+func (l *Limiter) Endpoint(method string, endPoint string, cost Cost) *Limiter {
+	l.costs[method+" "+endPoint] = cost
+	return l
+}
+
+//EndpointLimit add rule named by the endpoint which limit only the endpoint
+func (l *Limiter) EndpointLimit(method string, endPoint string, limit int, interval time.Duration) *Limiter {
+	name := method + " " + endPoint
+	l.windows[name] = &window{rule: Rule{Name: name, Limit: limit, Interval: interval}}
+	return l.Endpoint(method, endPoint, Cost{name: 1})
+}
+
+//Header apply used weight reported by the response header to the rule
+func (l *Limiter) Header(header string, rule string) *Limiter {
+	l.headers[header] = rule
+	return l
+}
+
+//Backoff set range of the adaptive backoff
+func (l *Limiter) Backoff(min, max time.Duration) *Limiter {
+	l.minBackoff = min
+	l.maxBackoff = max
+	return l
+}
+
+//Wait block until the request is allowed or ctx done
+func (l *Limiter) Wait(ctx context.Context, method string, endPoint string) error {
+	if l == nil {
+		return nil
+	}
+
+	for {
+		d := l.acquire(time.Now(), method, endPoint)
+		if d <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+
+		case <-timer.C:
+		}
+	}
+}
+
+//TryAcquire consume the cost if the request is allowed now without waiting, send
+//the request with ctx marked by WithAcquired to avoid being charged twice
+func (l *Limiter) TryAcquire(method string, endPoint string) bool {
+	if l == nil {
+		return true
+	}
+	return l.acquire(time.Now(), method, endPoint) <= 0
+}
+
+//Update apply feedback of the response
+func (l *Limiter) Update(resp *http.Response) {
+	if l == nil || resp == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for header, name := range l.headers {
+		val := resp.Header.Get(header)
+		if val == "" {
+			continue
+		}
+		used, err := strconv.Atoi(val)
+		if err != nil {
+			continue
+		}
+		if w, ok := l.windows[name]; ok {
+			w.reset(now)
+			//usage of other processes sharing the ip or uid is included
+			if used > w.used {
+				w.used = used
+			}
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		d := retryAfter(resp, now)
+		if d <= 0 {
+			if l.backoff < l.minBackoff {
+				l.backoff = l.minBackoff
+			} else if l.backoff *= 2; l.backoff > l.maxBackoff {
+				l.backoff = l.maxBackoff
+			}
+			d = l.backoff
+		}
+		if until := now.Add(d); until.After(l.until) {
+			l.until = until
+		}
+
+	default:
+		if resp.StatusCode < http.StatusBadRequest {
+			l.backoff = 0
+		}
+	}
+}
+
+//acquire consume the cost and return 0, or the duration to wait without consuming
+func (l *Limiter) acquire(now time.Time, method string, endPoint string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.until) {
+		return l.until.Sub(now)
+	}
+
+	cost, ok := l.costs[method+" "+endPoint]
+	if !ok {
+		cost, ok = l.costs[" "+endPoint]
+	}
+	if !ok {
+		cost = l.def
+	}
+
+	var wait time.Duration
+	for name, n := range cost {
+		w, ok := l.windows[name]
+		if !ok || n <= 0 {
+			continue
+		}
+		w.reset(now)
+		//request heavier than the limit is allowed in an empty window
+		if w.used+n > w.rule.Limit && w.used > 0 {
+			if d := w.start.Add(w.rule.Interval).Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+
+	for name, n := range cost {
+		if w, ok := l.windows[name]; ok {
+			w.used += n
+		}
+	}
+	return 0
+}
+
+//reset start a new window aligned to the interval, which is how most venues count
+func (w *window) reset(now time.Time) {
+	if now.Before(w.start.Add(w.rule.Interval)) {
+		return
+	}
+	w.start = now.Truncate(w.rule.Interval)
+	w.used = 0
+}
+
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	val := resp.Header.Get("Retry-After")
+	if val == "" {
+		return 0
+	}
+
+	if sec, err := strconv.Atoi(val); err == nil {
+		return time.Duration(sec) * time.Second
+	}
+	if ts, err := http.ParseTime(val); err == nil {
+		return ts.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(Rule{Name: "weight", Limit: 10, Interval: time.Hour}).
+		Endpoint(http.MethodGet, "/account", Cost{"weight": 5}).
+		EndpointLimit(http.MethodPost, "/order", 1, time.Hour).
+		Header("X-USED-WEIGHT", "weight")
+
+	if !l.TryAcquire(http.MethodGet, "/account") || !l.TryAcquire(http.MethodGet, "/ticker") {
+		t.Fatalf("acquire fail")
+	}
+	if l.TryAcquire(http.MethodGet, "/account") {
+		t.Errorf("weight exceeded")
+	}
+	if !l.TryAcquire(http.MethodPost, "/order") || l.TryAcquire(http.MethodPost, "/order") {
+		t.Errorf("endpoint limit not applied")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, http.MethodPost, "/order"); err != context.DeadlineExceeded {
+		t.Errorf("wait should be canceled %v", err)
+	}
+
+	//usage reported by server
+	l = NewLimiter(Rule{Name: "weight", Limit: 10, Interval: time.Hour}).Header("X-USED-WEIGHT", "weight")
+	l.Update(&http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Used-Weight": []string{"10"}}})
+	if l.TryAcquire(http.MethodGet, "/ticker") {
+		t.Errorf("header usage not applied")
+	}
+
+	var nl *Limiter
+	if !nl.TryAcquire(http.MethodGet, "/ticker") || nl.Wait(context.Background(), http.MethodGet, "/ticker") != nil {
+		t.Errorf("nil limiter should not limit")
+	}
+}
+
+func TestLimiterBackoff(t *testing.T) {
+	l := NewLimiter().Backoff(time.Minute, 4*time.Minute)
+	l.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	if l.TryAcquire(http.MethodGet, "/ticker") || l.backoff != time.Minute {
+		t.Errorf("backoff not applied %s", l.backoff)
+	}
+
+	l.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	l.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	l.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	if l.backoff != 4*time.Minute {
+		t.Errorf("backoff should be capped %s", l.backoff)
+	}
+
+	l.Update(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	if l.backoff != 0 {
+		t.Errorf("backoff should be reset")
+	}
+
+	l = NewLimiter()
+	l.Update(&http.Response{StatusCode: http.StatusTeapot, Header: http.Header{"Retry-After": []string{"120"}}})
+	d := l.acquire(time.Now(), http.MethodGet, "/ticker")
+	if d <= time.Minute || d > 2*time.Minute {
+		t.Errorf("bad retry after %s", d)
+	}
+}
+
+func TestDoWithLimiterAcquired(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	l := NewLimiter(Rule{Name: "weight", Limit: 1, Interval: time.Hour})
+	if !l.TryAcquire(http.MethodGet, "/ticker") {
+		t.Fatalf("acquire fail")
+	}
+
+	do := func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/ticker", nil)
+		return DoWithLimiter(l, req, func(resp *http.Response, err error) error {
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		})
+	}
+	if err := do(WithAcquired(context.Background())); err != nil {
+		t.Errorf("acquired request should not wait %v", err)
+	}
+	if err := do(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("request should wait for the limiter %v", err)
+	}
+}